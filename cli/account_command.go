@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/choria-io/fisk"
@@ -44,12 +45,20 @@ type actCmd struct {
 	snapShotConsumers bool
 	force             bool
 	failOnWarn        bool
+	configOnly        bool
+	update            bool
 
 	placementCluster string
 	placementTags    []string
 	reverse          bool
+
+	tiers bool
+	json  bool
 }
 
+// tierLimitWarnPercent is the usage of a tier limit considered close to exhausting the limit
+const tierLimitWarnPercent = 90
+
 func configureActCommand(app commandHost) {
 	c := &actCmd{}
 	act := app.Command("account", "Account information and status").Alias("a")
@@ -66,17 +75,23 @@ func configureActCommand(app commandHost) {
 
 	report.Command("statistics", "Report on server statistics").Alias("stats").Alias("statsz").Action(c.reportServerStats)
 
+	js := report.Command("jetstream", "Report on JetStream usage compared to the account limits").Alias("js").Action(c.reportJetStreamAction)
+	js.Flag("tiers", "Show usage and limits for every tier of accounts using tiered limits").UnNegatableBoolVar(&c.tiers)
+	js.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+
 	backup := act.Command("backup", "Creates a backup of all  JetStream Streams over the NATS network").Alias("snapshot").Action(c.backupAction)
 	backup.Arg("target", "Directory to create the backup in").Required().StringVar(&c.backupDirectory)
 	backup.Flag("check", "Checks the Stream for health prior to backup").UnNegatableBoolVar(&c.healthCheck)
 	backup.Flag("consumers", "Enable or disable consumer backups").Default("true").BoolVar(&c.snapShotConsumers)
 	backup.Flag("force", "Perform backup without prompting").Short('f').UnNegatableBoolVar(&c.force)
 	backup.Flag("critical-warnings", "Treat warnings as failures").Short('w').UnNegatableBoolVar(&c.failOnWarn)
+	backup.Flag("config-only", "Saves only Stream and Consumer configuration, no data").UnNegatableBoolVar(&c.configOnly)
 
 	restore := act.Command("restore", "Restore an account backup over the NATS network").Action(c.restoreAction)
 	restore.Arg("directory", "The directory holding the account backup to restore").Required().ExistingDirVar(&c.backupDirectory)
 	restore.Flag("cluster", "Place the stream in a specific cluster").StringVar(&c.placementCluster)
 	restore.Flag("tag", "Place the stream on servers that has specific tags (pass multiple times)").StringsVar(&c.placementTags)
+	restore.Flag("update", "Updates existing Streams and Consumers when restoring a configuration only backup").UnNegatableBoolVar(&c.update)
 
 	configureAccountTLSCommand(act)
 }
@@ -91,6 +106,10 @@ func (c *actCmd) backupAction(_ *fisk.ParseContext) error {
 	_, mgr, err := prepareHelper("", natsOpts()...)
 	fisk.FatalIfError(err, "setup failed")
 
+	if c.configOnly {
+		return c.backupConfig(mgr)
+	}
+
 	streams, missing, err := mgr.Streams(nil)
 	if err != nil {
 		return err
@@ -177,6 +196,15 @@ func (c *actCmd) backupAction(_ *fisk.ParseContext) error {
 func (c *actCmd) restoreAction(kp *fisk.ParseContext) error {
 	_, mgr, err := prepareHelper("", natsOpts()...)
 	fisk.FatalIfError(err, "setup failed")
+
+	bundle := filepath.Join(c.backupDirectory, accountConfigBundleFile)
+	if iu.FileExists(bundle) {
+		return c.restoreConfig(mgr, bundle)
+	}
+	if c.update {
+		return fmt.Errorf("--update is only supported when restoring configuration only backups")
+	}
+
 	streams, err := mgr.StreamNames(nil)
 	if err != nil {
 		return err
@@ -283,6 +311,121 @@ func (c *actCmd) reportServerStats(_ *fisk.ParseContext) error {
 	return nil
 }
 
+func (c *actCmd) reportJetStreamAction(_ *fisk.ParseContext) error {
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	if err != nil {
+		return err
+	}
+
+	info, err := mgr.JetStreamAccountInfo()
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		iu.PrintJSON(info)
+		return nil
+	}
+
+	tiered := len(info.Tiers) > 0
+
+	var names []string
+	switch {
+	case !tiered:
+		names = []string{"Default"}
+	case c.tiers:
+		for n := range info.Tiers {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+	default:
+		names = []string{"Total"}
+	}
+
+	title := "JetStream Account Usage"
+	if info.Domain != "" {
+		title = fmt.Sprintf("JetStream Account Usage in domain %s", info.Domain)
+	}
+
+	table := iu.NewTableWriter(opts(), title)
+	table.AddHeaders("Tier", "Streams", "Consumers", "Memory", "Memory Reserved", "Storage", "Storage Reserved", "Near Limits")
+
+	for _, n := range names {
+		tier := info.JetStreamTier
+		if tiered && c.tiers {
+			tier = info.Tiers[n]
+		}
+
+		// totals of tiered accounts do not have limits
+		limited := !tiered || c.tiers
+
+		table.AddRow(
+			n,
+			tierLimitUsage(uint64(tier.Streams), int64(tier.Limits.MaxStreams), limited, false),
+			tierLimitUsage(uint64(tier.Consumers), int64(tier.Limits.MaxConsumers), limited, false),
+			tierLimitUsage(tier.Memory, tier.Limits.MaxMemory, limited, true),
+			humanize.IBytes(tier.ReservedMemory),
+			tierLimitUsage(tier.Store, tier.Limits.MaxStore, limited, true),
+			humanize.IBytes(tier.ReservedStore),
+			strings.Join(tierNearLimits(tier, limited), ", "),
+		)
+	}
+
+	fmt.Println(table.Render())
+
+	if tiered && !c.tiers {
+		fmt.Println()
+		fmt.Println("The account uses tiered limits, pass --tiers to compare usage to the limits of each tier")
+	}
+
+	return nil
+}
+
+// tierLimitUsage renders used compared to limit where negative limits are unlimited, limited is false when no limits are known
+func tierLimitUsage(used uint64, limit int64, limited bool, bytes bool) string {
+	render := func(v uint64) string {
+		if bytes {
+			return humanize.IBytes(v)
+		}
+		return f(v)
+	}
+
+	switch {
+	case !limited:
+		return render(used)
+	case limit < 0:
+		return fmt.Sprintf("%s of Unlimited", render(used))
+	case limit == 0:
+		return fmt.Sprintf("%s of %s", render(used), render(0))
+	default:
+		return fmt.Sprintf("%s of %s (%.0f%%)", render(used), render(uint64(limit)), float64(used)/float64(limit)*100)
+	}
+}
+
+// tierNearLimits lists the resources of a tier at or above tierLimitWarnPercent of their limits, reservations count towards memory and storage usage
+func tierNearLimits(tier api.JetStreamTier, limited bool) []string {
+	if !limited {
+		return nil
+	}
+
+	var near []string
+	check := func(name string, used uint64, limit int64) {
+		if limit < 0 {
+			return
+		}
+		if limit == 0 || float64(used)/float64(limit)*100 >= tierLimitWarnPercent {
+			near = append(near, name)
+		}
+	}
+
+	check("streams", uint64(tier.Streams), int64(tier.Limits.MaxStreams))
+	check("consumers", uint64(tier.Consumers), int64(tier.Limits.MaxConsumers))
+	check("memory", max(tier.Memory, tier.ReservedMemory), tier.Limits.MaxMemory)
+	check("storage", max(tier.Store, tier.ReservedStore), tier.Limits.MaxStore)
+
+	return near
+}
+
 func (c *actCmd) parseAccountStatResp(resp []byte) (*accountStats, error) {
 	reqresp := map[string]json.RawMessage{}
 	err := json.Unmarshal(resp, &reqresp)
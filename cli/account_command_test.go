@@ -0,0 +1,55 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestTierLimits(t *testing.T) {
+	if u := tierLimitUsage(5, 10, true, false); u != "5 of 10 (50%)" {
+		t.Fatalf("unexpected usage %q", u)
+	}
+	if u := tierLimitUsage(5, -1, true, false); u != "5 of Unlimited" {
+		t.Fatalf("unexpected usage %q", u)
+	}
+	if u := tierLimitUsage(1024, 0, false, true); u != "1.0 KiB" {
+		t.Fatalf("unexpected usage %q", u)
+	}
+
+	tier := api.JetStreamTier{
+		Streams:       9,
+		Consumers:     1,
+		Store:         10,
+		ReservedStore: 95,
+		Limits: api.JetStreamAccountLimits{
+			MaxStreams:   10,
+			MaxConsumers: -1,
+			MaxMemory:    0,
+			MaxStore:     100,
+		},
+	}
+
+	near := tierNearLimits(tier, true)
+	if !cmp.Equal(near, []string{"streams", "memory", "storage"}) {
+		t.Fatalf("unexpected near limits %v", near)
+	}
+
+	if tierNearLimits(tier, false) != nil {
+		t.Fatalf("expected no near limits without known limits")
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+const accountConfigBundleFile = "config.json"
+
+// accountConfigBundle holds the configuration of all streams and durable consumers in an account, streams are ordered so that those being mirrored or sourced are created first
+type accountConfigBundle struct {
+	Created time.Time             `json:"created"`
+	Streams []accountConfigStream `json:"streams"`
+}
+
+type accountConfigStream struct {
+	Config    api.StreamConfig     `json:"config"`
+	Consumers []api.ConsumerConfig `json:"consumers,omitempty"`
+}
+
+func (c *actCmd) backupConfig(mgr *jsm.Manager) error {
+	streams, missing, err := mgr.Streams(nil)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("could not obtain stream information for %d streams", len(missing))
+	}
+
+	if len(streams) == 0 {
+		return fmt.Errorf("no streams found")
+	}
+
+	var configs []api.StreamConfig
+	consumers := make(map[string][]api.ConsumerConfig)
+
+	for _, s := range streams {
+		cfg := s.Configuration()
+		cfg.Metadata = iu.RemoveReservedMetadata(cfg.Metadata)
+		configs = append(configs, cfg)
+
+		_, err = s.EachConsumer(func(cons *jsm.Consumer) {
+			if !cons.IsDurable() {
+				return
+			}
+
+			ccfg := cons.Configuration()
+			ccfg.Metadata = iu.RemoveReservedMetadata(ccfg.Metadata)
+			consumers[s.Name()] = append(consumers[s.Name()], ccfg)
+		})
+		if err != nil {
+			return fmt.Errorf("could not load consumers for stream %s: %w", s.Name(), err)
+		}
+
+		sort.Slice(consumers[s.Name()], func(i, j int) bool {
+			return consumers[s.Name()][i].Durable < consumers[s.Name()][j].Durable
+		})
+	}
+
+	bundle := accountConfigBundle{Created: time.Now().UTC()}
+	for _, cfg := range orderStreamConfigs(configs) {
+		bundle.Streams = append(bundle.Streams, accountConfigStream{Config: cfg, Consumers: consumers[cfg.Name]})
+	}
+
+	j, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(c.backupDirectory, 0700)
+	if err != nil {
+		return err
+	}
+
+	target := filepath.Join(c.backupDirectory, accountConfigBundleFile)
+	err = os.WriteFile(target, j, 0600)
+	if err != nil {
+		return err
+	}
+
+	var total int
+	for _, s := range bundle.Streams {
+		total += len(s.Consumers)
+	}
+
+	fmt.Printf("Saved configuration for %s Streams and %s Consumers to %s\n", f(len(bundle.Streams)), f(total), target)
+
+	return nil
+}
+
+func (c *actCmd) restoreConfig(mgr *jsm.Manager, file string) error {
+	j, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var bundle accountConfigBundle
+	err = json.Unmarshal(j, &bundle)
+	if err != nil {
+		return fmt.Errorf("invalid configuration bundle %s: %w", file, err)
+	}
+
+	if !c.update {
+		for _, s := range bundle.Streams {
+			known, err := mgr.IsKnownStream(s.Config.Name)
+			if err != nil {
+				return err
+			}
+			if known {
+				return fmt.Errorf("stream %q exists already, use --update to reconcile existing resources", s.Config.Name)
+			}
+		}
+	}
+
+	fmt.Printf("Restoring configuration of %s Streams from %s\n\n", f(len(bundle.Streams)), file)
+
+	for _, s := range bundle.Streams {
+		cfg := s.Config
+		if c.placementCluster != "" || len(c.placementTags) > 0 {
+			cfg.Placement = &api.Placement{Cluster: c.placementCluster, Tags: c.placementTags}
+		}
+
+		known, err := mgr.IsKnownStream(cfg.Name)
+		if err != nil {
+			return err
+		}
+
+		if known {
+			str, err := mgr.LoadStream(cfg.Name)
+			if err != nil {
+				return err
+			}
+
+			err = str.UpdateConfiguration(cfg)
+			if err != nil {
+				return fmt.Errorf("could not update stream %s: %w", cfg.Name, err)
+			}
+			fmt.Printf("Updated Stream %s\n", cfg.Name)
+		} else {
+			_, err = mgr.NewStreamFromDefault(cfg.Name, cfg)
+			if err != nil {
+				return fmt.Errorf("could not create stream %s: %w", cfg.Name, err)
+			}
+			fmt.Printf("Created Stream %s\n", cfg.Name)
+		}
+
+		for _, ccfg := range s.Consumers {
+			known, err := mgr.IsKnownConsumer(cfg.Name, ccfg.Durable)
+			if err != nil {
+				return err
+			}
+			if known && !c.update {
+				return fmt.Errorf("consumer %s > %s exists already, use --update to reconcile existing resources", cfg.Name, ccfg.Durable)
+			}
+
+			_, err = mgr.NewConsumerFromDefault(cfg.Name, ccfg)
+			if err != nil {
+				return fmt.Errorf("could not restore consumer %s > %s: %w", cfg.Name, ccfg.Durable, err)
+			}
+
+			if known {
+				fmt.Printf("  Updated Consumer %s\n", ccfg.Durable)
+			} else {
+				fmt.Printf("  Created Consumer %s\n", ccfg.Durable)
+			}
+		}
+	}
+
+	return nil
+}
+
+// orderStreamConfigs sorts streams so that any stream is placed after the streams it mirrors or sources from, cycles are placed last in name order
+func orderStreamConfigs(configs []api.StreamConfig) []api.StreamConfig {
+	byName := make(map[string]api.StreamConfig, len(configs))
+	var names []string
+	for _, cfg := range configs {
+		byName[cfg.Name] = cfg
+		names = append(names, cfg.Name)
+	}
+	sort.Strings(names)
+
+	dependencies := func(cfg api.StreamConfig) []string {
+		var deps []string
+		if cfg.Mirror != nil && cfg.Mirror.External == nil {
+			deps = append(deps, cfg.Mirror.Name)
+		}
+		for _, source := range cfg.Sources {
+			if source != nil && source.External == nil {
+				deps = append(deps, source.Name)
+			}
+		}
+
+		return deps
+	}
+
+	var ordered []api.StreamConfig
+	done := make(map[string]bool)
+
+	for len(ordered) < len(names) {
+		progressed := false
+
+		for _, name := range names {
+			if done[name] {
+				continue
+			}
+
+			ready := true
+			for _, dep := range dependencies(byName[name]) {
+				_, known := byName[dep]
+				if known && !done[dep] && dep != name {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				ordered = append(ordered, byName[name])
+				done[name] = true
+				progressed = true
+			}
+		}
+
+		if !progressed {
+			for _, name := range names {
+				if !done[name] {
+					ordered = append(ordered, byName[name])
+					done[name] = true
+				}
+			}
+		}
+	}
+
+	return ordered
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/choria-io/fisk"
+	"github.com/kballard/go-shellquote"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+type aliasCmd struct {
+	name    string
+	command string
+	force   bool
+}
+
+var (
+	validAliasName   = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+	aliasPlaceholder = regexp.MustCompile(`{{\s*(\d+)\s*}}`)
+
+	configuredAliasesOnce sync.Once
+	configuredAliases     map[string]string
+)
+
+func configureAliasCommand(app commandHost) {
+	c := &aliasCmd{}
+
+	alias := app.Command("alias", "Manage command aliases")
+	addCheat("alias", alias)
+	alias.HelpLong(`Aliases are shortcuts for commonly used commands stored in the CLI configuration.
+
+Positional arguments given to the alias are placed in the command using
+{{1}}, {{2}} and so forth, any remaining arguments are appended:
+
+   nats alias add lag "consumer report {{1}} --sort pending"
+   nats lag ORDERS
+
+Built in commands always take precedence over aliases with the same name and
+global flags like --context have to be given after the alias name.`)
+
+	add := alias.Command("add", "Adds or updates an alias").Alias("set").Action(c.addAction)
+	add.Arg("name", "The name of the alias").Required().StringVar(&c.name)
+	add.Arg("command", "The command to run, without the leading nats").Required().StringVar(&c.command)
+	add.Flag("force", "Overwrite an existing alias").Short('f').UnNegatableBoolVar(&c.force)
+
+	alias.Command("ls", "List aliases").Alias("list").Action(c.lsAction)
+
+	rm := alias.Command("rm", "Removes an alias").Action(c.rmAction)
+	rm.Arg("name", "The name of the alias").Required().StringVar(&c.name)
+}
+
+func init() {
+	registerCommand("alias", 20, configureAliasCommand)
+}
+
+func (c *aliasCmd) addAction(_ *fisk.ParseContext) error {
+	if !validAliasName.MatchString(c.name) {
+		return fmt.Errorf("alias names must match %s", validAliasName.String())
+	}
+
+	_, err := shellquote.Split(c.command)
+	if err != nil {
+		return fmt.Errorf("invalid command: %w", err)
+	}
+
+	cfg, err := iu.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+
+	_, exist := cfg.Aliases[c.name]
+	if exist && !c.force {
+		return fmt.Errorf("alias %s already exist, use --force to update", c.name)
+	}
+
+	cfg.Aliases[c.name] = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(c.command), "nats "))
+
+	err = iu.SaveConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Alias %s was added for nats %s\n", c.name, cfg.Aliases[c.name])
+
+	return nil
+}
+
+func (c *aliasCmd) lsAction(_ *fisk.ParseContext) error {
+	cfg, err := iu.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(cfg.Aliases) == 0 {
+		fmt.Println("No aliases defined")
+		return nil
+	}
+
+	var names []string
+	for name := range cfg.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table := iu.NewTableWriter(opts(), "Aliases")
+	table.AddHeaders("Name", "Command")
+	for _, name := range names {
+		table.AddRow(name, "nats "+cfg.Aliases[name])
+	}
+	fmt.Println(table.Render())
+
+	return nil
+}
+
+func (c *aliasCmd) rmAction(_ *fisk.ParseContext) error {
+	cfg, err := iu.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	_, exist := cfg.Aliases[c.name]
+	if !exist {
+		return fmt.Errorf("unknown alias %s", c.name)
+	}
+
+	delete(cfg.Aliases, c.name)
+
+	err = iu.SaveConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Alias %s was removed\n", c.name)
+
+	return nil
+}
+
+// ExpandAliases replaces a user defined alias in the first position of args with its command, built in commands take precedence
+func ExpandAliases(app *fisk.Application, args []string) []string {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return args
+	}
+
+	for _, cmd := range app.Model().Commands {
+		if cmd.Name == args[0] {
+			return args
+		}
+		for _, a := range cmd.Aliases {
+			if a == args[0] {
+				return args
+			}
+		}
+	}
+
+	alias, ok := aliases()[args[0]]
+	if !ok {
+		return args
+	}
+
+	expanded, err := expandAlias(alias, args[1:])
+	if err != nil {
+		log.Fatalf("Could not expand alias %s: %v", args[0], err)
+	}
+
+	return expanded
+}
+
+// aliases are the user defined aliases, the configuration is only loaded once
+func aliases() map[string]string {
+	configuredAliasesOnce.Do(func() {
+		cfg, err := iu.LoadConfig()
+		if err == nil {
+			configuredAliases = cfg.Aliases
+		}
+	})
+
+	return configuredAliases
+}
+
+// expandAlias splits the alias command and places args in its {{n}} placeholders, unused args are appended
+func expandAlias(alias string, args []string) ([]string, error) {
+	parts, err := shellquote.Split(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[int]bool)
+	var result []string
+
+	for _, part := range parts {
+		var perr error
+
+		part = aliasPlaceholder.ReplaceAllStringFunc(part, func(m string) string {
+			idx, _ := strconv.Atoi(aliasPlaceholder.FindStringSubmatch(m)[1])
+			if idx < 1 || idx > len(args) {
+				perr = fmt.Errorf("argument %d is required", idx)
+				return m
+			}
+
+			used[idx] = true
+			return args[idx-1]
+		})
+		if perr != nil {
+			return nil, perr
+		}
+
+		result = append(result, part)
+	}
+
+	for i, arg := range args {
+		if !used[i+1] {
+			result = append(result, arg)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandAlias(t *testing.T) {
+	cases := []struct {
+		alias  string
+		args   []string
+		expect []string
+		err    bool
+	}{
+		{alias: "consumer report {{1}} --sort pending", args: []string{"ORDERS"}, expect: []string{"consumer", "report", "ORDERS", "--sort", "pending"}},
+		{alias: "stream info", args: []string{"ORDERS", "--json"}, expect: []string{"stream", "info", "ORDERS", "--json"}},
+		{alias: `pub {{2}} "hello {{1}}"`, args: []string{"world", "greet", "-H", "a:b"}, expect: []string{"pub", "greet", "hello world", "-H", "a:b"}},
+		{alias: "consumer info {{1}} {{2}}", args: []string{"ORDERS"}, err: true},
+	}
+
+	for _, tc := range cases {
+		res, err := expandAlias(tc.alias, tc.args)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("expected an error for %q", tc.alias)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expansion failed: %v", err)
+		}
+		if !cmp.Equal(res, tc.expect) {
+			t.Fatalf("expected %#v got %#v", tc.expect, res)
+		}
+	}
+}
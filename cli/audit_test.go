@@ -0,0 +1,86 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/natscli/options"
+)
+
+func TestAuditArgs(t *testing.T) {
+	args := auditArgs([]string{"stream", "rm", "ORDERS", "--password", "s3cret", "--password=s3cret", "--user", "bob", "--user=tok", "-s", "nats://bob:s3cret@a:4222,tls://tok@b:4222", "--server=nats://c:4222", "-f"})
+	expect := []string{"stream", "rm", "ORDERS", "--password", "[redacted]", "--password=[redacted]", "--user", "[redacted]", "--user=[redacted]", "-s", "nats://[redacted]@a:4222,tls://[redacted]@b:4222", "--server=nats://c:4222", "-f"}
+	if !cmp.Equal(args, expect) {
+		t.Fatalf("invalid args: %s", cmp.Diff(expect, args))
+	}
+
+	if auditDigest(nil) != "" {
+		t.Fatalf("expected no digest for nil")
+	}
+
+	a := auditDigest(api.StreamConfig{Name: "ORDERS"})
+	b := auditDigest(&api.StreamConfig{Name: "ORDERS"})
+	if a != b || !strings.HasPrefix(a, "sha256:") {
+		t.Fatalf("expected equal digests for values and pointers: %q %q", a, b)
+	}
+	if a == auditDigest(api.StreamConfig{Name: "ORDERS", MaxMsgs: 10}) {
+		t.Fatalf("expected different digests for different configurations")
+	}
+
+	if auditConfigDigest([]byte(`{"a":1,"b":2}`)) != auditConfigDigest([]byte(`{"b":2, "a":1}`)) {
+		t.Fatalf("expected equal digests regardless of key order")
+	}
+}
+
+func TestAuditResource(t *testing.T) {
+	prev := options.DefaultOptions
+	defer func() { options.DefaultOptions = prev }()
+	options.DefaultOptions = &options.Options{}
+
+	cases := []struct {
+		subject   string
+		data      string
+		operation string
+		resource  string
+		config    bool
+	}{
+		{"$JS.API.STREAM.UPDATE.ORDERS", `{"name":"ORDERS"}`, "STREAM.UPDATE", "stream ORDERS", true},
+		{"$JS.hub.API.STREAM.MSG.DELETE.ORDERS", `{"seq":1}`, "STREAM.MSG.DELETE", "stream ORDERS", false},
+		{"$JS.API.STREAM.LEADER.STEPDOWN.ORDERS", ``, "STREAM.LEADER.STEPDOWN", "stream ORDERS", false},
+		{"$JS.API.CONSUMER.CREATE.ORDERS.C1.orders.>", `{"config":{"durable_name":"C1"}}`, "CONSUMER.CREATE", "consumer ORDERS > C1", true},
+		{"$JS.API.CONSUMER.CREATE.ORDERS", `{"config":{"durable_name":"C1"}}`, "CONSUMER.CREATE", "consumer ORDERS > C1", true},
+		{"$JS.API.CONSUMER.DURABLE.CREATE.ORDERS.C1", `{"config":{"durable_name":"C1"}}`, "CONSUMER.DURABLE.CREATE", "consumer ORDERS > C1", true},
+		{"$JS.API.CONSUMER.LEADER.STEPDOWN.ORDERS.C1", ``, "CONSUMER.LEADER.STEPDOWN", "consumer ORDERS > C1", false},
+		{"$JS.API.CONSUMER.DELETE.ORDERS.C1", ``, "CONSUMER.DELETE", "consumer ORDERS > C1", false},
+		{"$JS.API.ACCOUNT.STREAM.MOVE.ORDERS", ``, "ACCOUNT.STREAM.MOVE", "stream ORDERS", false},
+		{"$JS.API.SERVER.REMOVE", `{"peer":"n1"}`, "SERVER.REMOVE", "server n1", false},
+		{"$KV.CONFIG.a.b", `v`, "KV.PUT", "kv CONFIG > a.b", false},
+		{"$O.FILES.M.bmFtZQ==", `{}`, "OBJECT.PUT", "object FILES > bmFtZQ==", false},
+	}
+
+	for _, c := range cases {
+		operation, resource, config := auditResource(c.subject, []byte(c.data))
+		if operation != c.operation || resource != c.resource || (config != nil) != c.config {
+			t.Fatalf("%s: got %q %q %q", c.subject, operation, resource, config)
+		}
+	}
+
+	if !auditSkipped("$JS.ACK.ORDERS.C1.1.1.1.1.0") || !auditSkipped("$O.FILES.C.abc") || auditSkipped("$O.FILES.M.abc") {
+		t.Fatalf("invalid skipped subjects")
+	}
+}
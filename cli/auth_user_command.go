@@ -27,6 +27,7 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/choria-io/fisk"
+	"github.com/nats-io/nkeys"
 	ab "github.com/synadia-io/jwt-auth-builder.go"
 )
 
@@ -54,6 +55,7 @@ type authUserCommand struct {
 	credFile        string
 	expire          time.Duration
 	revoke          bool
+	revokeKey       string
 }
 
 func configureAuthUserCommand(auth commandHost) {
@@ -111,6 +113,17 @@ func configureAuthUserCommand(auth commandHost) {
 	rm.Flag("revoke", "Also revokes the user before deleting it").UnNegatableBoolVar(&c.revoke)
 	rm.Flag("force", "Removes without prompting").Short('f').UnNegatableBoolVar(&c.force)
 
+	revoke := user.Command("revoke", "Revokes all credentials issued to a User before now").Action(c.revokeAction)
+	revoke.Arg("name", "Unique name for this User").StringVar(&c.userName)
+	revoke.Arg("account", "Account to query").StringVar(&c.accountName)
+	revoke.Flag("key", "Revokes a User public key not managed by this tool").PlaceHolder("PUBLIC_KEY").StringVar(&c.revokeKey)
+	revoke.Flag("operator", "Operator holding the Account").StringVar(&c.operatorName)
+	revoke.Flag("force", "Revokes without prompting").Short('f').UnNegatableBoolVar(&c.force)
+
+	revocations := user.Command("revocations", "List revoked Users").Alias("revoked").Action(c.revocationsAction)
+	revocations.Arg("account", "Account to query").StringVar(&c.accountName)
+	revocations.Flag("operator", "Operator holding the Account").StringVar(&c.operatorName)
+
 	cred := user.Command("credential", "Creates a credential file for a user").Alias("cred").Alias("creds").Action(c.credAction)
 	cred.Arg("file", "The file to create").Required().StringVar(&c.credFile)
 	cred.Arg("name", "User to generate a credential for").StringVar(&c.userName)
@@ -242,6 +255,94 @@ func (c *authUserCommand) rmAction(_ *fisk.ParseContext) error {
 
 	return nil
 }
+func (c *authUserCommand) revokeAction(_ *fisk.ParseContext) error {
+	auth, _, acct, err := c.selectAccount(true)
+	if err != nil {
+		return err
+	}
+
+	key := c.revokeKey
+	switch {
+	case key != "":
+		if !nkeys.IsValidPublicUserKey(key) {
+			return fmt.Errorf("%s is not a valid User public key", key)
+		}
+
+	default:
+		if c.userName == "" {
+			err = c.pickUser(acct)
+			if err != nil {
+				return err
+			}
+		}
+
+		user, err := acct.Users().Get(c.userName)
+		if errors.Is(err, ab.ErrNotFound) {
+			return fmt.Errorf("user does not exist")
+		} else if err != nil {
+			return err
+		}
+
+		key = user.Subject()
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really revoke all credentials issued to %s before now", key), false)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return nil
+		}
+	}
+
+	err = acct.Revocations().Add(key, time.Now())
+	if err != nil {
+		return fmt.Errorf("revocation failed: %v", err)
+	}
+
+	err = auth.Commit()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Revoked %s, push the Account using 'nats auth account push %s' to apply the revocation\n", key, acct.Name())
+
+	return nil
+}
+
+func (c *authUserCommand) revocationsAction(_ *fisk.ParseContext) error {
+	_, _, acct, err := c.selectAccount(true)
+	if err != nil {
+		return err
+	}
+
+	revocations := acct.Revocations().List()
+	if len(revocations) == 0 {
+		fmt.Println("No revocations found")
+		return nil
+	}
+
+	names := map[string]string{}
+	for _, u := range acct.Users().List() {
+		names[u.Subject()] = u.Name()
+	}
+
+	sort.Slice(revocations, func(i, j int) bool {
+		return revocations[i].At().Before(revocations[j].At())
+	})
+
+	table := iu.NewTableWriter(opts(), fmt.Sprintf("Revocations in account %s", acct.Name()))
+	table.AddHeaders("Public Key", "User", "Revoked Before")
+	for _, rev := range revocations {
+		table.AddRow(rev.PublicKey(), names[rev.PublicKey()], rev.At().Format(time.RFC3339))
+	}
+	fmt.Println(table.Render())
+
+	return nil
+}
+
 func (c *authUserCommand) lsAction(_ *fisk.ParseContext) error {
 	_, _, acct, err := c.selectAccount(true)
 	if err != nil {
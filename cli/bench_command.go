@@ -67,6 +67,9 @@ type benchCmd struct {
 	deDuplicationWindow  time.Duration
 	ack                  bool
 	randomizeGets        int
+	saveRun              bool
+	latencies            *benchLatencies
+	compareRuns          []string
 }
 
 const (
@@ -102,6 +105,7 @@ func configureBenchCommand(app commandHost) {
 		f.Flag("progress", "Enable or disable the progress bar").Default("true").BoolVar(&c.progressBar)
 		f.Flag("csv", "Save benchmark data to CSV file").StringVar(&c.csvFile)
 		f.Flag("size", "Size of the test messages").Default("128").StringVar(&c.msgSizeString)
+		f.Flag("save", "Save the results in the benchmark history for later comparison").UnNegatableBoolVar(&c.saveRun)
 		// TODO: support randomized payload data
 	}
 
@@ -201,6 +205,19 @@ func configureBenchCommand(app commandHost) {
 	kvget := kvCommand.Command("get", "Get messages from a KV bucket").Action(c.kvGetAction)
 	kvget.Flag("randomize", "Randomly access messages using keys between 0 and this number (set to 0 for sequential access)").Default("0").IntVar(&c.randomizeGets)
 
+	benchCommand.Command("history", "List benchmark runs saved using --save").Alias("ls").Action(c.historyAction)
+
+	compare := benchCommand.Command("compare", "Compare the results of two saved benchmark runs").Action(c.compareAction)
+	compare.HelpLong(`Shows the message rates, throughput and latency percentiles of two runs saved
+using --save along with the change between them. Runs are identified by their
+ID or a unique prefix of it as shown by 'nats bench history'.
+
+Latency percentiles are recorded for synchronous operations: requests, JetStream
+publishes using --batch 1 and KV puts and gets.
+
+   nats bench compare 3f2a91 8c0d4e`)
+	compare.Arg("runs", "The IDs of the runs to compare").Required().StringsVar(&c.compareRuns)
+
 	oldJSCommand := benchCommand.Command("oldjs", "JetStream benchmark commands using the old JS API").Hidden()
 	addCommonFlags(oldJSCommand)
 	addJSCommonFlags(oldJSCommand)
@@ -285,6 +302,10 @@ func (c *benchCmd) processActionArgs() error {
 		c.streamMaxBytes = size
 	}
 
+	if c.saveRun {
+		c.latencies = &benchLatencies{}
+	}
+
 	return nil
 }
 
@@ -417,7 +438,7 @@ func (c *benchCmd) generateBanner(benchType string) string {
 	return banner
 }
 
-func (c *benchCmd) printResults(bm *bench.Benchmark) error {
+func (c *benchCmd) printResults(benchType string, bm *bench.Benchmark) error {
 	if c.progressBar {
 		uiprogress.Stop()
 	}
@@ -438,6 +459,14 @@ func (c *benchCmd) printResults(bm *bench.Benchmark) error {
 		fmt.Printf("Saved metric data in csv file %s\n", c.csvFile)
 	}
 
+	if c.saveRun {
+		run, err := c.saveBenchRun(benchType, bm)
+		if err != nil {
+			return fmt.Errorf("saving benchmark results: %w", err)
+		}
+		fmt.Printf("Saved benchmark results as run %s\n", run.ID)
+	}
+
 	return nil
 }
 
@@ -578,7 +607,7 @@ func (c *benchCmd) pubAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeCorePub, bm)
 	if err != nil {
 		return err
 	}
@@ -638,7 +667,7 @@ func (c *benchCmd) subAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeCoreSub, bm)
 	if err != nil {
 		return err
 	}
@@ -701,7 +730,7 @@ func (c *benchCmd) requestAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeServiceRequest, bm)
 	if err != nil {
 		return err
 	}
@@ -758,7 +787,7 @@ func (c *benchCmd) serveAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeServiceServe, bm)
 	if err != nil {
 		return err
 	}
@@ -858,7 +887,7 @@ func (c *benchCmd) jspubAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeJSPub, bm)
 	if err != nil {
 		return err
 	}
@@ -923,7 +952,7 @@ func (c *benchCmd) jsOrderedAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeJSOrdered, bm)
 	if err != nil {
 		return err
 	}
@@ -1010,7 +1039,7 @@ func (c *benchCmd) jsConsumeAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeJSConsume, bm)
 	if err != nil {
 		return err
 	}
@@ -1097,7 +1126,7 @@ func (c *benchCmd) jsFetchAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeJSFetch, bm)
 	if err != nil {
 		return err
 	}
@@ -1187,7 +1216,7 @@ func (c *benchCmd) kvPutAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeKVPut, bm)
 	if err != nil {
 		return err
 	}
@@ -1248,7 +1277,7 @@ func (c *benchCmd) kvGetAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(BenchTypeKVGet, bm)
 	if err != nil {
 		return err
 	}
@@ -1313,7 +1342,7 @@ func (c *benchCmd) oldjsOrderedAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeOldJSOrdered, bm)
 	if err != nil {
 		return err
 	}
@@ -1427,7 +1456,7 @@ func (c *benchCmd) oldjsPushAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeOldJSPush, bm)
 	if err != nil {
 		return err
 	}
@@ -1530,7 +1559,7 @@ func (c *benchCmd) oldjsPullAction(_ *fisk.ParseContext) error {
 	}
 
 	bm.Close()
-	err = c.printResults(bm)
+	err = c.printResults(benchTypeOldJSPull, bm)
 	if err != nil {
 		return err
 	}
@@ -1585,7 +1614,9 @@ func (c *benchCmd) coreNATSRequester(nc *nats.Conn, progress *uiprogress.Bar, ms
 			progress.Incr()
 		}
 
+		start := time.Now()
 		m, err := nc.Request(c.getPublishSubject(i+offset), msg, time.Second)
+		c.latencies.observe(start)
 		if err != nil {
 			return fmt.Errorf("requesting: %w", err)
 		}
@@ -1663,6 +1694,7 @@ func (c *benchCmd) jsPublisher(nc *nats.Conn, progress *uiprogress.Bar, msg []by
 			if progress != nil {
 				progress.Incr()
 			}
+			start := time.Now()
 			if c.deDuplication {
 				header := nats.Header{}
 				header.Set(nats.MsgIdHdr, idPrefix+"-"+pubNumber+"-"+strconv.Itoa(i+offset))
@@ -1671,6 +1703,7 @@ func (c *benchCmd) jsPublisher(nc *nats.Conn, progress *uiprogress.Bar, msg []by
 			} else {
 				_, err = js.Publish(ctx, c.getPublishSubject(i+offset), msg)
 			}
+			c.latencies.observe(start)
 			if err != nil {
 				return fmt.Errorf("publishing synchronously: %w", err)
 			}
@@ -1707,7 +1740,9 @@ func (c *benchCmd) kvPutter(nc *nats.Conn, progress *uiprogress.Bar, msg []byte,
 			progress.Incr()
 		}
 
+		start := time.Now()
 		_, err = kvBucket.Put(ctx, fmt.Sprintf("%d", offset+i), msg)
+		c.latencies.observe(start)
 		if err != nil {
 			return fmt.Errorf("putting: %w", err)
 		}
@@ -2292,7 +2327,9 @@ func (c *benchCmd) runKVGetter(bm *bench.Benchmark, errChan chan error, nc *nats
 		} else {
 			key = fmt.Sprintf("%d", rand.Intn(c.randomizeGets))
 		}
+		start := time.Now()
 		entry, err := kvBucket.Get(ctx, key)
+		c.latencies.observe(start)
 
 		if err != nil {
 			errChan <- fmt.Errorf("getting key '%s': %w", key, err)
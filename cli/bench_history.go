@@ -0,0 +1,374 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/bench"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// benchLatencies records the duration of synchronous benchmark operations like requests, publishes and gets
+type benchLatencies struct {
+	samples []time.Duration
+	mu      sync.Mutex
+}
+
+// observe records an operation started at start, recording on a nil benchLatencies does nothing
+func (l *benchLatencies) observe(start time.Time) {
+	if l == nil {
+		return
+	}
+
+	d := time.Since(start)
+
+	l.mu.Lock()
+	l.samples = append(l.samples, d)
+	l.mu.Unlock()
+}
+
+// percentiles calculates the latency percentiles of the recorded operations, nil when none were recorded
+func (l *benchLatencies) percentiles() *benchRunLatency {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.samples) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(l.samples)
+	slices.Sort(sorted)
+
+	at := func(p float64) time.Duration {
+		return sorted[min(len(sorted)-1, int(p*float64(len(sorted))))]
+	}
+
+	return &benchRunLatency{
+		P50:  at(0.5),
+		P90:  at(0.9),
+		P99:  at(0.99),
+		P999: at(0.999),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// benchRunLatency holds latency percentiles of a benchmark run
+type benchRunLatency struct {
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P99  time.Duration `json:"p99"`
+	P999 time.Duration `json:"p999"`
+	Max  time.Duration `json:"max"`
+}
+
+// benchRunStats holds the aggregated results of the publishers or subscribers in a benchmark run
+type benchRunStats struct {
+	Clients     int           `json:"clients"`
+	Messages    uint64        `json:"messages"`
+	Bytes       uint64        `json:"bytes"`
+	Duration    time.Duration `json:"duration"`
+	MsgsPerSec  int64         `json:"msgs_per_sec"`
+	BytesPerSec float64       `json:"bytes_per_sec"`
+	MinRate     int64         `json:"min_rate"`
+	MaxRate     int64         `json:"max_rate"`
+	AvgRate     int64         `json:"avg_rate"`
+}
+
+// benchRun is a benchmark result saved in the benchmark history
+type benchRun struct {
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Time     time.Time        `json:"time"`
+	Command  string           `json:"command"`
+	Server   string           `json:"server_version,omitempty"`
+	MsgSize  int              `json:"msg_size"`
+	Pubs     *benchRunStats   `json:"pubs,omitempty"`
+	Subs     *benchRunStats   `json:"subs,omitempty"`
+	Latency  *benchRunLatency `json:"latency,omitempty"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+func newBenchRunStats(sg *bench.SampleGroup) *benchRunStats {
+	if sg == nil || !sg.HasSamples() {
+		return nil
+	}
+
+	return &benchRunStats{
+		Clients:     len(sg.Samples),
+		Messages:    sg.MsgCnt,
+		Bytes:       sg.MsgBytes,
+		Duration:    sg.Duration(),
+		MsgsPerSec:  sg.Rate(),
+		BytesPerSec: sg.Throughput(),
+		MinRate:     sg.MinRate(),
+		MaxRate:     sg.MaxRate(),
+		AvgRate:     sg.AvgRate(),
+	}
+}
+
+// benchHistoryDir is the directory holding saved benchmark runs
+func benchHistoryDir() (string, error) {
+	parent, err := iu.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(parent, "bench")
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// saveBenchRun stores the results of bm in the benchmark history, assigning the run a short ID derived from its content
+func (c *benchCmd) saveBenchRun(benchType string, bm *bench.Benchmark) (*benchRun, error) {
+	run := &benchRun{
+		Type:    benchType,
+		Time:    time.Now().UTC(),
+		Command: strings.Join(os.Args[1:], " "),
+		MsgSize: c.msgSize,
+		Pubs:    newBenchRunStats(bm.Pubs),
+		Subs:    newBenchRunStats(bm.Subs),
+		Latency: c.latencies.percentiles(),
+	}
+
+	nc, err := nats.Connect(opts().Config.ServerURL(), natsOpts()...)
+	if err == nil {
+		run.Server = nc.ConnectedServerVersion()
+		nc.Close()
+	}
+
+	if c.fetchTimeout {
+		run.Warnings = append(run.Warnings, "at least one pull consumer Fetch operation timed out")
+	}
+
+	j, err := json.Marshal(run)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(j)
+	run.ID = hex.EncodeToString(sum[:])[:12]
+
+	j, err = json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := benchHistoryDir()
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(filepath.Join(dir, run.ID+".json"), j, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// loadBenchRuns loads all saved benchmark runs ordered by time
+func loadBenchRuns() ([]*benchRun, error) {
+	dir, err := benchHistoryDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*benchRun
+	for _, file := range files {
+		j, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		run := &benchRun{}
+		err = json.Unmarshal(j, run)
+		if err != nil {
+			return nil, fmt.Errorf("invalid benchmark run %s: %w", file, err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Time.Before(runs[j].Time)
+	})
+
+	return runs, nil
+}
+
+// findBenchRun finds the run with an ID starting with prefix, prefixes matching several runs are an error
+func findBenchRun(runs []*benchRun, prefix string) (*benchRun, error) {
+	var found *benchRun
+	for _, run := range runs {
+		if !strings.HasPrefix(run.ID, prefix) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("benchmark run %q is ambiguous", prefix)
+		}
+		found = run
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("unknown benchmark run %q", prefix)
+	}
+
+	return found, nil
+}
+
+// benchDelta formats the change from a to b as a percentage, colored by whether the change is an improvement
+func benchDelta(a float64, b float64, higherIsBetter bool) string {
+	if a == 0 {
+		return ""
+	}
+
+	delta := (b - a) / a * 100
+	s := fmt.Sprintf("%+.1f%%", delta)
+
+	switch {
+	case delta > 0.05 && higherIsBetter, delta < -0.05 && !higherIsBetter:
+		return color.GreenString("%s", s)
+	case delta < -0.05 && higherIsBetter, delta > 0.05 && !higherIsBetter:
+		return color.RedString("%s", s)
+	default:
+		return s
+	}
+}
+
+func (c *benchCmd) historyAction(_ *fisk.ParseContext) error {
+	runs, err := loadBenchRuns()
+	if err != nil {
+		return err
+	}
+
+	if len(runs) == 0 {
+		fmt.Println("No benchmark runs were saved, run benchmarks with --save to keep their results")
+		return nil
+	}
+
+	table := iu.NewTableWriter(opts(), "Saved Benchmark Runs")
+	table.AddHeaders("ID", "Time", "Type", "Server", "Msgs/sec", "Throughput", "P99 Latency")
+	for _, run := range runs {
+		rate, throughput := "", ""
+		stats := run.Subs
+		if stats == nil {
+			stats = run.Pubs
+		}
+		if stats != nil {
+			rate = f(stats.MsgsPerSec)
+			throughput = humanize.IBytes(uint64(stats.BytesPerSec)) + "/s"
+		}
+
+		p99 := ""
+		if run.Latency != nil {
+			p99 = f(run.Latency.P99)
+		}
+
+		table.AddRow(run.ID, f(run.Time.Local()), run.Type, run.Server, rate, throughput, p99)
+	}
+	fmt.Println(table.Render())
+
+	return nil
+}
+
+func (c *benchCmd) compareAction(_ *fisk.ParseContext) error {
+	if len(c.compareRuns) != 2 {
+		return fmt.Errorf("two benchmark runs are required")
+	}
+
+	runs, err := loadBenchRuns()
+	if err != nil {
+		return err
+	}
+
+	a, err := findBenchRun(runs, c.compareRuns[0])
+	if err != nil {
+		return err
+	}
+	b, err := findBenchRun(runs, c.compareRuns[1])
+	if err != nil {
+		return err
+	}
+
+	if a.Type != b.Type {
+		log.Printf("WARNING: comparing a %s benchmark with a %s benchmark", a.Type, b.Type)
+	}
+	if a.MsgSize != b.MsgSize {
+		log.Printf("WARNING: comparing runs using %s and %s messages", humanize.IBytes(uint64(a.MsgSize)), humanize.IBytes(uint64(b.MsgSize)))
+	}
+
+	table := iu.NewTableWriter(opts(), fmt.Sprintf("Comparing benchmark runs %s and %s", a.ID, b.ID))
+	table.AddHeaders("", a.ID, b.ID, "Change")
+	table.AddRow("Type", a.Type, b.Type, "")
+	table.AddRow("Time", f(a.Time.Local()), f(b.Time.Local()), "")
+	table.AddRow("Server", a.Server, b.Server, "")
+
+	addStats := func(name string, sa *benchRunStats, sb *benchRunStats) {
+		if sa == nil || sb == nil {
+			return
+		}
+
+		table.AddRow(name+" Msgs/sec", f(sa.MsgsPerSec), f(sb.MsgsPerSec), benchDelta(float64(sa.MsgsPerSec), float64(sb.MsgsPerSec), true))
+		table.AddRow(name+" Throughput", humanize.IBytes(uint64(sa.BytesPerSec))+"/s", humanize.IBytes(uint64(sb.BytesPerSec))+"/s", benchDelta(sa.BytesPerSec, sb.BytesPerSec, true))
+		table.AddRow(name+" Avg Client Rate", f(sa.AvgRate), f(sb.AvgRate), benchDelta(float64(sa.AvgRate), float64(sb.AvgRate), true))
+	}
+	addStats("Publisher", a.Pubs, b.Pubs)
+	addStats("Subscriber", a.Subs, b.Subs)
+
+	if a.Latency != nil && b.Latency != nil {
+		for _, l := range []struct {
+			name string
+			a, b time.Duration
+		}{
+			{"Latency P50", a.Latency.P50, b.Latency.P50},
+			{"Latency P90", a.Latency.P90, b.Latency.P90},
+			{"Latency P99", a.Latency.P99, b.Latency.P99},
+			{"Latency P99.9", a.Latency.P999, b.Latency.P999},
+			{"Latency Max", a.Latency.Max, b.Latency.Max},
+		} {
+			table.AddRow(l.name, f(l.a), f(l.b), benchDelta(float64(l.a), float64(l.b), false))
+		}
+	}
+
+	fmt.Println(table.Render())
+
+	return nil
+}
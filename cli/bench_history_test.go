@@ -0,0 +1,65 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+func TestBenchHistory(t *testing.T) {
+	l := &benchLatencies{}
+	if l.percentiles() != nil {
+		t.Fatalf("expected no percentiles without samples")
+	}
+
+	for i := 100; i > 0; i-- {
+		l.samples = append(l.samples, time.Duration(i)*time.Millisecond)
+	}
+
+	p := l.percentiles()
+	if p.P50 != 51*time.Millisecond || p.P90 != 91*time.Millisecond || p.P99 != 100*time.Millisecond || p.Max != 100*time.Millisecond {
+		t.Fatalf("invalid percentiles %+v", p)
+	}
+
+	var nilLatencies *benchLatencies
+	nilLatencies.observe(time.Now())
+	if nilLatencies.percentiles() != nil {
+		t.Fatalf("expected nil latencies to have no percentiles")
+	}
+
+	runs := []*benchRun{{ID: "3f2a91aa"}, {ID: "3f2b00bb"}, {ID: "8c0d4e11"}}
+	run, err := findBenchRun(runs, "8c")
+	if err != nil || run.ID != "8c0d4e11" {
+		t.Fatalf("expected to find run 8c0d4e11: %v", err)
+	}
+	_, err = findBenchRun(runs, "3f2")
+	if err == nil {
+		t.Fatalf("expected ambiguous prefix to fail")
+	}
+	_, err = findBenchRun(runs, "ff")
+	if err == nil {
+		t.Fatalf("expected unknown run to fail")
+	}
+
+	color.NoColor = true
+	if d := benchDelta(100, 110, true); d != "+10.0%" {
+		t.Fatalf("invalid delta %q", d)
+	}
+	if d := benchDelta(0, 110, true); d != "" {
+		t.Fatalf("expected no delta from zero, got %q", d)
+	}
+}
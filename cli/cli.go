@@ -16,13 +16,16 @@ package cli
 import (
 	"context"
 	"embed"
+	"errors"
 	"github.com/nats-io/natscli/options"
 	glog "log"
+	"os"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/choria-io/fisk"
+	"github.com/nats-io/nats.go"
 )
 
 type command struct {
@@ -51,6 +54,9 @@ var (
 	log      Logger
 	ctx      context.Context
 
+	// selectedCommand is the full name of the command being run, used to identify connections
+	selectedCommand string
+
 	//go:embed cheats
 	fs embed.FS
 
@@ -68,6 +74,18 @@ func registerCommand(name string, order int, c func(app commandHost)) {
 // SkipContexts used during tests
 var SkipContexts bool
 
+// ExitCodeError is returned by commands that need to exit with a specific code after reporting Err
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string { return e.Err.Error() }
+func (e *ExitCodeError) Unwrap() error { return e.Err }
+
+// ErrDifferencesFound is returned by commands that compare configurations when differences were shown, it should result in exit code 1
+var ErrDifferencesFound = errors.New("differences found")
+
 func SetVersion(v string) {
 	mu.Lock()
 	defer mu.Unlock()
@@ -96,7 +114,8 @@ func commonConfigure(cmd commandHost, cliOpts *options.Options, disable ...strin
 		options.DefaultOptions = cliOpts
 	} else {
 		options.DefaultOptions = &options.Options{
-			Timeout: 5 * time.Second,
+			Timeout:       5 * time.Second,
+			ReconnectWait: nats.DefaultReconnectWait,
 		}
 	}
 
@@ -106,6 +125,7 @@ func commonConfigure(cmd commandHost, cliOpts *options.Options, disable ...strin
 
 	ctx = context.Background()
 	log = goLogger{}
+	fisk.CommandLine.Terminate(Exit)
 
 	sort.Slice(commands, func(i int, j int) bool {
 		return commands[i].Name < commands[j].Name
@@ -160,18 +180,40 @@ func ConfigureInApp(app *fisk.Application, cliOpts *options.Options, prepare boo
 	return options.DefaultOptions, nil
 }
 
-func preAction(_ *fisk.ParseContext) (err error) {
+func preAction(pc *fisk.ParseContext) (err error) {
+	if pc != nil && pc.SelectedCommand != nil {
+		selectedCommand = pc.SelectedCommand.FullCommand()
+	}
+
 	loadContext(true)
-	return nil
+
+	err = checkReadOnlyCommand(selectedCommand)
+	if err != nil {
+		return err
+	}
+
+	return checkAuditFile()
 }
 
 type goLogger struct{}
 
-func (goLogger) Fatalf(format string, a ...any) { glog.Fatalf(format, a...) }
+func (goLogger) Fatalf(format string, a ...any) { flushOutput(); glog.Fatalf(format, a...) }
 func (goLogger) Printf(format string, a ...any) { glog.Printf(format, a...) }
 func (goLogger) Print(a ...any)                 { glog.Print(a...) }
 func (goLogger) Println(a ...any)               { glog.Println(a...) }
-func (goLogger) Fatal(a ...any)                 { glog.Fatal(a...) }
+func (goLogger) Fatal(a ...any)                 { flushOutput(); glog.Fatal(a...) }
+
+// Exit writes output collected for paging and audit records of unanswered requests before exiting with code, fisk uses
+// it to terminate so fatal errors do not lose output
+func Exit(code int) {
+	flushOutput()
+	os.Exit(code)
+}
+
+func flushOutput() {
+	flushPagedOutput()
+	flushAuditRecords()
+}
 
 func opts() *options.Options {
 	return options.DefaultOptions
@@ -0,0 +1,155 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/nats.go"
+)
+
+type completionCmd struct {
+	shell string
+}
+
+// completionTimeout limits how long dynamic completions wait for the server so that a slow or unreachable server does not block the shell
+const completionTimeout = 2 * time.Second
+
+const fishCompletionScript = `function __complete_{{.App.Name}}
+    set -l tokens (commandline -opc) (commandline -ct)
+    $tokens[1] --completion-bash $tokens[2..-1]
+end
+
+complete -c {{.App.Name}} -f -a '(__complete_{{.App.Name}})'
+`
+
+func configureCompletionCommand(app commandHost) {
+	c := &completionCmd{}
+
+	completion := app.Command("completion", "Generates shell completion scripts").Action(c.generateAction)
+	addCheat("completion", completion)
+	completion.HelpLong(`Generates a completion script for the given shell, Stream and Consumer names
+are completed by querying the server using the selected context.
+
+   bash: source <(nats completion bash)
+    zsh: source <(nats completion zsh)
+   fish: nats completion fish | source`)
+	completion.Arg("shell", "The shell to generate a script for (bash, zsh, fish)").Required().EnumVar(&c.shell, "bash", "zsh", "fish")
+}
+
+func init() {
+	registerCommand("completion", 19, configureCompletionCommand)
+}
+
+func (c *completionCmd) generateAction(_ *fisk.ParseContext) error {
+	script, err := completionScript(c.shell, "nats")
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(script)
+
+	return nil
+}
+
+// completionScript renders the completion script for shell for the command called name
+func completionScript(shell string, name string) (string, error) {
+	var body string
+
+	switch shell {
+	case "bash":
+		body = fisk.BashCompletionTemplate
+	case "zsh":
+		body = fisk.ZshCompletionTemplate
+	case "fish":
+		body = fishCompletionScript
+	default:
+		return "", fmt.Errorf("unsupported shell %q", shell)
+	}
+
+	tmpl, err := template.New(shell).Parse(body)
+	if err != nil {
+		return "", err
+	}
+
+	var data struct{ App struct{ Name string } }
+	data.App.Name = name
+
+	buf := bytes.NewBuffer(nil)
+	err = tmpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// completionConnect connects to the server using the selected context for dynamic completions,
+// pre actions do not run while completing so the context is loaded here
+func completionConnect() (*nats.Conn, error) {
+	if opts().Config == nil {
+		err := loadContext(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts().Timeout == 0 || opts().Timeout > completionTimeout {
+		opts().Timeout = completionTimeout
+	}
+
+	nc, _, err := prepareHelper("", append(natsOpts(), nats.Timeout(completionTimeout), nats.MaxReconnects(0))...)
+
+	return nc, err
+}
+
+// completeStreamNames lists Stream names for shell completion, errors result in no suggestions
+func completeStreamNames() []string {
+	_, err := completionConnect()
+	if err != nil {
+		return nil
+	}
+
+	names, err := opts().Mgr.StreamNames(nil)
+	if err != nil {
+		return nil
+	}
+
+	return names
+}
+
+// completeConsumerNames creates a completion hint listing the Consumers on the Stream held in stream, errors result in no suggestions
+func completeConsumerNames(stream *string) fisk.HintAction {
+	return func() []string {
+		if *stream == "" {
+			return nil
+		}
+
+		_, err := completionConnect()
+		if err != nil {
+			return nil
+		}
+
+		names, err := opts().Mgr.ConsumerNames(*stream)
+		if err != nil {
+			return nil
+		}
+
+		return names
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompletionScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		script, err := completionScript(shell, "nats")
+		if err != nil {
+			t.Fatalf("%s failed: %v", shell, err)
+		}
+		if !bytes.Contains([]byte(script), []byte("--completion-bash")) {
+			t.Fatalf("%s script does not use dynamic completion: %s", shell, script)
+		}
+		if bytes.Contains([]byte(script), []byte("{{")) {
+			t.Fatalf("%s script was not rendered: %s", shell, script)
+		}
+	}
+
+	_, err := completionScript("csh", "nats")
+	if err == nil {
+		t.Fatalf("expected unsupported shell error")
+	}
+}
@@ -0,0 +1,783 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/jsm.go/natscontext"
+	"github.com/nats-io/nats.go"
+)
+
+// publishGuard refuses messages that modify assets in read-only mode, allowing the ephemeral consumers used to read
+// streams to be created and removed, and writes them to the audit log when auditing. Configurations received from
+// the JetStream API are tracked so audit records can hold the digest of an asset before it was changed
+type publishGuard struct {
+	readOnly bool
+	audit    string
+	// context and natsUser identify the connection in audit records
+	context  string
+	natsUser string
+
+	mu        sync.Mutex
+	ephemeral map[string]bool
+	replies   map[string]guardRequest
+	configs   map[string]string
+}
+
+// guardRequest is a request awaiting its response, record is written to the audit log once the response arrives
+type guardRequest struct {
+	resource string
+	record   *auditRecord
+}
+
+// maxGuardReplies limits how many unanswered requests are tracked for configurations and audit records
+const maxGuardReplies = 10000
+
+var (
+	// auditGuards are the guards writing audit records on connections made by this process
+	auditGuards   []*publishGuard
+	auditGuardsMu sync.Mutex
+)
+
+func newPublishGuard(readOnly bool, audit string) *publishGuard {
+	return &publishGuard{
+		readOnly:  readOnly,
+		audit:     audit,
+		ephemeral: make(map[string]bool),
+		replies:   make(map[string]guardRequest),
+		configs:   make(map[string]string),
+	}
+}
+
+func (g *publishGuard) inspects(subject string) bool {
+	if g.audit != "" {
+		_, ok := jsAPIOperation(subject)
+		if ok {
+			return true
+		}
+	}
+
+	return isMutatingSubject(subject)
+}
+
+func (g *publishGuard) allow(subject string, reply string, _ []byte, data []byte) (bool, []byte) {
+	if !isMutatingSubject(subject) || g.ephemeralConsumer(subject, data) {
+		g.await(subject, reply, data, nil)
+		return true, nil
+	}
+
+	var record *auditRecord
+	if g.audit != "" && !auditSkipped(subject) {
+		r := newAuditRecord(g.context, g.natsUser, subject, data, g.before(subject, data))
+		record = &r
+	}
+
+	if g.readOnly {
+		if record != nil {
+			record.Result = auditRefused
+			record.Error = "refused in read-only mode"
+			writeAuditRecord(g.audit, *record)
+		}
+
+		return false, readOnlyRefusal(subject, reply)
+	}
+
+	// the outcome of messages without a reply is never known, others are recorded once their response arrives
+	if record != nil && reply == "" {
+		record.Result = auditSent
+		writeAuditRecord(g.audit, *record)
+		record = nil
+	}
+
+	g.await(subject, reply, data, record)
+
+	return true, nil
+}
+
+// readOnlyRefusal is the response to a request to subject refused in read-only mode, an API error so requests fail
+// immediately with a clear error. Acknowledgements are not answered as any response confirms them
+func readOnlyRefusal(subject string, reply string) []byte {
+	if reply == "" || strings.HasPrefix(subject, "$JS.ACK.") {
+		log.Printf("Refusing to publish to %s in read-only mode", subject)
+		return nil
+	}
+
+	resp, _ := json.Marshal(map[string]any{"error": map[string]any{
+		"code":        403,
+		"description": fmt.Sprintf("refusing to publish to %s in read-only mode", subject),
+	}})
+
+	return resp
+}
+
+// before is the digest of the configuration last received for the asset a request to subject acts on
+func (g *publishGuard) before(subject string, data []byte) string {
+	_, resource, _ := auditResource(subject, data)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.configs[resource]
+}
+
+// await tracks the response to JetStream API requests when auditing so the configuration it holds can be recorded,
+// and to audited requests so record can be written with their result
+func (g *publishGuard) await(subject string, reply string, data []byte, record *auditRecord) {
+	if g.audit == "" || reply == "" {
+		return
+	}
+
+	_, ok := jsAPIOperation(subject)
+	if !ok && record == nil {
+		return
+	}
+
+	_, resource, _ := auditResource(subject, data)
+
+	g.mu.Lock()
+	var dropped map[string]guardRequest
+	if len(g.replies) >= maxGuardReplies {
+		dropped = g.replies
+		g.replies = make(map[string]guardRequest)
+	}
+	g.replies[reply] = guardRequest{resource: resource, record: record}
+	g.mu.Unlock()
+
+	g.writeUnanswered(dropped)
+}
+
+func (g *publishGuard) awaits(subject string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, ok := g.replies[subject]
+
+	return ok
+}
+
+func (g *publishGuard) received(subject string, hdr []byte, data []byte) {
+	var resp struct {
+		Config json.RawMessage `json:"config"`
+		Error  *struct {
+			Description string `json:"description"`
+		} `json:"error"`
+	}
+	json.Unmarshal(data, &resp)
+
+	status, description := responseStatus(hdr)
+	digest := auditConfigDigest(resp.Config)
+	failed := resp.Error != nil || status != ""
+
+	g.mu.Lock()
+	req, ok := g.replies[subject]
+	if ok {
+		delete(g.replies, subject)
+		if digest != "" && !failed {
+			g.configs[req.resource] = digest
+		}
+	}
+	g.mu.Unlock()
+
+	if !ok || req.record == nil {
+		return
+	}
+
+	record := *req.record
+
+	switch {
+	case resp.Error != nil:
+		record.Result = auditFailed
+		record.Error = resp.Error.Description
+	case status == "503":
+		record.Result = auditFailed
+		record.Error = "no responders available"
+	case status != "":
+		record.Result = auditFailed
+		record.Error = strings.TrimSpace(status + " " + description)
+	default:
+		record.Result = auditSucceeded
+		// the configuration in the response includes the defaults set by the server
+		if digest != "" {
+			record.After = digest
+		}
+	}
+
+	writeAuditRecord(g.audit, record)
+}
+
+// flush writes the audit records of requests still awaiting their response
+func (g *publishGuard) flush() {
+	g.mu.Lock()
+	unanswered := make(map[string]guardRequest)
+	for reply, req := range g.replies {
+		if req.record != nil {
+			unanswered[reply] = req
+			delete(g.replies, reply)
+		}
+	}
+	g.mu.Unlock()
+
+	g.writeUnanswered(unanswered)
+}
+
+// writeUnanswered writes the audit records of requests that will not see their response
+func (g *publishGuard) writeUnanswered(reqs map[string]guardRequest) {
+	for _, req := range reqs {
+		if req.record == nil {
+			continue
+		}
+
+		record := *req.record
+		record.Result = auditNoResponse
+		writeAuditRecord(g.audit, record)
+	}
+}
+
+// flushAuditRecords writes the audit records of requests still awaiting their response on all connections, the
+// result of those requests is unknown
+func flushAuditRecords() {
+	auditGuardsMu.Lock()
+	guards := auditGuards
+	auditGuards = nil
+	auditGuardsMu.Unlock()
+
+	for _, g := range guards {
+		g.flush()
+	}
+}
+
+// responseStatus is the status code and description in the headers of a response, they are empty when it has none
+func responseStatus(hdr []byte) (string, string) {
+	line, _, _ := bytes.Cut(hdr, []byte("\r\n"))
+	fields := strings.SplitN(strings.TrimSpace(string(line)), " ", 3)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "NATS/") {
+		return "", ""
+	}
+
+	if len(fields) == 3 {
+		return fields[1], fields[2]
+	}
+
+	return fields[1], ""
+}
+
+// ephemeralConsumer determines if subject and data creates an ephemeral consumer or removes one created earlier on
+// the same connection, it remembers the names of those it created
+func (g *publishGuard) ephemeralConsumer(subject string, data []byte) bool {
+	op, ok := jsAPIOperation(subject)
+	if !ok {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	tokens := strings.Split(op, ".")
+
+	switch {
+	case len(tokens) >= 3 && tokens[0] == "CONSUMER" && tokens[1] == "CREATE":
+		var req struct {
+			Config struct {
+				Durable string `json:"durable_name"`
+				Name    string `json:"name"`
+			} `json:"config"`
+		}
+
+		err := json.Unmarshal(data, &req)
+		if err != nil || req.Config.Durable != "" {
+			return false
+		}
+
+		name := req.Config.Name
+		if len(tokens) >= 4 {
+			name = tokens[3]
+		}
+		if name != "" {
+			g.ephemeral[tokens[2]+"."+name] = true
+		}
+
+		return true
+
+	case len(tokens) == 4 && tokens[0] == "CONSUMER" && tokens[1] == "DELETE":
+		key := tokens[2] + "." + tokens[3]
+		if !g.ephemeral[key] {
+			return false
+		}
+		delete(g.ephemeral, key)
+
+		return true
+	}
+
+	return false
+}
+
+// contextGuardOpts returns the options that install the connection guard on connections to the context name
+// configured by nctx when read-only mode or auditing is enabled, they must follow all other options
+func contextGuardOpts(name string, nctx *natscontext.Context) []nats.Option {
+	readOnly, err := readOnlyContext(name)
+	if err != nil {
+		log.Printf("Enabling read-only mode: %v", err)
+	}
+	audit := auditFile()
+
+	if !readOnly && audit == "" {
+		return nil
+	}
+
+	g := newPublishGuard(readOnly, audit)
+	g.context = name
+
+	// without a password the user setting is a token
+	if nctx != nil && nctx.Password() != "" {
+		g.natsUser = nctx.User()
+	}
+
+	if audit != "" {
+		auditGuardsMu.Lock()
+		auditGuards = append(auditGuards, g)
+		auditGuardsMu.Unlock()
+	}
+
+	return []nats.Option{guardPublishes(g, readOnly)}
+}
+
+// publishFilter inspects messages before they are sent and the responses received to them
+type publishFilter interface {
+	// inspects determines if messages to subject should be held until complete and passed to allow
+	inspects(subject string) bool
+	// allow determines if a message may be sent, hdr and data are the raw headers and payload. Refused messages
+	// with a reply are answered with the returned response when there is one
+	allow(subject string, reply string, hdr []byte, data []byte) (bool, []byte)
+	// awaits determines if messages received on subject should be passed to received
+	awaits(subject string) bool
+	// received is shown the headers and payload of messages received on awaited subjects
+	received(subject string, hdr []byte, data []byte)
+}
+
+// guardConn passes messages published on a connection through a publishFilter, all other protocol operations are
+// written unchanged, and shows it the messages received on subjects it awaits
+type guardConn struct {
+	net.Conn
+	filter publishFilter
+
+	// data read from the connection while setting it up that the client has not seen yet
+	pending []byte
+
+	mu  sync.Mutex
+	out protoScanner
+	in  protoScanner
+}
+
+// protoScanner tracks protocol lines and message payloads in one direction of a connection
+type protoScanner struct {
+	line      []byte
+	remaining int
+	held      []byte
+	subject   string
+	reply     string
+	lineLen   int
+	hdrLen    int
+}
+
+// message returns the headers and payload of the held message once it is complete
+func (s *protoScanner) message() ([]byte, []byte) {
+	msg := s.held[s.lineLen : len(s.held)-2]
+	return msg[:s.hdrLen], msg[s.hdrLen:]
+}
+
+func (c *guardConn) Read(b []byte) (int, error) {
+	var n int
+	var err error
+
+	if len(c.pending) > 0 {
+		n = copy(b, c.pending)
+		c.pending = c.pending[n:]
+	} else {
+		n, err = c.Conn.Read(b)
+	}
+
+	if n > 0 {
+		c.scanReceived(b[:n])
+	}
+
+	return n, err
+}
+
+// scanReceived passes complete messages on subjects the filter awaits to it, data is never changed
+func (c *guardConn) scanReceived(b []byte) {
+	s := &c.in
+
+	for len(b) > 0 {
+		if s.remaining > 0 {
+			n := min(len(b), s.remaining)
+			if s.held != nil {
+				s.held = append(s.held, b[:n]...)
+			}
+			s.remaining -= n
+			b = b[n:]
+
+			if s.remaining == 0 && s.held != nil {
+				c.filter.received(s.subject, s.message())
+				s.held = nil
+			}
+
+			continue
+		}
+
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			s.line = append(s.line, b...)
+			break
+		}
+
+		line := append(s.line, b[:i+1]...)
+		s.line = nil
+		b = b[i+1:]
+
+		subject, _, hdrLen, size, ok := parseMessageLine(line, "MSG", "HMSG", 1)
+		if !ok {
+			continue
+		}
+
+		s.remaining = size + 2
+		if c.filter.awaits(subject) {
+			s.held = line
+			s.subject = subject
+			s.lineLen = len(line)
+			s.hdrLen = hdrLen
+		}
+	}
+}
+
+func (c *guardConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := &c.out
+	total := len(b)
+	var out []byte
+
+	for len(b) > 0 {
+		// inside the payload of a message, held messages are only sent once complete and allowed
+		if s.remaining > 0 {
+			n := min(len(b), s.remaining)
+			if s.held != nil {
+				s.held = append(s.held, b[:n]...)
+			} else {
+				out = append(out, b[:n]...)
+			}
+			s.remaining -= n
+			b = b[n:]
+
+			if s.remaining == 0 && s.held != nil {
+				hdr, data := s.message()
+				allowed, resp := c.filter.allow(s.subject, s.reply, hdr, data)
+				switch {
+				case allowed:
+					out = append(out, s.held...)
+				case s.reply != "" && resp != nil:
+					// the server delivers the response to the subscription awaiting it
+					out = append(out, fmt.Sprintf("PUB %s %d\r\n", s.reply, len(resp))...)
+					out = append(out, resp...)
+					out = append(out, "\r\n"...)
+				}
+				s.held = nil
+			}
+
+			continue
+		}
+
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			s.line = append(s.line, b...)
+			break
+		}
+
+		line := append(s.line, b[:i+1]...)
+		s.line = nil
+		b = b[i+1:]
+
+		subject, reply, hdrLen, size, ok := parseMessageLine(line, "PUB", "HPUB", 0)
+		if !ok {
+			out = append(out, line...)
+			continue
+		}
+
+		s.remaining = size + 2
+		if c.filter.inspects(subject) {
+			s.held = line
+			s.subject = subject
+			s.reply = reply
+			s.lineLen = len(line)
+			s.hdrLen = hdrLen
+		} else {
+			out = append(out, line...)
+		}
+	}
+
+	if len(out) > 0 {
+		_, err := c.Conn.Write(out)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+// parseMessageLine parses a protocol line for a message without headers like PUB or MSG or with headers like HPUB or
+// HMSG returning the subject, reply, header size and total message size. MSG and HMSG have an extra subscription id
+// after the subject which skip tells it to ignore
+func parseMessageLine(line []byte, plain string, headers string, skip int) (subject string, reply string, hdrLen int, size int, ok bool) {
+	fields := strings.Fields(string(line))
+	if len(fields) < 3+skip {
+		return "", "", 0, 0, false
+	}
+
+	op := strings.ToUpper(fields[0])
+	subject = fields[1]
+	args := fields[2+skip:]
+
+	var err error
+
+	switch op {
+	case plain:
+		if len(args) > 2 {
+			return "", "", 0, 0, false
+		}
+		size, err = strconv.Atoi(args[len(args)-1])
+
+	case headers:
+		if len(args) < 2 || len(args) > 3 {
+			return "", "", 0, 0, false
+		}
+		hdrLen, err = strconv.Atoi(args[len(args)-2])
+		if err == nil {
+			size, err = strconv.Atoi(args[len(args)-1])
+		}
+		if err == nil && hdrLen > size {
+			err = fmt.Errorf("invalid header size")
+		}
+
+	default:
+		return "", "", 0, 0, false
+	}
+
+	if err != nil || size < 0 || hdrLen < 0 {
+		return "", "", 0, 0, false
+	}
+
+	if (op == plain && len(args) == 2) || (op == headers && len(args) == 3) {
+		reply = args[0]
+	}
+
+	return subject, reply, hdrLen, size, true
+}
+
+// guardDialer wraps connections in a guardConn, it performs any TLS handshake itself so the filter sees the
+// protocol rather than encrypted data and tells the client to skip its own handshake
+type guardDialer struct {
+	dialer    nats.CustomDialer
+	filter    publishFilter
+	timeout   time.Duration
+	secure    bool
+	tlsFirst  bool
+	tlsConfig *tls.Config
+	certCB    nats.TLSCertHandler
+	rootCAsCB nats.RootCAsHandler
+	// hostname verifies certificates of servers that are known by IP address only, like discovered cluster members
+	hostname string
+}
+
+func (d *guardDialer) SkipTLSHandshake() bool { return true }
+
+func (d *guardDialer) Dial(network, address string) (net.Conn, error) {
+	conn, err := d.dialer.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	gc, err := d.setup(conn, address)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return gc, nil
+}
+
+func (d *guardDialer) setup(conn net.Conn, address string) (*guardConn, error) {
+	gc := &guardConn{Conn: conn, filter: d.filter}
+
+	if d.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(d.timeout))
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	if d.tlsFirst {
+		tconn, err := d.tlsClient(conn, address)
+		if err != nil {
+			return nil, err
+		}
+		gc.Conn = tconn
+
+		return gc, nil
+	}
+
+	// the server sends INFO before any TLS handshake, it is kept for the client to read
+	br := bufio.NewReader(conn)
+	line, err := br.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	gc.pending = line
+
+	var info struct {
+		TLSRequired  bool `json:"tls_required"`
+		TLSAvailable bool `json:"tls_available"`
+	}
+	if len(line) > 5 && strings.EqualFold(string(line[:5]), "INFO ") {
+		json.Unmarshal(bytes.TrimSpace(line[5:]), &info)
+	}
+
+	// the client reports servers without TLS when it wants TLS so the handshake is only done when it would do it
+	if !info.TLSRequired && !(d.secure && info.TLSAvailable) {
+		buffered, _ := br.Peek(br.Buffered())
+		gc.pending = append(gc.pending, buffered...)
+		return gc, nil
+	}
+
+	if br.Buffered() > 0 {
+		return nil, fmt.Errorf("unexpected data received before the TLS handshake")
+	}
+
+	tconn, err := d.tlsClient(conn, address)
+	if err != nil {
+		return nil, err
+	}
+	gc.Conn = tconn
+
+	return gc, nil
+}
+
+// tlsClient performs a TLS handshake configured like the client would configure its own
+func (d *guardDialer) tlsClient(conn net.Conn, address string) (net.Conn, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if d.tlsConfig != nil {
+		cfg = d.tlsConfig.Clone()
+	}
+
+	if d.certCB != nil {
+		cert, err := d.certCB()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if d.rootCAsCB != nil {
+		pool, err := d.rootCAsCB()
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		if net.ParseIP(host) != nil && d.hostname != "" {
+			host = d.hostname
+		}
+		cfg.ServerName = host
+	}
+
+	tconn := tls.Client(conn, cfg)
+	err := tconn.Handshake()
+	if err != nil {
+		return nil, err
+	}
+
+	return tconn, nil
+}
+
+// guardPublishes passes messages published on connections made using the options configured so far through filter,
+// it must be the last option so it sees the final TLS and dialer settings. Websocket connections cannot be guarded,
+// they fail when enforcing read-only mode and are not audited otherwise
+func guardPublishes(filter publishFilter, readOnly bool) nats.Option {
+	return func(o *nats.Options) error {
+		d := &guardDialer{
+			dialer:    o.CustomDialer,
+			filter:    filter,
+			timeout:   o.Timeout,
+			secure:    o.Secure,
+			tlsFirst:  o.TLSHandshakeFirst,
+			tlsConfig: o.TLSConfig,
+			certCB:    o.TLSCertCB,
+			rootCAsCB: o.RootCAsCB,
+		}
+
+		for _, s := range append(append([]string{}, o.Servers...), o.Url) {
+			for _, s := range strings.Split(s, ",") {
+				u, err := url.Parse(strings.TrimSpace(s))
+				if err != nil || u.Host == "" {
+					continue
+				}
+
+				switch u.Scheme {
+				case "ws", "wss":
+					if readOnly {
+						return fmt.Errorf("read-only mode is not supported on websocket connections")
+					}
+
+					log.Printf("WARNING: requests sent over websocket connections to %s are not written to the audit log", u.Redacted())
+					return nil
+				case "tls":
+					d.secure = true
+				}
+
+				if d.hostname == "" && net.ParseIP(u.Hostname()) == nil {
+					d.hostname = u.Hostname()
+				}
+			}
+		}
+
+		if d.dialer == nil {
+			if o.Dialer != nil {
+				d.dialer = o.Dialer
+			} else {
+				d.dialer = &net.Dialer{Timeout: o.Timeout}
+			}
+		}
+
+		// certificates are verified against the host names in server URLs so those are dialed without resolving them
+		o.SkipHostLookup = true
+		o.CustomDialer = d
+
+		return nil
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/natscli/options"
+)
+
+type testPublishFilter struct {
+	allowed  []string
+	denied   []string
+	received []string
+}
+
+func (f *testPublishFilter) inspects(subject string) bool { return subject != "orders.new" }
+
+func (f *testPublishFilter) allow(subject string, reply string, hdr []byte, data []byte) (bool, []byte) {
+	if string(data) == "deny" {
+		f.denied = append(f.denied, subject)
+		return false, []byte("denied")
+	}
+
+	f.allowed = append(f.allowed, subject+":"+reply+":"+string(hdr)+string(data))
+	return true, nil
+}
+
+func (f *testPublishFilter) awaits(subject string) bool { return subject == "_INBOX.1" }
+
+func (f *testPublishFilter) received(subject string, hdr []byte, data []byte) {
+	f.received = append(f.received, subject+":"+string(hdr)+string(data))
+}
+
+func TestParseMessageLine(t *testing.T) {
+	cases := []struct {
+		line    string
+		subject string
+		reply   string
+		hdr     int
+		size    int
+		ok      bool
+	}{
+		{"PUB x 5\r\n", "x", "", 0, 5, true},
+		{"PUB x _INBOX.1 5\r\n", "x", "_INBOX.1", 0, 5, true},
+		{"HPUB x 12 20\r\n", "x", "", 12, 20, true},
+		{"HPUB x _INBOX.1 12 20\r\n", "x", "_INBOX.1", 12, 20, true},
+		{"HPUB x 30 20\r\n", "", "", 0, 0, false},
+		{"SUB x 1\r\n", "", "", 0, 0, false},
+		{"PING\r\n", "", "", 0, 0, false},
+		{"PUB x y\r\n", "", "", 0, 0, false},
+	}
+
+	for _, c := range cases {
+		subject, reply, hdr, size, ok := parseMessageLine([]byte(c.line), "PUB", "HPUB", 0)
+		if subject != c.subject || reply != c.reply || hdr != c.hdr || size != c.size || ok != c.ok {
+			t.Fatalf("%q: got %q %q %d %d %v", c.line, subject, reply, hdr, size, ok)
+		}
+	}
+
+	subject, reply, hdr, size, ok := parseMessageLine([]byte("HMSG x 1 r 12 20\r\n"), "MSG", "HMSG", 1)
+	if subject != "x" || reply != "r" || hdr != 12 || size != 20 || !ok {
+		t.Fatalf("invalid HMSG parse: %q %q %d %d %v", subject, reply, hdr, size, ok)
+	}
+
+	subject, reply, _, size, ok = parseMessageLine([]byte("MSG x 1 5\r\n"), "MSG", "HMSG", 1)
+	if subject != "x" || reply != "" || size != 5 || !ok {
+		t.Fatalf("invalid MSG parse: %q %q %d %v", subject, reply, size, ok)
+	}
+}
+
+func TestGuardConnWrite(t *testing.T) {
+	client, server := net.Pipe()
+	filter := &testPublishFilter{}
+	gc := &guardConn{Conn: client, filter: filter, pending: []byte("INFO {}\r\n")}
+
+	received := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(server)
+		received <- string(b)
+	}()
+
+	info := make([]byte, 64)
+	n, err := gc.Read(info)
+	if err != nil || string(info[:n]) != "INFO {}\r\n" {
+		t.Fatalf("expected pending INFO, got %q: %v", info[:n], err)
+	}
+
+	// messages are split across writes the way a buffered writer would flush them
+	writes := []string{
+		"CONNECT {}\r\nPING\r\nPUB orders.new 2\r\nhi\r\nPUB $JS.API.STREAM.DELETE.X _INBOX.1 4",
+		"\r\nde",
+		"ny\r\nHPUB $KV.X.k 12 15\r\nNATS/1.0\r\n\r\nabc\r\nSUB _INBOX.> 1\r",
+		"\nPUB $KV.X.k 0\r\n\r\n",
+	}
+
+	for _, w := range writes {
+		n, err := gc.Write([]byte(w))
+		if err != nil || n != len(w) {
+			t.Fatalf("write failed: %d %v", n, err)
+		}
+	}
+	client.Close()
+
+	expected := "CONNECT {}\r\nPING\r\nPUB orders.new 2\r\nhi\r\nPUB _INBOX.1 6\r\ndenied\r\nHPUB $KV.X.k 12 15\r\nNATS/1.0\r\n\r\nabc\r\nSUB _INBOX.> 1\r\nPUB $KV.X.k 0\r\n\r\n"
+	if got := <-received; got != expected {
+		t.Fatalf("expected %q got %q", expected, got)
+	}
+
+	if len(filter.denied) != 1 || filter.denied[0] != "$JS.API.STREAM.DELETE.X" {
+		t.Fatalf("unexpected denied: %v", filter.denied)
+	}
+
+	if len(filter.allowed) != 2 || filter.allowed[0] != "$KV.X.k::NATS/1.0\r\n\r\nabc" || filter.allowed[1] != "$KV.X.k::" {
+		t.Fatalf("unexpected allowed: %q", filter.allowed)
+	}
+}
+
+func TestGuardConnRead(t *testing.T) {
+	client, server := net.Pipe()
+	filter := &testPublishFilter{}
+	gc := &guardConn{Conn: client, filter: filter}
+
+	sent := "INFO {}\r\nMSG orders.new 1 2\r\nhi\r\nMSG _INBOX.1 2 5\r\nhello\r\nPING\r\nHMSG _INBOX.1 2 12 14\r\nNATS/1.0\r\n\r\nhi\r\n"
+	go func() {
+		// small writes split protocol lines and payloads across reads
+		for i := 0; i < len(sent); i += 7 {
+			server.Write([]byte(sent[i:min(i+7, len(sent))]))
+		}
+		server.Close()
+	}()
+
+	b, err := io.ReadAll(gc)
+	if err != nil || string(b) != sent {
+		t.Fatalf("expected data to be unchanged, got %q: %v", b, err)
+	}
+
+	if len(filter.received) != 2 || filter.received[0] != "_INBOX.1:hello" || filter.received[1] != "_INBOX.1:NATS/1.0\r\n\r\nhi" {
+		t.Fatalf("unexpected received: %q", filter.received)
+	}
+}
+
+func TestPublishGuardEphemeralConsumers(t *testing.T) {
+	prevOpts, prevLog := options.DefaultOptions, log
+	defer func() { options.DefaultOptions, log = prevOpts, prevLog }()
+	options.DefaultOptions, log = &options.Options{}, goLogger{}
+
+	g := newPublishGuard(true, "")
+	allowed := func(g *publishGuard, subject string, data []byte) bool {
+		ok, _ := g.allow(subject, "", nil, data)
+		return ok
+	}
+
+	if !allowed(g, "$JS.API.CONSUMER.CREATE.ORDERS.eph_1.orders.>", []byte(`{"stream_name":"ORDERS","config":{"name":"eph_1"}}`)) {
+		t.Fatalf("expected ephemeral create to be allowed")
+	}
+
+	if allowed(g, "$JS.API.CONSUMER.CREATE.ORDERS.C1", []byte(`{"stream_name":"ORDERS","config":{"durable_name":"C1"}}`)) {
+		t.Fatalf("expected durable create to be denied")
+	}
+
+	if allowed(g, "$JS.API.CONSUMER.DELETE.ORDERS.C1", nil) {
+		t.Fatalf("expected durable delete to be denied")
+	}
+
+	if !allowed(g, "$JS.API.CONSUMER.DELETE.ORDERS.eph_1", nil) {
+		t.Fatalf("expected ephemeral delete to be allowed")
+	}
+
+	if allowed(g, "$JS.API.CONSUMER.DELETE.ORDERS.eph_1", nil) {
+		t.Fatalf("expected second ephemeral delete to be denied")
+	}
+
+	if allowed(g, "$JS.ACK.ORDERS.C1.1.1.1.1.0", nil) {
+		t.Fatalf("expected ack to be denied")
+	}
+
+	if !allowed(newPublishGuard(false, ""), "$JS.API.STREAM.DELETE.ORDERS", nil) {
+		t.Fatalf("expected delete to be allowed outside read-only mode")
+	}
+
+	// refused requests are answered so they fail immediately, except acknowledgements which any response confirms
+	ok, resp := g.allow("$JS.API.STREAM.DELETE.ORDERS", "_INBOX.1", nil, nil)
+	if ok || !strings.Contains(string(resp), "refusing to publish to $JS.API.STREAM.DELETE.ORDERS in read-only mode") {
+		t.Fatalf("expected refused delete to be answered, got %q", resp)
+	}
+
+	ok, resp = g.allow("$JS.ACK.ORDERS.C1.1.1.1.1.0", "_INBOX.2", nil, nil)
+	if ok || resp != nil {
+		t.Fatalf("expected refused ack to not be answered, got %q", resp)
+	}
+}
+
+func TestPublishGuardAudit(t *testing.T) {
+	prevOpts, prevLog := options.DefaultOptions, log
+	defer func() { options.DefaultOptions, log = prevOpts, prevLog }()
+	options.DefaultOptions, log = &options.Options{}, goLogger{}
+
+	file := filepath.Join(t.TempDir(), "audit.log")
+	g := newPublishGuard(false, file)
+	g.context = "test"
+
+	if !g.inspects("$JS.API.STREAM.INFO.ORDERS") || !g.inspects("$KV.CONFIG.k") || g.inspects("orders.new") {
+		t.Fatalf("invalid inspected subjects")
+	}
+
+	g.allow("$JS.API.STREAM.INFO.ORDERS", "_INBOX.1", nil, nil)
+	if !g.awaits("_INBOX.1") {
+		t.Fatalf("expected the info response to be awaited")
+	}
+	g.received("_INBOX.1", nil, []byte(`{"config":{"name":"ORDERS","subjects":["orders.>"]}}`))
+	if g.awaits("_INBOX.1") {
+		t.Fatalf("expected the info response to be handled")
+	}
+
+	g.allow("$JS.API.STREAM.UPDATE.ORDERS", "_INBOX.2", nil, []byte(`{"subjects":["orders.>"],"name":"ORDERS","max_msgs":10}`))
+	g.allow("$JS.ACK.ORDERS.C1.1.1.1.1.0", "", nil, nil)
+	g.allow("$O.FILES.C.abc", "", nil, []byte("chunk"))
+	g.allow("$KV.CONFIG.k", "", nil, []byte("v"))
+
+	// the put has no reply so it is recorded immediately, the update once its response arrives
+	f, err := os.ReadFile(file)
+	if err != nil || strings.Count(string(f), "\n") != 1 {
+		t.Fatalf("expected only the put to be recorded before the update response: %s: %v", f, err)
+	}
+
+	g.received("_INBOX.2", nil, []byte(`{"config":{"name":"ORDERS","subjects":["orders.>"],"max_msgs":10,"retention":"limits"}}`))
+
+	g.allow("$JS.API.STREAM.DELETE.ORDERS", "_INBOX.3", nil, nil)
+	g.received("_INBOX.3", nil, []byte(`{"error":{"code":404,"err_code":10059,"description":"stream not found"}}`))
+
+	g.allow("$JS.API.STREAM.PURGE.ORDERS", "_INBOX.4", nil, nil)
+	g.received("_INBOX.4", []byte("NATS/1.0 503\r\n\r\n"), nil)
+
+	g.allow("$JS.API.STREAM.PURGE.ORDERS", "_INBOX.5", nil, nil)
+	g.flush()
+
+	f, err = os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("could not read audit log: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(f)), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 records got %d: %s", len(lines), f)
+	}
+
+	records := make([]auditRecord, len(lines))
+	for i, line := range lines {
+		err = json.Unmarshal([]byte(line), &records[i])
+		if err != nil {
+			t.Fatalf("invalid record: %v", err)
+		}
+	}
+	put, update, del, noResponders, unanswered := records[0], records[1], records[2], records[3], records[4]
+
+	if update.Operation != "STREAM.UPDATE" || update.Resource != "stream ORDERS" || update.Context != "test" || update.Result != auditSucceeded || update.Error != "" {
+		t.Fatalf("invalid update record: %+v", update)
+	}
+	if update.Before != auditConfigDigest([]byte(`{"name":"ORDERS","subjects":["orders.>"]}`)) {
+		t.Fatalf("invalid before digest: %+v", update)
+	}
+	if update.After != auditConfigDigest([]byte(`{"name":"ORDERS","max_msgs":10,"retention":"limits","subjects":["orders.>"]}`)) || update.After == update.Before {
+		t.Fatalf("invalid after digest: %+v", update)
+	}
+
+	if put.Operation != "KV.PUT" || put.Resource != "kv CONFIG > k" || put.Result != auditSent {
+		t.Fatalf("invalid put record: %+v", put)
+	}
+
+	if del.Operation != "STREAM.DELETE" || del.Result != auditFailed || del.Error != "stream not found" {
+		t.Fatalf("invalid delete record: %+v", del)
+	}
+
+	if noResponders.Result != auditFailed || noResponders.Error != "no responders available" {
+		t.Fatalf("invalid no responders record: %+v", noResponders)
+	}
+
+	if unanswered.Operation != "STREAM.PURGE" || unanswered.Result != auditNoResponse {
+		t.Fatalf("invalid unanswered record: %+v", unanswered)
+	}
+}
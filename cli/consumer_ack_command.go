@@ -0,0 +1,260 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/jedib0t/go-pretty/v6/progress"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+func configureConsumerAckCommand(cons *fisk.CmdClause, c *consumerCmd) {
+	consAck := cons.Command("ack", "Acknowledges a backlog of messages to advance the Ack Floor of a Pull Consumer").Action(c.ackAction)
+	consAck.HelpLong(`Fetches and acknowledges messages in batches until the Ack Floor has
+advanced to the given stream sequence, the messages will not be processed
+by any other client of the Consumer.
+
+   nats consumer ack ORDERS NEW --up-to-seq 1000
+   nats consumer ack ORDERS NEW --all
+
+Messages already delivered to other clients and awaiting acknowledgement
+can only be acknowledged once they are redelivered after Ack Wait.`)
+	consAck.Arg("stream", "Stream name").StringVar(&c.stream)
+	consAck.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+	consAck.Flag("up-to-seq", "Acknowledge messages up to and including this stream sequence").PlaceHolder("SEQ").Uint64Var(&c.ackUpToSeq)
+	consAck.Flag("all", "Acknowledge all messages currently in the Stream").UnNegatableBoolVar(&c.ackAll)
+	consAck.Flag("batch", "Number of messages to fetch and acknowledge at a time").Default("1000").IntVar(&c.ackBatch)
+	consAck.Flag("progress", "Enable progress bar").Default("true").BoolVar(&c.showProgress)
+	consAck.Flag("force", "Acknowledge without prompting").Short('f').UnNegatableBoolVar(&c.force)
+}
+
+func (c *consumerCmd) ackAction(_ *fisk.ParseContext) error {
+	switch {
+	case c.ackAll && c.ackUpToSeq > 0:
+		return fmt.Errorf("--all and --up-to-seq are mutually exclusive")
+	case !c.ackAll && c.ackUpToSeq == 0:
+		return fmt.Errorf("either --all or --up-to-seq is required")
+	case c.ackBatch <= 0:
+		return fmt.Errorf("--batch must be greater than 0")
+	}
+
+	c.connectAndSetup(true, true)
+
+	consumer := c.selectedConsumer
+	if !consumer.IsPullMode() {
+		return fmt.Errorf("consumer %q is not a Pull consumer", c.consumer)
+	}
+	if consumer.AckPolicy() == api.AckNone {
+		return fmt.Errorf("consumer %q does not require acknowledgements", c.consumer)
+	}
+
+	target := c.ackUpToSeq
+	if c.ackAll {
+		stream, err := c.mgr.LoadStream(c.stream)
+		if err != nil {
+			return err
+		}
+		state, err := stream.State()
+		if err != nil {
+			return err
+		}
+		target = state.LastSeq
+	}
+
+	nfo, err := consumer.LatestState()
+	if err != nil {
+		return err
+	}
+	start := nfo.AckFloor.Stream
+
+	if start >= target {
+		fmt.Printf("Ack Floor of %s > %s is already at stream sequence %d\n", c.stream, c.consumer, start)
+		return nil
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really acknowledge messages up to stream sequence %d on %s > %s, they will not be processed", target, c.stream, c.consumer), false)
+		fisk.FatalIfError(err, "could not obtain confirmation")
+
+		if !ok {
+			return nil
+		}
+	}
+
+	var progbar progress.Writer
+	var tracker *progress.Tracker
+	if c.showProgress {
+		progbar, tracker, err = iu.NewProgress(opts(), &progress.Tracker{Total: int64(target - start)})
+		if err != nil {
+			return err
+		}
+	}
+
+	acked, last, err := c.ackBacklog(consumer, start, target, func(seq uint64) {
+		if tracker != nil {
+			tracker.SetValue(int64(seq - start))
+		} else {
+			log.Printf("Acknowledged up to stream sequence %d", seq)
+		}
+	})
+
+	if tracker != nil {
+		if last >= target {
+			tracker.SetValue(tracker.Total)
+			tracker.MarkAsDone()
+		}
+		time.Sleep(250 * time.Millisecond) // let it draw
+		progbar.Stop()
+		fmt.Println()
+	}
+	if err != nil {
+		return err
+	}
+
+	nfo, err = consumer.LatestState()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Acknowledged %s messages, Ack Floor of %s > %s is now at stream sequence %d\n", f(acked), c.stream, c.consumer, nfo.AckFloor.Stream)
+	if nfo.AckFloor.Stream < target {
+		fmt.Printf("Ack Floor did not reach stream sequence %d, %s messages are pending acknowledgement by other clients\n", target, f(nfo.NumAckPending))
+	}
+
+	return nil
+}
+
+// ackBacklog fetches messages in batches acknowledging those up to target. Batches are limited to the number of sequences
+// left between the highest acknowledged sequence, starting at floor, and target so messages beyond target are normally not
+// fetched, those delivered regardless when sequences were skipped are negatively acknowledged. With an AckAll policy only
+// the last message of each batch is acknowledged. It returns the number of messages acknowledged and the last stream
+// sequence acknowledged, cb is called after every batch
+func (c *consumerCmd) ackBacklog(consumer *jsm.Consumer, floor uint64, target uint64, cb func(seq uint64)) (int, uint64, error) {
+	sub, err := c.nc.SubscribeSync(c.nc.NewRespInbox())
+	if err != nil {
+		return 0, 0, err
+	}
+	defer sub.Unsubscribe()
+
+	ackAll := consumer.AckPolicy() == api.AckAll
+
+	var acked int
+	var lastSeq uint64
+
+	for {
+		if ctx.Err() != nil {
+			return acked, lastSeq, ctx.Err()
+		}
+
+		acknowledged := max(floor, lastSeq)
+		if acknowledged >= target {
+			return acked, lastSeq, nil
+		}
+
+		size := int(min(uint64(c.ackBatch), target-acknowledged))
+
+		req := &api.JSApiConsumerGetNextRequest{Batch: size, Expires: opts().Timeout}
+		err = validateRequest(req)
+		if err != nil {
+			return acked, lastSeq, err
+		}
+
+		err = c.mgr.NextMsgRequest(consumer.StreamName(), consumer.Name(), sub.Subject, req)
+		if err != nil {
+			return acked, lastSeq, err
+		}
+
+		var last *nats.Msg
+		var received int
+		done := false
+		exhausted := false
+
+	batch:
+		for received < size {
+			msg, err := sub.NextMsg(opts().Timeout + time.Second)
+			if err == nats.ErrTimeout {
+				exhausted = received == 0
+				break
+			}
+			if err != nil {
+				return acked, lastSeq, err
+			}
+
+			switch msg.Header.Get("Status") {
+			case "":
+			case "404", "408":
+				exhausted = received == 0
+				break batch
+			default:
+				return acked, lastSeq, fmt.Errorf("pull request failed: %s %s", msg.Header.Get("Status"), msg.Header.Get("Description"))
+			}
+
+			received++
+
+			meta, err := jsm.ParseJSMsgMetadata(msg)
+			if err != nil {
+				return acked, lastSeq, err
+			}
+
+			if meta.StreamSequence() > target {
+				err = msg.Respond(api.AckNak)
+				if err != nil {
+					return acked, lastSeq, err
+				}
+				done = true
+				continue
+			}
+
+			last = msg
+			acked++
+			lastSeq = max(lastSeq, meta.StreamSequence())
+			if meta.StreamSequence() == target {
+				done = true
+			}
+
+			if !ackAll {
+				err = msg.Respond(api.AckAck)
+				if err != nil {
+					return acked, lastSeq, err
+				}
+			}
+		}
+
+		if ackAll && last != nil {
+			err = last.Respond(api.AckAck)
+			if err != nil {
+				return acked, lastSeq, err
+			}
+		}
+
+		err = c.nc.Flush()
+		if err != nil {
+			return acked, lastSeq, err
+		}
+
+		if last != nil {
+			cb(lastSeq)
+		}
+
+		if done || exhausted {
+			return acked, lastSeq, nil
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go/api"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// consumerBookmark is a named position in a stream recorded for a consumer
+type consumerBookmark struct {
+	Stream   string    `json:"stream"`
+	Consumer string    `json:"consumer"`
+	Name     string    `json:"name"`
+	Sequence uint64    `json:"stream_seq"`
+	Time     time.Time `json:"time,omitempty"`
+	Created  time.Time `json:"created"`
+}
+
+func configureConsumerBookmarkCommand(cons *fisk.CmdClause, c *consumerCmd) {
+	bm := cons.Command("bookmark", "Records named Consumer positions and restarts Consumers from them").Alias("bm")
+	bm.HelpLong(`Bookmarks record the last Stream sequence delivered by a Consumer under a name,
+the Consumer can later be recreated to deliver messages following that position.
+
+This allows messages to be reprocessed repeatedly from well known checkpoints.
+Bookmarks are stored locally in the CLI configuration directory.`)
+
+	bmAdd := bm.Command("add", "Records the current position of a Consumer").Action(c.bookmarkAddAction)
+	bmAdd.Arg("stream", "Stream name").Required().StringVar(&c.stream)
+	bmAdd.Arg("consumer", "Consumer name").Required().StringVar(&c.consumer)
+	bmAdd.Arg("name", "Name for the bookmark").Required().StringVar(&c.bookmark)
+	bmAdd.Flag("seq", "Records this Stream sequence rather than the current position").PlaceHolder("SEQUENCE").Uint64Var(&c.bookmarkSeq)
+	bmAdd.Flag("force", "Overwrite an existing bookmark").Short('f').UnNegatableBoolVar(&c.force)
+
+	bmLs := bm.Command("ls", "List recorded bookmarks").Alias("list").Action(c.bookmarkLsAction)
+	bmLs.Arg("stream", "Stream name").StringVar(&c.stream)
+	bmLs.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+
+	bmGoto := bm.Command("goto", "Recreates a Consumer to continue after a bookmark").Action(c.bookmarkGotoAction)
+	bmGoto.Arg("stream", "Stream name").Required().StringVar(&c.stream)
+	bmGoto.Arg("consumer", "Consumer name").Required().StringVar(&c.consumer)
+	bmGoto.Arg("name", "The bookmark to go to").Required().StringVar(&c.bookmark)
+	bmGoto.Flag("by-time", "Start from the time of the bookmarked message rather than its sequence").UnNegatableBoolVar(&c.bookmarkByTime)
+	bmGoto.Flag("force", "Force recreating the Consumer without prompting").Short('f').UnNegatableBoolVar(&c.force)
+
+	bmRm := bm.Command("rm", "Removes a bookmark").Action(c.bookmarkRmAction)
+	bmRm.Arg("stream", "Stream name").Required().StringVar(&c.stream)
+	bmRm.Arg("consumer", "Consumer name").Required().StringVar(&c.consumer)
+	bmRm.Arg("name", "The bookmark to remove").Required().StringVar(&c.bookmark)
+}
+
+func (c *consumerCmd) bookmarkAddAction(_ *fisk.ParseContext) error {
+	bookmarks, err := loadConsumerBookmarks()
+	if err != nil {
+		return err
+	}
+
+	if findConsumerBookmark(bookmarks, c.stream, c.consumer, c.bookmark) != -1 && !c.force {
+		return fmt.Errorf("bookmark %s already exist for %s > %s, use --force to update", c.bookmark, c.stream, c.consumer)
+	}
+
+	c.connectAndSetup(false, false)
+
+	consumer, err := c.mgr.LoadConsumer(c.stream, c.consumer)
+	if err != nil {
+		return err
+	}
+
+	seq := c.bookmarkSeq
+	if seq == 0 {
+		state, err := consumer.State()
+		if err != nil {
+			return err
+		}
+		seq = state.Delivered.Stream
+	}
+
+	bookmark := consumerBookmark{
+		Stream:   c.stream,
+		Consumer: c.consumer,
+		Name:     c.bookmark,
+		Sequence: seq,
+		Created:  time.Now().UTC(),
+	}
+
+	if seq > 0 {
+		stream, err := c.mgr.LoadStream(c.stream)
+		if err != nil {
+			return err
+		}
+
+		msg, err := stream.ReadMessage(seq)
+		if err == nil {
+			bookmark.Time = msg.Time.UTC()
+		} else {
+			log.Printf("Could not determine the time of message %d, the bookmark can only be used by sequence: %v", seq, err)
+		}
+	}
+
+	idx := findConsumerBookmark(bookmarks, c.stream, c.consumer, c.bookmark)
+	if idx == -1 {
+		bookmarks = append(bookmarks, bookmark)
+	} else {
+		bookmarks[idx] = bookmark
+	}
+
+	err = saveConsumerBookmarks(bookmarks)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Bookmark %s records %s > %s at stream sequence %d\n", c.bookmark, c.stream, c.consumer, seq)
+
+	return nil
+}
+
+func (c *consumerCmd) bookmarkLsAction(_ *fisk.ParseContext) error {
+	bookmarks, err := loadConsumerBookmarks()
+	if err != nil {
+		return err
+	}
+
+	var matched []consumerBookmark
+	for _, b := range bookmarks {
+		if c.stream != "" && b.Stream != c.stream {
+			continue
+		}
+		if c.consumer != "" && b.Consumer != c.consumer {
+			continue
+		}
+		matched = append(matched, b)
+	}
+
+	if len(matched) == 0 {
+		fmt.Println("No bookmarks found")
+		return nil
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Stream != matched[j].Stream {
+			return matched[i].Stream < matched[j].Stream
+		}
+		if matched[i].Consumer != matched[j].Consumer {
+			return matched[i].Consumer < matched[j].Consumer
+		}
+		return matched[i].Sequence < matched[j].Sequence
+	})
+
+	table := iu.NewTableWriter(opts(), "Consumer Bookmarks")
+	table.AddHeaders("Stream", "Consumer", "Bookmark", "Stream Sequence", "Message Time", "Created")
+	for _, b := range matched {
+		msgTime := ""
+		if !b.Time.IsZero() {
+			msgTime = f(b.Time)
+		}
+		table.AddRow(b.Stream, b.Consumer, b.Name, b.Sequence, msgTime, f(b.Created))
+	}
+	fmt.Println(table.Render())
+
+	return nil
+}
+
+func (c *consumerCmd) bookmarkGotoAction(_ *fisk.ParseContext) error {
+	bookmarks, err := loadConsumerBookmarks()
+	if err != nil {
+		return err
+	}
+
+	idx := findConsumerBookmark(bookmarks, c.stream, c.consumer, c.bookmark)
+	if idx == -1 {
+		return fmt.Errorf("unknown bookmark %s for %s > %s", c.bookmark, c.stream, c.consumer)
+	}
+	bookmark := bookmarks[idx]
+
+	if c.bookmarkByTime && bookmark.Time.IsZero() {
+		return fmt.Errorf("bookmark %s does not have a message time", c.bookmark)
+	}
+
+	c.connectAndSetup(false, false)
+
+	consumer, err := c.mgr.LoadConsumer(c.stream, c.consumer)
+	if err != nil {
+		return err
+	}
+
+	if !consumer.IsDurable() {
+		return fmt.Errorf("only durable Consumers can be moved to a bookmark")
+	}
+
+	cfg := consumer.Configuration()
+	cfg.OptStartSeq = 0
+	cfg.OptStartTime = nil
+
+	if c.bookmarkByTime {
+		// the bookmark records the last delivered message so start just after it
+		start := bookmark.Time.Add(time.Nanosecond)
+		cfg.DeliverPolicy = api.DeliverByStartTime
+		cfg.OptStartTime = &start
+	} else {
+		cfg.DeliverPolicy = api.DeliverByStartSequence
+		cfg.OptStartSeq = bookmark.Sequence + 1
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really recreate Consumer %s > %s to continue after bookmark %s (sequence %d)", c.stream, c.consumer, c.bookmark, bookmark.Sequence), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	err = consumer.Delete()
+	if err != nil {
+		return fmt.Errorf("could not remove Consumer: %w", err)
+	}
+
+	created, err := c.mgr.NewConsumerFromDefault(c.stream, cfg)
+	if err != nil {
+		orig, _ := json.Marshal(consumer.Configuration())
+		return fmt.Errorf("could not recreate Consumer, the original configuration was %s: %w", orig, err)
+	}
+
+	c.selectedConsumer = created
+	c.showConsumer(os.Stdout, created)
+
+	return nil
+}
+
+func (c *consumerCmd) bookmarkRmAction(_ *fisk.ParseContext) error {
+	bookmarks, err := loadConsumerBookmarks()
+	if err != nil {
+		return err
+	}
+
+	idx := findConsumerBookmark(bookmarks, c.stream, c.consumer, c.bookmark)
+	if idx == -1 {
+		return fmt.Errorf("unknown bookmark %s for %s > %s", c.bookmark, c.stream, c.consumer)
+	}
+
+	err = saveConsumerBookmarks(append(bookmarks[:idx], bookmarks[idx+1:]...))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Bookmark %s was removed\n", c.bookmark)
+
+	return nil
+}
+
+func findConsumerBookmark(bookmarks []consumerBookmark, stream string, consumer string, name string) int {
+	for i, b := range bookmarks {
+		if b.Stream == stream && b.Consumer == consumer && b.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func consumerBookmarksFile() (string, error) {
+	parent, err := iu.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(parent, "consumer-bookmarks.json"), nil
+}
+
+func loadConsumerBookmarks() ([]consumerBookmark, error) {
+	file, err := consumerBookmarksFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if !iu.FileExists(file) {
+		return nil, nil
+	}
+
+	j, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read bookmarks: %w", err)
+	}
+
+	var bookmarks []consumerBookmark
+	err = json.Unmarshal(j, &bookmarks)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse bookmarks: %w", err)
+	}
+
+	return bookmarks, nil
+}
+
+func saveConsumerBookmarks(bookmarks []consumerBookmark) error {
+	file, err := consumerBookmarksFile()
+	if err != nil {
+		return err
+	}
+
+	j, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, j, 0600)
+}
@@ -0,0 +1,292 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const consumerCaptureStateFile = "state.json"
+
+var consumerCaptureFileRe = regexp.MustCompile(`^capture-(\d+)\.jsonl$`)
+
+// consumerCaptureState is stored in the capture directory and records which consumer is captured, the current file
+// and the highest stream sequence the server confirmed as acknowledged
+type consumerCaptureState struct {
+	Stream       string    `json:"stream"`
+	Consumer     string    `json:"consumer"`
+	File         string    `json:"file"`
+	LastAckedSeq uint64    `json:"last_acked_stream_seq"`
+	Updated      time.Time `json:"updated"`
+}
+
+// consumerCapture appends messages to rotating JSONL files, every message is synced to disk before it is acknowledged
+type consumerCapture struct {
+	dir     string
+	maxSize int64
+	state   consumerCaptureState
+
+	// ackFloor is the consumer ack floor when the capture started, messages at or below it will not be delivered again
+	ackFloor uint64
+	// captured holds the sequences above the ack floor already written to disk and not yet acknowledged, redeliveries
+	// of them are not written again
+	captured map[uint64]bool
+
+	file  *os.File
+	index int
+	size  int64
+	mu    sync.Mutex
+}
+
+func newConsumerCapture(dir string, stream string, consumer string, maxSize int64, ackFloor uint64) (*consumerCapture, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	cc := &consumerCapture{
+		dir:      dir,
+		maxSize:  maxSize,
+		state:    consumerCaptureState{Stream: stream, Consumer: consumer},
+		ackFloor: ackFloor,
+		captured: make(map[uint64]bool),
+		index:    1,
+	}
+
+	sj, err := os.ReadFile(filepath.Join(dir, consumerCaptureStateFile))
+	switch {
+	case err == nil:
+		var state consumerCaptureState
+		err = json.Unmarshal(sj, &state)
+		if err != nil {
+			return nil, fmt.Errorf("invalid capture state: %w", err)
+		}
+		if state.Stream != stream || state.Consumer != consumer {
+			return nil, fmt.Errorf("%s holds a capture of %s > %s", dir, state.Stream, state.Consumer)
+		}
+		cc.state = state
+
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	files, err := consumerCaptureFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// messages written but not acknowledged when a previous capture stopped will be redelivered, files are searched
+	// from the newest until one holds nothing above the ack floor
+	for i := len(files) - 1; i >= 0; i-- {
+		found, err := consumerCaptureSeqs(cc.fileName(files[i]), ackFloor, cc.captured)
+		if err != nil {
+			return nil, err
+		}
+		if found == 0 {
+			break
+		}
+	}
+
+	if len(files) > 0 {
+		cc.index = files[len(files)-1]
+	}
+
+	err = cc.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return cc, nil
+}
+
+// consumerCaptureFiles lists the indexes of the capture files in dir in order
+func consumerCaptureFiles(dir string) ([]int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []int
+	for _, entry := range entries {
+		parts := consumerCaptureFileRe.FindStringSubmatch(entry.Name())
+		if parts == nil {
+			continue
+		}
+
+		idx, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+		indexes = append(indexes, idx)
+	}
+
+	sort.Ints(indexes)
+
+	return indexes, nil
+}
+
+// consumerCaptureSeqs adds the stream sequences above floor in a capture file to seqs returning how many it found, a
+// partially written final line is ignored
+func consumerCaptureSeqs(file string, floor uint64, seqs map[uint64]bool) (int, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	found := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var msg jsonLinesMsg
+		if json.Unmarshal(scanner.Bytes(), &msg) != nil || msg.JS == nil {
+			continue
+		}
+
+		if msg.JS.StreamSequence > floor {
+			seqs[msg.JS.StreamSequence] = true
+			found++
+		}
+	}
+
+	return found, scanner.Err()
+}
+
+func (cc *consumerCapture) fileName(index int) string {
+	return filepath.Join(cc.dir, fmt.Sprintf("capture-%06d.jsonl", index))
+}
+
+func (cc *consumerCapture) open() error {
+	name := cc.fileName(cc.index)
+
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	cc.file = file
+	cc.size = stat.Size()
+	cc.state.File = filepath.Base(name)
+
+	return cc.saveState()
+}
+
+func (cc *consumerCapture) rotate() error {
+	err := cc.file.Close()
+	if err != nil {
+		return err
+	}
+
+	cc.index++
+
+	err = cc.open()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Capturing to %s", cc.file.Name())
+
+	return nil
+}
+
+// duplicate determines if seq was already captured by this or a previous session
+func (cc *consumerCapture) duplicate(seq uint64) bool {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	return seq <= cc.ackFloor || cc.captured[seq]
+}
+
+// write appends line holding message seq to the current capture file and syncs it to disk, rotating the file when it
+// would exceed the maximum size
+func (cc *consumerCapture) write(seq uint64, line []byte) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.maxSize > 0 && cc.size > 0 && cc.size+int64(len(line))+1 > cc.maxSize {
+		err := cc.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := cc.file.Write(append(line, '\n'))
+	cc.size += int64(n)
+	if err != nil {
+		return err
+	}
+
+	err = cc.file.Sync()
+	if err != nil {
+		return err
+	}
+
+	cc.captured[seq] = true
+
+	return nil
+}
+
+// acked records that the server confirmed the acknowledgement of seq so it will not be delivered again, the state file
+// is updated with the last acknowledged sequence
+func (cc *consumerCapture) acked(seq uint64) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	delete(cc.captured, seq)
+	cc.state.LastAckedSeq = max(cc.state.LastAckedSeq, seq)
+
+	return cc.saveState()
+}
+
+// saveState replaces the state file atomically
+func (cc *consumerCapture) saveState() error {
+	cc.state.Updated = time.Now().UTC()
+
+	sj, err := json.Marshal(cc.state)
+	if err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(cc.dir, consumerCaptureStateFile+".tmp")
+	tf, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = tf.Write(sj)
+	if err == nil {
+		err = tf.Sync()
+	}
+	tf.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, filepath.Join(cc.dir, consumerCaptureStateFile))
+}
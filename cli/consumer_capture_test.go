@@ -0,0 +1,86 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConsumerCapture(t *testing.T) {
+	dir := t.TempDir()
+
+	line := func(seq uint64) []byte {
+		j, err := json.Marshal(jsonLinesMsg{Subject: "x", JS: &jsonLinesMsgMeta{Stream: "ORDERS", StreamSequence: seq}})
+		if err != nil {
+			t.Fatalf("marshal failed: %v", err)
+		}
+		return j
+	}
+
+	cc, err := newConsumerCapture(dir, "ORDERS", "C1", int64(len(line(1))*2+2), 0)
+	if err != nil {
+		t.Fatalf("capture failed: %v", err)
+	}
+
+	// messages are delivered out of order with several outstanding
+	for _, seq := range []uint64{1, 3, 2, 5} {
+		err = cc.write(seq, line(seq))
+		if err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+	for _, seq := range []uint64{3, 1} {
+		err = cc.acked(seq)
+		if err != nil {
+			t.Fatalf("ack failed: %v", err)
+		}
+	}
+	if !cc.duplicate(2) || cc.duplicate(3) || cc.duplicate(4) {
+		t.Fatalf("unexpected duplicate detection in the session")
+	}
+	cc.file.Close()
+
+	files, err := consumerCaptureFiles(dir)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !cmp.Equal(files, []int{1, 2}) {
+		t.Fatalf("expected files 1 and 2 got %v", files)
+	}
+
+	// the consumer ack floor is 1 as 2 was not acknowledged, 4 was never delivered
+	cc, err = newConsumerCapture(dir, "ORDERS", "C1", 0, 1)
+	if err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	defer cc.file.Close()
+
+	if cc.index != 2 || !cmp.Equal(cc.captured, map[uint64]bool{2: true, 3: true, 5: true}) {
+		t.Fatalf("unexpected resume state %d %v", cc.index, cc.captured)
+	}
+	if cc.state.LastAckedSeq != 3 || cc.state.File != "capture-000002.jsonl" {
+		t.Fatalf("unexpected resumed state file %+v", cc.state)
+	}
+	if !cc.duplicate(1) || !cc.duplicate(2) || cc.duplicate(4) || !cc.duplicate(5) {
+		t.Fatalf("unexpected duplicate detection after resume")
+	}
+
+	_, err = newConsumerCapture(dir, "ORDERS", "OTHER", 0, 0)
+	if err == nil {
+		t.Fatalf("expected capture of another consumer to fail")
+	}
+}
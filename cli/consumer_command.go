@@ -17,16 +17,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
-	"math/rand"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/nats-io/natscli/columns"
@@ -50,12 +53,17 @@ type consumerCmd struct {
 	consumer       string
 	stream         string
 	json           bool
+	outTemplate    string
+	csv            bool
+	replicaWait    time.Duration
 	listNames      bool
 	force          bool
+	allowLoop      bool
 	ack            bool
 	ackSetByUser   bool
 	term           bool
 	raw            bool
+	jsonl          bool
 	destination    string
 	inputFile      string
 	outFile        string
@@ -125,10 +133,78 @@ type consumerCmd struct {
 	groupName          string
 	fPinned            bool
 	placementPreferred string
+	exportAll          bool
+	exportDirectory    string
+	startAtDelivered   bool
+	queue              bool
+	workerID           string
+	autoProgress       bool
+	ackType            string
+	ackDelay           time.Duration
+	configValues       []string
+	configValuesFile   string
+	rmAll              bool
+	rmFilter           *regexp.Regexp
+	showAgeOnly        bool
+	nextNoWait         bool
+	nextMaxBytes       string
+	preset             string
+	bookmark           string
+	bookmarkSeq        uint64
+	bookmarkByTime     bool
+	partitions         int
+	partitionTemplate  string
+	reportPartitioned  bool
+	translate          string
+	decoders           []string
+	reportWorkers      int
+	reportWatch        bool
+	reportInterval     time.Duration
+	retuneSelect       *regexp.Regexp
+	retunePatch        string
+	ackUpToSeq         uint64
+	ackAll             bool
+	ackBatch           int
+	showProgress       bool
+	display            msgDisplay
+	outputDir          string
+	outputMaxSize      string
+	capture            *consumerCapture
+	reportWhere        string
+	metaSelectors      map[string]string
+	untilSealed        bool
+	untilGrace         time.Duration
+	eos                *endOfStream
+	syncContext        string
+	syncStream         string
+	syncConsumer       string
+	subCtx             context.Context
+	subCancel          context.CancelFunc
+	subFor             time.Duration
+	subCount           uint
+	subReceived        uint
+	schemaFile         string
+	invalidOnly        bool
+	schema             *payloadSchema
+	stats              *subStats
+	gcIdle             time.Duration
+	gcDurables         *regexp.Regexp
+	peerName           string
+}
+
+type consumerExportManifest struct {
+	Stream    string                `json:"stream"`
+	Created   time.Time             `json:"created"`
+	Consumers []consumerExportEntry `json:"consumers"`
+}
+
+type consumerExportEntry struct {
+	Name string `json:"name"`
+	File string `json:"file"`
 }
 
 func configureConsumerCommand(app commandHost) {
-	c := &consumerCmd{metadata: map[string]string{}}
+	c := &consumerCmd{metadata: map[string]string{}, metaSelectors: map[string]string{}}
 
 	addCreateFlags := func(f *fisk.CmdClause, edit bool) {
 		if !edit {
@@ -147,7 +223,7 @@ func configureConsumerCommand(app commandHost) {
 		if !edit {
 			f.Flag("ephemeral", "Create an ephemeral Consumer").UnNegatableBoolVar(&c.ephemeral)
 		}
-		f.Flag("filter", "Filter Stream by subjects").PlaceHolder("SUBJECTS").StringsVar(&c.filterSubjects)
+		f.Flag("filter", "Filter Stream by subjects, can be repeated to filter by multiple subjects").PlaceHolder("SUBJECT").StringsVar(&c.filterSubjects)
 		if !edit {
 			f.Flag("flow-control", "Enable Push consumer flow control").IsSetByUser(&c.fcSet).UnNegatableBoolVar(&c.fc)
 			f.Flag("heartbeat", "Enable idle Push consumer heartbeats (-1 disable)").StringVar(&c.idleHeartbeat)
@@ -161,7 +237,9 @@ func configureConsumerCommand(app commandHost) {
 			f.Flag("max-waiting", "Maximum number of outstanding pulls allowed").PlaceHolder("PULLS").IntVar(&c.maxWaiting)
 		}
 		f.Flag("max-pull-batch", "Maximum size batch size for a pull request to accept").PlaceHolder("BATCH_SIZE").IntVar(&c.maxPullBatch)
+		f.Flag("max-batch", "Maximum size batch size for a pull request to accept").Hidden().IntVar(&c.maxPullBatch)
 		f.Flag("max-pull-expire", "Maximum expire duration for a pull request to accept").PlaceHolder("EXPIRES").DurationVar(&c.maxPullExpire)
+		f.Flag("max-expires", "Maximum expire duration for a pull request to accept").Hidden().DurationVar(&c.maxPullExpire)
 		f.Flag("max-pull-bytes", "Maximum max bytes for a pull request to accept").PlaceHolder("BYTES").IntVar(&c.maxPullBytes)
 		if !edit {
 			f.Flag("pull", "Deliver messages in 'pull' mode").UnNegatableBoolVar(&c.pull)
@@ -170,7 +248,7 @@ func configureConsumerCommand(app commandHost) {
 		f.Flag("sample", "Percentage of requests to sample for monitoring purposes").Default("-1").IntVar(&c.samplePct)
 		f.Flag("target", "Push based delivery target subject").PlaceHolder("SUBJECT").StringVar(&c.delivery)
 		f.Flag("wait", "Acknowledgment waiting time").Default("-1s").DurationVar(&c.ackWait)
-		f.Flag("inactive-threshold", "How long to allow an ephemeral consumer to be idle before removing it").PlaceHolder("THRESHOLD").DurationVar(&c.inactiveThreshold)
+		f.Flag("inactive-threshold", "How long to allow a consumer to be idle before the server removes it, durable consumers require NATS Server 2.10").PlaceHolder("THRESHOLD").DurationVar(&c.inactiveThreshold)
 		if !edit {
 			f.Flag("memory", "Force the consumer state to be stored in memory rather than inherit from the stream").UnNegatableBoolVar(&c.memory)
 		}
@@ -189,31 +267,42 @@ func configureConsumerCommand(app commandHost) {
 	cons.Flag("all", "Operate on all streams including system ones").Short('a').UnNegatableBoolVar(&c.showAll)
 
 	consAdd := cons.Command("add", "Creates a new Consumer").Alias("create").Alias("new").Action(c.createAction)
-	consAdd.Arg("stream", "Stream name").StringVar(&c.stream)
+	consAdd.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	consAdd.Arg("consumer", "Consumer name").StringVar(&c.consumer)
-	consAdd.Flag("config", "JSON file to read configuration from").ExistingFileVar(&c.inputFile)
-	consAdd.Flag("validate", "Only validates the configuration against the official Schema").UnNegatableBoolVar(&c.validateOnly)
+	consAdd.Flag("config", "JSON or YAML file to read configuration from, - for STDIN").PlaceHolder("FILE").StringVar(&c.inputFile)
+	consAdd.Flag("json-config", "JSON or YAML file to read configuration from, - for STDIN").Hidden().StringVar(&c.inputFile)
+	consAdd.Flag("set", "Sets a value used when rendering the configuration file as a template").PlaceHolder("KEY=VALUE").StringsVar(&c.configValues)
+	consAdd.Flag("values", "JSON or YAML file holding values used when rendering the configuration file as a template").PlaceHolder("FILE").ExistingFileVar(&c.configValuesFile)
+	consAdd.Flag("validate", "Only validates the configuration against the official Schema and compares it to the live asset when it exists").UnNegatableBoolVar(&c.validateOnly)
+	consAdd.Flag("allow-loop", "Allow push delivery subjects that overlap with the Stream subjects").UnNegatableBoolVar(&c.allowLoop)
 	consAdd.Flag("output", "Save configuration instead of creating").PlaceHolder("FILE").StringVar(&c.outFile)
 	addCreateFlags(consAdd, false)
 	consAdd.Flag("defaults", "Accept default values for all prompts").UnNegatableBoolVar(&c.acceptDefaults)
+	consAdd.Flag("preset", fmt.Sprintf("Pre-populates the configuration for a common use case (%s)", strings.Join(consumerPresets, ", "))).PlaceHolder("PRESET").EnumVar(&c.preset, consumerPresets...)
 
 	edit := cons.Command("edit", "Edits the configuration of a consumer").Alias("update").Action(c.editAction)
-	edit.Arg("stream", "Stream name").StringVar(&c.stream)
-	edit.Arg("consumer", "Consumer name").StringVar(&c.consumer)
-	edit.Flag("config", "JSON file to read configuration from").ExistingFileVar(&c.inputFile)
+	edit.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	edit.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
+	edit.Flag("config", "JSON or YAML file to read configuration from, - for STDIN").PlaceHolder("FILE").StringVar(&c.inputFile)
+	edit.Flag("set", "Sets a value used when rendering the configuration file as a template").PlaceHolder("KEY=VALUE").StringsVar(&c.configValues)
+	edit.Flag("values", "JSON or YAML file holding values used when rendering the configuration file as a template").PlaceHolder("FILE").ExistingFileVar(&c.configValuesFile)
 	edit.Flag("force", "Force removal without prompting").Short('f').UnNegatableBoolVar(&c.force)
+	edit.Flag("allow-loop", "Allow push delivery subjects that overlap with the Stream subjects").UnNegatableBoolVar(&c.allowLoop)
 	edit.Flag("interactive", "Edit the configuring using your editor").Short('i').BoolVar(&c.interactive)
 	edit.Flag("dry-run", "Only shows differences, do not edit the stream").UnNegatableBoolVar(&c.dryRun)
+	edit.Flag("replica-wait", "How long to wait for new replicas to become current after changing replicas, 0 to not wait").Default("10m").PlaceHolder("DURATION").DurationVar(&c.replicaWait)
 	addCreateFlags(edit, true)
 
 	consLs := cons.Command("ls", "List known Consumers").Alias("list").Action(c.lsAction)
-	consLs.Arg("stream", "Stream name").StringVar(&c.stream)
+	consLs.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	consLs.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+	addOutputTemplateFlag(consLs, &c.outTemplate)
 	consLs.Flag("names", "Show just the consumer names").Short('n').UnNegatableBoolVar(&c.listNames)
+	consLs.Flag("select", "Limit the list to consumers with matching metadata").PlaceHolder("KEY=VALUE").StringMapVar(&c.metaSelectors)
 	consLs.Flag("no-select", "Do not select consumers from a list").Default("false").UnNegatableBoolVar(&c.force)
 
 	consFind := cons.Command("find", "Finds consumers matching certain criteria").Alias("query").Action(c.findAction)
-	consFind.Arg("stream", "Stream name").StringVar(&c.stream)
+	consFind.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	consFind.Flag("pull", "Display only pull based consumers").UnNegatableBoolVar(&c.fPull)
 	consFind.Flag("push", "Display only push based consumers").UnNegatableBoolVar(&c.fPush)
 	consFind.Flag("bound", "Display push-bound or pull consumers with waiting pulls").UnNegatableBoolVar(&c.fBound)
@@ -229,80 +318,146 @@ func configureConsumerCommand(app commandHost) {
 	consFind.Flag("expression", "Match consumers using an expression language").StringVar(&c.fExpression)
 
 	consInfo := cons.Command("info", "Consumer information").Alias("nfo").Action(c.infoAction)
-	consInfo.Arg("stream", "Stream name").StringVar(&c.stream)
-	consInfo.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+	consInfo.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	consInfo.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
 	consInfo.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+	addOutputTemplateFlag(consInfo, &c.outTemplate)
 	consInfo.Flag("no-select", "Do not select consumers from a list").Default("false").UnNegatableBoolVar(&c.force)
 
 	consState := cons.Command("state", "Stream state").Action(c.stateAction)
-	consState.Arg("stream", "Stream to retrieve state information for").StringVar(&c.stream)
-	consState.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+	consState.Arg("stream", "Stream to retrieve state information for").HintAction(completeStreamNames).StringVar(&c.stream)
+	consState.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
 	consState.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
 	consState.Flag("no-select", "Do not select streams from a list").Default("false").UnNegatableBoolVar(&c.force)
 
 	consRm := cons.Command("rm", "Removes a Consumer").Alias("delete").Alias("del").Action(c.rmAction)
-	consRm.Arg("stream", "Stream name").StringVar(&c.stream)
-	consRm.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+	consRm.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	consRm.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
 	consRm.Flag("force", "Force removal without prompting").Short('f').UnNegatableBoolVar(&c.force)
+	consRm.Flag("all", "Removes all Consumers on the Stream").UnNegatableBoolVar(&c.rmAll)
+	consRm.Flag("filter", "Removes all Consumers with names matching a regular expression").PlaceHolder("REGEX").RegexpVar(&c.rmFilter)
+
+	consDiff := cons.Command("diff", "Compares the configuration of a Consumer with a configuration file").Action(c.diffAction)
+	consDiff.HelpLong(`Compares the live configuration with a JSON or YAML file and shows the differences.
+
+Exits with code 1 when the configuration differs from the file, files can
+be produced using 'nats consumer info --json' or 'nats consumer add --output'.`)
+	consDiff.Arg("stream", "Stream name").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	consDiff.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).Required().StringVar(&c.consumer)
+	consDiff.Arg("file", "JSON or YAML file holding the desired configuration").Required().ExistingFileVar(&c.inputFile)
+	consDiff.Flag("set", "Sets a value used when rendering the configuration file as a template").PlaceHolder("KEY=VALUE").StringsVar(&c.configValues)
+	consDiff.Flag("values", "JSON or YAML file holding values used when rendering the configuration file as a template").PlaceHolder("FILE").ExistingFileVar(&c.configValuesFile)
 
 	consCp := cons.Command("copy", "Creates a new Consumer based on the configuration of another").Alias("cp").Action(c.cpAction)
-	consCp.Arg("stream", "Stream name").Required().StringVar(&c.stream)
+	consCp.Arg("stream", "Stream name").HintAction(completeStreamNames).Required().StringVar(&c.stream)
 	consCp.Arg("source", "Source Consumer name").Required().StringVar(&c.consumer)
 	consCp.Arg("destination", "Destination Consumer name").Required().StringVar(&c.destination)
+	consCp.Flag("start-at-delivered", "Start the new Consumer after the last message delivered by the source Consumer").UnNegatableBoolVar(&c.startAtDelivered)
 	addCreateFlags(consCp, false)
 
+	consClone := cons.Command("clone-to-file", "Saves Consumer configurations to JSON files").Action(c.cloneToFileAction)
+	consClone.HelpLong(`Writes the configuration of durable Consumers to files named after the durable
+in a consumers directory along with an index.json manifest listing all the exported
+Consumers.
+
+The files can be used with 'nats consumer add STREAM --config FILE'.`)
+	consClone.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	consClone.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
+	consClone.Flag("all", "Export all durable Consumers on the Stream").UnNegatableBoolVar(&c.exportAll)
+	consClone.Flag("directory", "Directory to write the configuration files to").Default(".").StringVar(&c.exportDirectory)
+
+	configureConsumerBookmarkCommand(cons, c)
+	configurePartitionedConsumerCommand(cons, c, addCreateFlags)
+	configureConsumerRetuneCommand(cons, c, addCreateFlags)
+	configureConsumerAckCommand(cons, c)
+	configureConsumerSyncCommand(cons, c)
+	configureConsumerGCCommand(cons, c)
+
 	consNext := cons.Command("next", "Retrieves messages from Pull Consumers without interactive prompts").Action(c.nextAction)
-	consNext.Arg("stream", "Stream name").Required().StringVar(&c.stream)
-	consNext.Arg("consumer", "Consumer name").Required().StringVar(&c.consumer)
+	consNext.Arg("stream", "Stream name").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	consNext.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).Required().StringVar(&c.consumer)
 	consNext.Flag("ack", "Acknowledge received message").Default("true").IsSetByUser(&c.ackSetByUser).BoolVar(&c.ack)
 	consNext.Flag("nak", "Perform a Negative Acknowledgement on the message").UnNegatableBoolVar(&c.nak)
 	consNext.Flag("term", "Terms the message").Default("false").UnNegatableBoolVar(&c.term)
 	consNext.Flag("raw", "Show only the message").Short('r').UnNegatableBoolVar(&c.raw)
-	consNext.Flag("wait", "Wait up to this period to acknowledge messages").DurationVar(&c.ackWait)
+	consNext.Flag("jsonl", "Show each message as a single line of JSON including headers and metadata").UnNegatableBoolVar(&c.jsonl)
+	consNext.Flag("translate", "Translate the message data by running it through the given command before output").StringVar(&c.translate)
+	consNext.Flag("decode", fmt.Sprintf("Decodes the message data before output, can be repeated to decode in order (%s)", strings.Join(payloadDecoders, ", "))).PlaceHolder("DECODER").EnumsVar(&c.decoders, payloadDecoders...)
+	addMsgDisplayFlags(consNext, &c.display)
+	consNext.Flag("ack-type", "How to acknowledge received messages (ack, nak, term, in-progress)").PlaceHolder("TYPE").EnumVar(&c.ackType, nextAckTypes...)
+	consNext.Flag("ack-delay", "Wait this period before acknowledging messages").PlaceHolder("DURATION").DurationVar(&c.ackDelay)
+	consNext.Flag("wait", "Wait this period before acknowledging messages").Hidden().DurationVar(&c.ackDelay)
+	consNext.Flag("auto-progress", "Send progress acknowledgements while waiting to acknowledge messages").UnNegatableBoolVar(&c.autoProgress)
 	consNext.Flag("count", "Number of messages to try to fetch from the pull consumer").Default("1").IntVar(&c.pullCount)
+	consNext.Flag("show-age-only", "Only show the sequences and age of the next message without pulling it").UnNegatableBoolVar(&c.showAgeOnly)
+	consNext.Flag("no-wait", "Return immediately when no messages are available").UnNegatableBoolVar(&c.nextNoWait)
+	consNext.Flag("max-bytes", "Limits the size of messages fetched by each request").PlaceHolder("BYTES").StringVar(&c.nextMaxBytes)
+	addPayloadSchemaFlags(consNext, &c.schemaFile, &c.invalidOnly)
 
 	consSub := cons.Command("sub", "Retrieves messages from Consumers").Action(c.subAction)
-	consSub.Arg("stream", "Stream name").StringVar(&c.stream)
-	consSub.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+	consSub.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	consSub.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
 	consSub.Flag("ack", "Acknowledge received message").Default("true").BoolVar(&c.ack)
 	consSub.Flag("raw", "Show only the message").Short('r').UnNegatableBoolVar(&c.raw)
+	consSub.Flag("jsonl", "Show each message as a single line of JSON including headers and metadata").UnNegatableBoolVar(&c.jsonl)
+	consSub.Flag("translate", "Translate the message data by running it through the given command before output").StringVar(&c.translate)
+	consSub.Flag("decode", fmt.Sprintf("Decodes the message data before output, can be repeated to decode in order (%s)", strings.Join(payloadDecoders, ", "))).PlaceHolder("DECODER").EnumsVar(&c.decoders, payloadDecoders...)
+	addMsgDisplayFlags(consSub, &c.display)
 	consSub.Flag("deliver-group", "Deliver group of the consumer").StringVar(&c.deliveryGroup)
+	consSub.Flag("queue", "Cooperatively share the Consumer with other instances, continuously pulling from Pull Consumers").UnNegatableBoolVar(&c.queue)
+	consSub.Flag("worker-id", "Label identifying this instance in output when sharing a Consumer").PlaceHolder("ID").StringVar(&c.workerID)
+	consSub.Flag("output-dir", "Appends messages to rotating JSONL files in a directory, messages already captured are not written again when restarted").PlaceHolder("DIR").StringVar(&c.outputDir)
+	consSub.Flag("output-max-size", "Size at which files written using --output-dir are rotated").Default("64MB").StringVar(&c.outputMaxSize)
+	addUntilSealedFlags(consSub, &c.untilSealed, &c.untilGrace)
+	consSub.Flag("for", "Stop after receiving messages for this long").PlaceHolder("DURATION").DurationVar(&c.subFor)
+	consSub.Flag("count", "Stop after receiving this many messages").PlaceHolder("COUNT").UintVar(&c.subCount)
+	addPayloadSchemaFlags(consSub, &c.schemaFile, &c.invalidOnly)
 
 	graph := cons.Command("graph", "View a graph of Consumer activity").Action(c.graphAction)
-	graph.Arg("stream", "Stream name").StringVar(&c.stream)
-	graph.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+	graph.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	graph.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
 
 	conPause := cons.Command("pause", "Pause a consumer until a later time").Action(c.pauseAction)
-	conPause.Arg("stream", "Stream name").StringVar(&c.stream)
-	conPause.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+	conPause.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	conPause.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
 	conPause.Arg("until", fmt.Sprintf("Pause until a specific time (eg %s)", time.Now().UTC().Format(time.DateTime))).PlaceHolder("TIME").StringVar(&c.pauseUntil)
 	conPause.Flag("force", "Force pause without prompting").Short('f').UnNegatableBoolVar(&c.force)
 
 	conUnpin := cons.Command("unpin", "Unpin the current Pinned Client from a Priority Group").Action(c.unpinAction)
-	conUnpin.Arg("stream", "Stream name").StringVar(&c.stream)
-	conUnpin.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+	conUnpin.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	conUnpin.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
 	conUnpin.Arg("group", "The group to unpin").StringVar(&c.groupName)
 	conUnpin.Flag("force", "Force unpin without prompting").Short('f').UnNegatableBoolVar(&c.force)
 
 	conResume := cons.Command("resume", "Resume a paused consumer").Action(c.resumeAction)
-	conResume.Arg("stream", "Stream name").StringVar(&c.stream)
-	conResume.Arg("consumer", "Consumer name").StringVar(&c.consumer)
+	conResume.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	conResume.Arg("consumer", "Consumer name").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
 	conResume.Flag("force", "Force resume without prompting").Short('f').UnNegatableBoolVar(&c.force)
 
 	conReport := cons.Command("report", "Reports on Consumer statistics").Action(c.reportAction)
-	conReport.Arg("stream", "Stream name").StringVar(&c.stream)
+	conReport.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	conReport.Flag("raw", "Show un-formatted numbers").Short('r').UnNegatableBoolVar(&c.raw)
 	conReport.Flag("leaders", "Show details about the leaders").Short('l').UnNegatableBoolVar(&c.reportLeaderDistrib)
+	conReport.Flag("select", "Limit the report to consumers with matching metadata").PlaceHolder("KEY=VALUE").StringMapVar(&c.metaSelectors)
+	conReport.Flag("partitioned", "Report on partitioned Consumer sets and find missing partitions").UnNegatableBoolVar(&c.reportPartitioned)
+	conReport.Flag("workers", "Number of Consumer states to request concurrently, each bound by --timeout").Default("10").IntVar(&c.reportWorkers)
+	addOutputTemplateFlag(conReport, &c.outTemplate)
+	conReport.Flag("csv", "Produce CSV output").UnNegatableBoolVar(&c.csv)
+	addReportWhereFlag(conReport, &c.reportWhere, consumerReportWhereFields)
+	conReport.Flag("watch", "Repeatedly show the report with the ack floor rate and changes in redelivered and pending messages").UnNegatableBoolVar(&c.reportWatch)
+	conReport.Flag("interval", "How often to refresh the report when watching").Default("10s").PlaceHolder("DURATION").DurationVar(&c.reportInterval)
 
 	conCluster := cons.Command("cluster", "Manages a clustered Consumer").Alias("c")
 	conClusterDown := conCluster.Command("step-down", "Force a new leader election by standing down the current leader").Alias("elect").Alias("down").Alias("d").Action(c.leaderStandDownAction)
-	conClusterDown.Arg("stream", "Stream to act on").StringVar(&c.stream)
-	conClusterDown.Arg("consumer", "Consumer to act on").StringVar(&c.consumer)
+	conClusterDown.Arg("stream", "Stream to act on").HintAction(completeStreamNames).StringVar(&c.stream)
+	conClusterDown.Arg("consumer", "Consumer to act on").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
 	conClusterDown.Flag("preferred", "Prefer placing the leader on a specific host").StringVar(&c.placementPreferred)
 	conClusterDown.Flag("force", "Force leader step down ignoring current leader").Short('f').UnNegatableBoolVar(&c.force)
 
+	configureConsumerPeerRemoveCommand(conCluster, c)
+
 	conClusterBalance := conCluster.Command("balance", "Balance consumer leaders").Action(c.balanceAction)
-	conClusterBalance.Arg("stream", "Stream to act on").StringVar(&c.stream)
+	conClusterBalance.Arg("stream", "Stream to act on").HintAction(completeStreamNames).StringVar(&c.stream)
 	conClusterBalance.Flag("pull", "Balance only pull based consumers").UnNegatableBoolVar(&c.fPull)
 	conClusterBalance.Flag("push", "Balance only push based consumers").UnNegatableBoolVar(&c.fPush)
 	conClusterBalance.Flag("bound", "Balance push-bound or pull consumers with waiting pulls").UnNegatableBoolVar(&c.fBound)
@@ -742,7 +897,7 @@ func (c *consumerCmd) leaderStandDownAction(_ *fisk.ParseContext) error {
 	}
 
 	fmt.Println()
-	c.showConsumer(consumer)
+	c.showConsumer(os.Stdout, consumer)
 	return nil
 }
 
@@ -793,7 +948,7 @@ func (c *consumerCmd) copyAndEditConsumer(cfg api.ConsumerConfig) (*api.Consumer
 	var err error
 
 	if c.inputFile != "" {
-		return c.loadConfigFile(c.inputFile)
+		return c.loadConfigFile(c.inputFile, true)
 	}
 
 	if c.description != "" {
@@ -905,6 +1060,11 @@ func (c *consumerCmd) editAction(pc *fisk.ParseContext) error {
 		fisk.FatalIfError(err, "could not create new configuration for Consumer %s", c.selectedConsumer.Name())
 	}
 
+	err = checkPullSettings(ncfg)
+	if err != nil {
+		return err
+	}
+
 	if len(ncfg.BackOff) > 0 && ncfg.AckWait != t.AckWait {
 		return fmt.Errorf("consumers with backoff policies do not support editing Ack Wait")
 	}
@@ -930,7 +1090,12 @@ func (c *consumerCmd) editAction(pc *fisk.ParseContext) error {
 
 	fmt.Printf("Differences (-old +new):\n%s", diff)
 	if c.dryRun {
-		os.Exit(1)
+		return ErrDifferencesFound
+	}
+
+	err = c.checkDeliverLoop(ncfg)
+	if err != nil {
+		return err
 	}
 
 	if !c.force {
@@ -947,12 +1112,183 @@ func (c *consumerCmd) editAction(pc *fisk.ParseContext) error {
 		return err
 	}
 
+	err = validateRequest(*ncfg)
+	if err != nil {
+		return err
+	}
+
+	live, err := c.selectedConsumer.LatestState()
+	if err != nil {
+		return err
+	}
+
 	cons, err := c.mgr.NewConsumerFromDefault(c.stream, *ncfg)
 	if err != nil {
 		return err
 	}
 
-	c.showConsumer(cons)
+	if live.Cluster != nil && c.replicaWait > 0 && ncfg.Replicas != t.Replicas {
+		err = c.waitForConsumerReplicas(cons)
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	c.showConsumer(os.Stdout, cons)
+
+	return nil
+}
+
+func (c *consumerCmd) renderConsumersCSV(w io.Writer, infos []*api.ConsumerInfo) error {
+	var rows [][]any
+
+	for _, cs := range infos {
+		mode := "Push"
+		if cs.Config.DeliverSubject == "" {
+			mode = "Pull"
+		}
+
+		filter := cs.Config.FilterSubject
+		if len(cs.Config.FilterSubjects) > 0 {
+			filter = strings.Join(cs.Config.FilterSubjects, " ")
+		}
+
+		var leader string
+		replicas := 1
+		if cs.Cluster != nil {
+			leader = cs.Cluster.Leader
+			replicas = len(cs.Cluster.Replicas) + 1
+		}
+
+		rows = append(rows, []any{cs.Stream, cs.Name, mode, filter, cs.Config.AckPolicy.String(), cs.Config.AckWait.Seconds(), cs.NumAckPending, cs.NumRedelivered, cs.NumPending, cs.NumWaiting, cs.AckFloor.Stream, cs.Delivered.Stream, replicas, leader})
+	}
+
+	return writeCSV(w, []string{"Stream", "Consumer", "Mode", "Filter", "Ack Policy", "Ack Wait Seconds", "Ack Pending", "Redelivered", "Unprocessed", "Waiting Pulls", "Ack Floor", "Delivered", "Replicas", "Leader"}, rows)
+}
+
+// waitForConsumerReplicas waits for the consumer to have its configured replicas current, consumers without replicas set follow the stream
+func (c *consumerCmd) waitForConsumerReplicas(cons *jsm.Consumer) error {
+	replicas := cons.Replicas()
+	if replicas == 0 {
+		stream, err := c.mgr.LoadStream(c.stream)
+		if err != nil {
+			return err
+		}
+		replicas = stream.Replicas()
+	}
+
+	return waitForReplicas(fmt.Sprintf("Consumer %s > %s", c.stream, cons.Name()), replicas, "", c.replicaWait, func() (*api.ClusterInfo, error) {
+		state, err := cons.LatestState()
+		if err != nil {
+			return nil, err
+		}
+		return state.Cluster, nil
+	})
+}
+
+func (c *consumerCmd) diffAction(_ *fisk.ParseContext) error {
+	c.force = true
+	c.connectAndSetup(true, true)
+
+	desired, err := c.loadConfigFile(c.inputFile, false)
+	if err != nil {
+		return fmt.Errorf("could not load configuration file %s: %w", c.inputFile, err)
+	}
+
+	live := c.selectedConsumer.Configuration()
+	live.Metadata = iu.RemoveReservedMetadata(live.Metadata)
+	desired.Metadata = iu.RemoveReservedMetadata(desired.Metadata)
+
+	diff := configDiff(live, desired)
+	if diff == "" {
+		fmt.Printf("Consumer %s > %s matches %s\n", c.stream, c.consumer, c.inputFile)
+		return nil
+	}
+
+	fmt.Printf("Differences (-live +%s):\n%s", c.inputFile, colorDiff(diff))
+
+	return ErrDifferencesFound
+}
+
+func (c *consumerCmd) cloneToFileAction(_ *fisk.ParseContext) error {
+	c.connectAndSetup(true, !c.exportAll)
+
+	var names []string
+	if c.exportAll {
+		stream, err := c.mgr.LoadStream(c.stream)
+		if err != nil {
+			return err
+		}
+
+		names, err = stream.ConsumerNames()
+		if err != nil {
+			return err
+		}
+	} else {
+		names = []string{c.consumer}
+	}
+
+	// consumers are kept in their own directory so a consumer named index cannot replace the manifest
+	err := os.MkdirAll(filepath.Join(c.exportDirectory, "consumers"), 0700)
+	if err != nil {
+		return err
+	}
+
+	manifest := consumerExportManifest{
+		Stream:    c.stream,
+		Created:   time.Now().UTC(),
+		Consumers: []consumerExportEntry{},
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		consumer, err := c.mgr.LoadConsumer(c.stream, name)
+		if err != nil {
+			return fmt.Errorf("could not load Consumer %s > %s: %w", c.stream, name, err)
+		}
+
+		if !consumer.IsDurable() {
+			if opts().Trace {
+				log.Printf("Skipping ephemeral Consumer %s", name)
+			}
+			continue
+		}
+
+		cfg := consumer.Configuration()
+		cfg.Metadata = iu.RemoveReservedMetadata(cfg.Metadata)
+
+		j, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		file := "consumers/" + cfg.Durable + ".json"
+		err = os.WriteFile(filepath.Join(c.exportDirectory, file), j, 0600)
+		if err != nil {
+			return err
+		}
+
+		manifest.Consumers = append(manifest.Consumers, consumerExportEntry{Name: cfg.Durable, File: file})
+		fmt.Printf("Saved Consumer %s > %s to %s\n", c.stream, cfg.Durable, filepath.Join(c.exportDirectory, file))
+	}
+
+	if len(manifest.Consumers) == 0 {
+		return fmt.Errorf("no durable Consumers found on Stream %s", c.stream)
+	}
+
+	j, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(c.exportDirectory, "index.json"), j, 0600)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nSaved %d Consumer configurations to %s\n", len(manifest.Consumers), c.exportDirectory)
 
 	return nil
 }
@@ -977,6 +1313,10 @@ func (c *consumerCmd) backoffPolicy() ([]time.Duration, error) {
 func (c *consumerCmd) rmAction(_ *fisk.ParseContext) error {
 	var err error
 
+	if c.rmAll || c.rmFilter != nil {
+		return c.rmBulk()
+	}
+
 	if c.force {
 		if c.stream == "" || c.consumer == "" {
 			return fmt.Errorf("--force requires a stream and consumer name")
@@ -1012,40 +1352,148 @@ func (c *consumerCmd) rmAction(_ *fisk.ParseContext) error {
 	return c.selectedConsumer.Delete()
 }
 
+// rmBulk removes all consumers on a stream, optionally limited to those matching a filter
+func (c *consumerCmd) rmBulk() error {
+	if c.consumer != "" {
+		return fmt.Errorf("a consumer name cannot be given when removing multiple Consumers")
+	}
+	if c.force && c.stream == "" {
+		return fmt.Errorf("--force requires a stream name")
+	}
+
+	c.connectAndSetup(true, false)
+
+	stream, err := c.mgr.LoadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	names, err := stream.ConsumerNames()
+	if err != nil {
+		return err
+	}
+
+	var matched []string
+	for _, name := range names {
+		if c.rmFilter == nil || c.rmFilter.MatchString(name) {
+			matched = append(matched, name)
+		}
+	}
+
+	if len(matched) == 0 {
+		fmt.Printf("No Consumers on Stream %s matched\n", c.stream)
+		return nil
+	}
+
+	sort.Strings(matched)
+
+	fmt.Printf("Removing %s Consumers from Stream %s:\n\n", f(len(matched)), c.stream)
+	for _, name := range matched {
+		fmt.Printf("  %s\n", name)
+	}
+	fmt.Println()
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really delete %s Consumers from Stream %s", f(len(matched)), c.stream), false)
+		fisk.FatalIfError(err, "could not obtain confirmation")
+
+		if !ok {
+			return nil
+		}
+	}
+
+	var failed int
+	for _, name := range matched {
+		err = c.mgr.DeleteConsumer(c.stream, name)
+		if err != nil {
+			log.Printf("Could not delete Consumer %s > %s: %v", c.stream, name, err)
+			failed++
+		}
+	}
+
+	fmt.Printf("Removed %s Consumers from Stream %s\n", f(len(matched)-failed), c.stream)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to remove %s Consumers", f(failed))
+	}
+
+	return nil
+}
+
 func (c *consumerCmd) lsAction(pc *fisk.ParseContext) error {
 	c.connectAndSetup(true, false)
 
+	out := newPager()
+	defer out.Close()
+
 	stream, err := c.mgr.LoadStream(c.stream)
 	fisk.FatalIfError(err, "could not load Consumers")
 
 	consumerNames, err := stream.ConsumerNames()
 	fisk.FatalIfError(err, "could not load Consumers")
 
+	if len(c.metaSelectors) > 0 {
+		consumers, _, err := c.loadConsumersConcurrently(stream)
+		if err != nil {
+			return err
+		}
+
+		consumerNames = nil
+		for _, cons := range consumers {
+			if metadataMatches(cons.Metadata(), c.metaSelectors) {
+				consumerNames = append(consumerNames, cons.Name())
+			}
+		}
+		sort.Strings(consumerNames)
+	}
+
+	if c.outTemplate != "" {
+		consumers, _, err := c.loadConsumersConcurrently(stream)
+		if err != nil {
+			return err
+		}
+
+		var infos []*api.ConsumerInfo
+		for _, cons := range consumers {
+			if !metadataMatches(cons.Metadata(), c.metaSelectors) {
+				continue
+			}
+
+			info, err := cons.LatestState()
+			if err != nil {
+				return err
+			}
+			infos = append(infos, &info)
+		}
+
+		return renderOutputTemplateTo(out, c.outTemplate, infos)
+	}
+
 	if c.json {
-		err = iu.PrintJSON(consumerNames)
+		err = iu.FprintJSON(out, consumerNames)
 		fisk.FatalIfError(err, "could not display Consumers")
 		return nil
 	}
 
 	if c.listNames {
 		for _, sc := range consumerNames {
-			fmt.Println(sc)
+			fmt.Fprintln(out, sc)
 		}
 
 		return nil
 	}
 
 	if len(consumerNames) == 0 {
-		fmt.Println("No Consumers defined")
+		fmt.Fprintln(out, "No Consumers defined")
 		return nil
 	}
 
-	out, err := c.renderConsumerAsTable(stream)
+	table, err := c.renderConsumerAsTable(stream)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(out)
+	fmt.Fprintln(out, table)
 
 	return nil
 }
@@ -1056,6 +1504,10 @@ func (c *consumerCmd) renderConsumerAsTable(stream *jsm.Stream) (string, error)
 	table.AddHeaders("Name", "Description", "Created", "Ack Pending", "Unprocessed", "Last Delivery")
 
 	missing, err := stream.EachConsumer(func(cons *jsm.Consumer) {
+		if !metadataMatches(cons.Metadata(), c.metaSelectors) {
+			return
+		}
+
 		cs, err := cons.LatestState()
 		if err != nil {
 			log.Printf("Could not obtain consumer state for %s: %s", cons.Name(), err)
@@ -1083,12 +1535,12 @@ func (c *consumerCmd) renderConsumerAsTable(stream *jsm.Stream) (string, error)
 	return out.String(), nil
 }
 
-func (c *consumerCmd) showConsumer(consumer *jsm.Consumer) {
+func (c *consumerCmd) showConsumer(w io.Writer, consumer *jsm.Consumer) {
 	config := consumer.Configuration()
 	state, err := consumer.LatestState()
 	fisk.FatalIfError(err, "could not load Consumer %s > %s", c.stream, c.consumer)
 
-	c.showInfo(config, state)
+	c.showInfo(w, config, state)
 }
 
 func (c *consumerCmd) renderBackoff(bo []time.Duration) string {
@@ -1117,9 +1569,15 @@ func (c *consumerCmd) renderBackoff(bo []time.Duration) string {
 	}
 }
 
-func (c *consumerCmd) showInfo(config api.ConsumerConfig, state api.ConsumerInfo) {
+func (c *consumerCmd) showInfo(w io.Writer, config api.ConsumerConfig, state api.ConsumerInfo) {
+	if c.outTemplate != "" {
+		err := renderOutputTemplateTo(w, c.outTemplate, state)
+		fisk.FatalIfError(err, "could not display info")
+		return
+	}
+
 	if c.json {
-		iu.PrintJSON(state)
+		iu.FprintJSON(w, state)
 		return
 	}
 
@@ -1146,7 +1604,7 @@ func (c *consumerCmd) showInfo(config api.ConsumerConfig, state api.ConsumerInfo
 		if config.FilterSubject != "" {
 			cols.AddRow("Filter Subject", config.FilterSubject)
 		} else if len(config.FilterSubjects) > 0 {
-			cols.AddRow("Filter Subjects", config.FilterSubjects)
+			cols.AddStringsAsValue("Filter Subjects", config.FilterSubjects)
 		}
 
 		switch config.DeliverPolicy {
@@ -1176,7 +1634,7 @@ func (c *consumerCmd) showInfo(config api.ConsumerConfig, state api.ConsumerInfo
 		cols.AddRowIf("Idle Heartbeat", config.Heartbeat, config.Heartbeat > 0)
 		cols.AddRowIf("Flow Control", config.FlowControl, config.DeliverSubject != "")
 		cols.AddRowIf("Headers Only", true, config.HeadersOnly)
-		cols.AddRowIf("Inactive Threshold", config.InactiveThreshold, config.InactiveThreshold > 0 && config.DeliverSubject == "")
+		cols.AddRowIf("Inactive Threshold", config.InactiveThreshold, config.InactiveThreshold > 0)
 		cols.AddRowIf("Max Pull Expire", config.MaxRequestExpires, config.MaxRequestExpires > 0)
 		cols.AddRowIf("Max Pull Batch", config.MaxRequestBatch, config.MaxRequestBatch > 0)
 		cols.AddRowIf("Max Pull MaxBytes", config.MaxRequestMaxBytes, config.MaxRequestMaxBytes > 0)
@@ -1278,7 +1736,7 @@ func (c *consumerCmd) showInfo(config api.ConsumerConfig, state api.ConsumerInfo
 		cols.AddMapStringsAsValue("Priority Groups", groups)
 	}
 
-	cols.Frender(os.Stdout)
+	cols.Frender(w)
 }
 
 func (c *consumerCmd) stateAction(pc *fisk.ParseContext) error {
@@ -1289,6 +1747,9 @@ func (c *consumerCmd) stateAction(pc *fisk.ParseContext) error {
 func (c *consumerCmd) infoAction(_ *fisk.ParseContext) error {
 	c.connectAndSetup(true, true)
 
+	out := newPager()
+	defer out.Close()
+
 	var err error
 	consumer := c.selectedConsumer
 
@@ -1297,7 +1758,7 @@ func (c *consumerCmd) infoAction(_ *fisk.ParseContext) error {
 		fisk.FatalIfError(err, "could not load Consumer %s > %s", c.stream, c.consumer)
 	}
 
-	c.showConsumer(consumer)
+	c.showConsumer(out, consumer)
 
 	return nil
 }
@@ -1341,6 +1802,73 @@ func (c *consumerCmd) sampleFreqFromInt(s int) string {
 	return ""
 }
 
+var consumerPresets = []string{"work-queue", "fanout", "replay", "sampling"}
+
+// applyPreset sets all options not given on the command line to the values of the selected preset, accepting defaults for the rest
+func (c *consumerCmd) applyPreset() {
+	var set []string
+
+	setString := func(v *string, val string, desc string) {
+		if *v == "" {
+			*v = val
+			set = append(set, fmt.Sprintf("%s: %s", desc, val))
+		}
+	}
+
+	setInt := func(v *int, unset int, val int, desc string) {
+		if *v == unset {
+			*v = val
+			set = append(set, fmt.Sprintf("%s: %d", desc, val))
+		}
+	}
+
+	if c.delivery == "" && !c.pull {
+		c.pull = true
+		set = append(set, "Delivery: pull")
+	}
+
+	switch c.preset {
+	case "work-queue":
+		// every message processed once by one of many workers, retried when failing
+		setString(&c.startPolicy, "all", "Start Policy")
+		setString(&c.ackPolicy, "explicit", "Ack Policy")
+		setString(&c.replayPolicy, "instant", "Replay Policy")
+		setInt(&c.maxDeliver, 0, 10, "Maximum Deliveries")
+		setInt(&c.maxAckPending, -1, 1000, "Maximum Ack Pending")
+		if c.ackWait <= 0 {
+			c.ackWait = 30 * time.Second
+			set = append(set, "Ack Wait: 30s")
+		}
+
+	case "fanout":
+		// independent readers of new messages that do not need redelivery
+		setString(&c.startPolicy, "new", "Start Policy")
+		setString(&c.ackPolicy, "none", "Ack Policy")
+		setString(&c.replayPolicy, "instant", "Replay Policy")
+
+	case "replay":
+		// re-reads the entire stream at the rate messages were originally received
+		setString(&c.startPolicy, "all", "Start Policy")
+		setString(&c.ackPolicy, "none", "Ack Policy")
+		setString(&c.replayPolicy, "original", "Replay Policy")
+
+	case "sampling":
+		// observes new messages and publishes acknowledgement latency advisories for all of them
+		setString(&c.startPolicy, "new", "Start Policy")
+		setString(&c.ackPolicy, "explicit", "Ack Policy")
+		setString(&c.replayPolicy, "instant", "Replay Policy")
+		setInt(&c.samplePct, -1, 100, "Sample Percentage")
+	}
+
+	c.acceptDefaults = true
+
+	fmt.Printf("Applied preset %s setting:\n\n", c.preset)
+	for _, s := range set {
+		fmt.Printf("  %s\n", s)
+	}
+	fmt.Println()
+}
+
 func (c *consumerCmd) defaultConsumer() *api.ConsumerConfig {
 	return &api.ConsumerConfig{
 		AckPolicy:    api.AckExplicit,
@@ -1390,10 +1918,25 @@ func (c *consumerCmd) cpAction(pc *fisk.ParseContext) (err error) {
 		cfg.SampleFrequency = c.sampleFreqFromInt(c.samplePct)
 	}
 
+	if c.startPolicy != "" && c.startAtDelivered {
+		return fmt.Errorf("--deliver and --start-at-delivered cannot be used together")
+	}
+
 	if c.startPolicy != "" {
 		c.setStartPolicy(&cfg, c.startPolicy)
 	}
 
+	if c.startAtDelivered {
+		state, err := source.State()
+		if err != nil {
+			return fmt.Errorf("could not load source Consumer state: %w", err)
+		}
+
+		cfg.DeliverPolicy = api.DeliverByStartSequence
+		cfg.OptStartSeq = state.Delivered.Stream + 1
+		cfg.OptStartTime = nil
+	}
+
 	if c.ephemeral {
 		cfg.Durable = ""
 	} else {
@@ -1493,6 +2036,11 @@ func (c *consumerCmd) cpAction(pc *fisk.ParseContext) (err error) {
 		cfg.HeadersOnly = c.hdrsOnly
 	}
 
+	err = checkPullSettings(&cfg)
+	if err != nil {
+		return err
+	}
+
 	consumer, err := c.mgr.NewConsumerFromDefault(c.stream, cfg)
 	fisk.FatalIfError(err, "Consumer creation failed")
 
@@ -1502,13 +2050,13 @@ func (c *consumerCmd) cpAction(pc *fisk.ParseContext) (err error) {
 
 	c.consumer = cfg.Durable
 
-	c.showConsumer(consumer)
+	c.showConsumer(os.Stdout, consumer)
 
 	return nil
 }
 
-func (c *consumerCmd) loadConfigFile(file string) (*api.ConsumerConfig, error) {
-	f, err := os.ReadFile(file)
+func (c *consumerCmd) loadConfigFile(file string, expandEnv bool) (*api.ConsumerConfig, error) {
+	f, err := readConfigFile(file, c.configValuesFile, c.configValues, expandEnv)
 	if err != nil {
 		return nil, err
 	}
@@ -1549,7 +2097,11 @@ func (c *consumerCmd) prepareConfig() (cfg *api.ConsumerConfig, err error) {
 	cfg.Description = c.description
 
 	if c.inputFile != "" {
-		cfg, err = c.loadConfigFile(c.inputFile)
+		if c.preset != "" {
+			return nil, fmt.Errorf("presets can not be used with configuration files")
+		}
+
+		cfg, err = c.loadConfigFile(c.inputFile, true)
 		if err != nil {
 			return nil, err
 		}
@@ -1568,14 +2120,38 @@ func (c *consumerCmd) prepareConfig() (cfg *api.ConsumerConfig, err error) {
 
 		cfg.Metadata = iu.RemoveReservedMetadata(cfg.Metadata)
 
-		return cfg, err
+		nameTemplate, err := consumerNameTemplate()
+		if err != nil {
+			return nil, err
+		}
+		if nameTemplate != "" && cfg.Durable != "" {
+			err = validateConsumerName(nameTemplate, cfg.Durable)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return cfg, nil
+	}
+
+	if c.preset != "" {
+		c.applyPreset()
+	}
+
+	nameTemplate, err := consumerNameTemplate()
+	if err != nil {
+		return nil, err
 	}
 
 	if c.consumer == "" && !c.ephemeral {
-		err = iu.AskOne(&survey.Input{
-			Message: "Consumer name",
-			Help:    "This will be used for the name to be used when referencing this Consumer later. Settable using 'name' CLI argument",
-		}, &c.consumer, survey.WithValidator(survey.Required))
+		if nameTemplate != "" {
+			c.consumer, err = askTemplatedConsumerName(nameTemplate)
+		} else {
+			err = iu.AskOne(&survey.Input{
+				Message: "Consumer name",
+				Help:    "This will be used for the name to be used when referencing this Consumer later. Settable using 'name' CLI argument",
+			}, &c.consumer, survey.WithValidator(survey.Required))
+		}
 		fisk.FatalIfError(err, "could not request durable name")
 	}
 
@@ -1585,6 +2161,13 @@ func (c *consumerCmd) prepareConfig() (cfg *api.ConsumerConfig, err error) {
 		cfg.Durable = c.consumer
 	}
 
+	if nameTemplate != "" && cfg.Durable != "" {
+		err = validateConsumerName(nameTemplate, cfg.Durable)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if ok, _ := regexp.MatchString(`\.|\*|>`, cfg.Durable); ok {
 		fisk.Fatalf("durable name can not contain '.', '*', '>'")
 	}
@@ -2046,7 +2629,7 @@ func (c *consumerCmd) validateCfg(cfg *api.ConsumerConfig) (bool, []byte, []stri
 		return false, nil, nil, err
 	}
 
-	if os.Getenv("NOVALIDATE") != "" {
+	if validationLevel() == validationOff {
 		return true, nil, nil, nil
 	}
 
@@ -2055,10 +2638,148 @@ func (c *consumerCmd) validateCfg(cfg *api.ConsumerConfig) (bool, []byte, []stri
 	return valid, j, errs, nil
 }
 
-func (c *consumerCmd) createAction(pc *fisk.ParseContext) (err error) {
-	cfg, err := c.prepareConfig()
-	if err != nil {
-		return err
+// checkPullSettings ensures pull request limits are only set on pull consumers and are not negative
+func checkPullSettings(cfg *api.ConsumerConfig) error {
+	settings := []struct {
+		name string
+		set  bool
+		neg  bool
+	}{
+		{"Max Waiting Pulls", cfg.MaxWaiting != 0, cfg.MaxWaiting < 0},
+		{"Max Pull Batch", cfg.MaxRequestBatch != 0, cfg.MaxRequestBatch < 0},
+		{"Max Pull Expire", cfg.MaxRequestExpires != 0, cfg.MaxRequestExpires < 0},
+		{"Max Pull MaxBytes", cfg.MaxRequestMaxBytes != 0, cfg.MaxRequestMaxBytes < 0},
+	}
+
+	var errs []string
+	for _, setting := range settings {
+		switch {
+		case setting.set && cfg.DeliverSubject != "":
+			errs = append(errs, fmt.Sprintf("%s can only be set on Pull consumers", setting.name))
+		case setting.neg:
+			errs = append(errs, fmt.Sprintf("%s can not be negative", setting.name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid Consumer configuration: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// checkDeliverLoop refuses push consumers that deliver messages back into their own stream unless allowed
+func (c *consumerCmd) checkDeliverLoop(cfg *api.ConsumerConfig) error {
+	if c.allowLoop || cfg.DeliverSubject == "" {
+		return nil
+	}
+
+	stream, err := c.mgr.LoadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	subject, loop := subjectLoop(stream.Subjects(), cfg.DeliverSubject)
+	if loop {
+		return fmt.Errorf("delivery subject %q overlaps with stream subject %q and would create a loop, use --allow-loop to override", cfg.DeliverSubject, subject)
+	}
+
+	return nil
+}
+
+// validatePlan compares cfg with the live Consumer of the same name when it exists
+func (c *consumerCmd) validatePlan(cfg *api.ConsumerConfig) error {
+	name := cfg.Name
+	if cfg.Durable != "" {
+		name = cfg.Durable
+	}
+	if c.stream == "" || name == "" {
+		return nil
+	}
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	if err != nil {
+		return err
+	}
+
+	known, err := mgr.IsKnownStream(c.stream)
+	if err == nil && known {
+		known, err = mgr.IsKnownConsumer(c.stream, name)
+	}
+	if err != nil {
+		return err
+	}
+	if !known {
+		return nil
+	}
+
+	consumer, err := mgr.LoadConsumer(c.stream, name)
+	if err != nil {
+		return err
+	}
+
+	live := consumer.Configuration()
+	desired := *cfg
+	live.Metadata = iu.RemoveReservedMetadata(live.Metadata)
+	desired.Metadata = iu.RemoveReservedMetadata(desired.Metadata)
+	sort.Strings(live.FilterSubjects)
+	sort.Strings(desired.FilterSubjects)
+
+	return renderValidatePlan("Consumer", fmt.Sprintf("%s > %s", c.stream, name), live, desired, immutableConsumerChanges(live, desired))
+}
+
+// immutableConsumerChanges lists changes from live to desired that the server would reject in an update
+func immutableConsumerChanges(live api.ConsumerConfig, desired api.ConsumerConfig) []string {
+	var changes []string
+
+	if live.DeliverPolicy != desired.DeliverPolicy {
+		changes = append(changes, fmt.Sprintf("Deliver Policy can not be changed from %v to %v", live.DeliverPolicy, desired.DeliverPolicy))
+	}
+
+	if live.OptStartSeq != desired.OptStartSeq {
+		changes = append(changes, fmt.Sprintf("Start Sequence can not be changed from %d to %d", live.OptStartSeq, desired.OptStartSeq))
+	}
+
+	if (live.OptStartTime == nil) != (desired.OptStartTime == nil) || (live.OptStartTime != nil && !live.OptStartTime.Equal(*desired.OptStartTime)) {
+		changes = append(changes, "Start Time can not be changed")
+	}
+
+	if live.AckPolicy != desired.AckPolicy {
+		changes = append(changes, fmt.Sprintf("Ack Policy can not be changed from %v to %v", live.AckPolicy, desired.AckPolicy))
+	}
+
+	if live.ReplayPolicy != desired.ReplayPolicy {
+		changes = append(changes, fmt.Sprintf("Replay Policy can not be changed from %v to %v", live.ReplayPolicy, desired.ReplayPolicy))
+	}
+
+	if (live.DeliverSubject == "") != (desired.DeliverSubject == "") {
+		changes = append(changes, "Consumers can not be changed between push and pull modes")
+	}
+
+	if live.Heartbeat != desired.Heartbeat {
+		changes = append(changes, fmt.Sprintf("Idle Heartbeat can not be changed from %v to %v", live.Heartbeat, desired.Heartbeat))
+	}
+
+	if live.FlowControl != desired.FlowControl {
+		changes = append(changes, "Flow Control can not be changed")
+	}
+
+	if live.DeliverSubject == "" && desired.MaxWaiting != 0 && live.MaxWaiting != desired.MaxWaiting {
+		changes = append(changes, fmt.Sprintf("Maximum Waiting Pulls can not be changed from %d to %d", live.MaxWaiting, desired.MaxWaiting))
+	}
+
+	return changes
+}
+
+func (c *consumerCmd) createAction(pc *fisk.ParseContext) (err error) {
+	cfg, err := c.prepareConfig()
+	if err != nil {
+		return err
+	}
+
+	err = checkPullSettings(cfg)
+	if err != nil {
+		return err
 	}
 
 	switch {
@@ -2073,7 +2794,8 @@ func (c *consumerCmd) createAction(pc *fisk.ParseContext) (err error) {
 		}
 
 		fmt.Println("Configuration is a valid Consumer")
-		return nil
+
+		return c.validatePlan(cfg)
 
 	case c.outFile != "":
 		valid, j, errs, err := c.validateCfg(cfg)
@@ -2088,7 +2810,7 @@ func (c *consumerCmd) createAction(pc *fisk.ParseContext) (err error) {
 
 	c.connectAndSetup(true, false)
 
-	err = c.checkConfigLevel(cfg)
+	err = c.checkCreateConfig(cfg)
 	if err != nil {
 		return err
 	}
@@ -2098,12 +2820,43 @@ func (c *consumerCmd) createAction(pc *fisk.ParseContext) (err error) {
 
 	c.consumer = created.Name()
 
-	c.showConsumer(created)
+	c.showConsumer(os.Stdout, created)
 
 	return nil
 }
 
+// checkCreateConfig performs the checks that require a connection before a Consumer is created using cfg
+func (c *consumerCmd) checkCreateConfig(cfg *api.ConsumerConfig) error {
+	err := c.checkConfigLevel(cfg)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkDeliverLoop(cfg)
+	if err != nil {
+		return err
+	}
+
+	return validateRequest(*cfg)
+}
+
 func (c *consumerCmd) checkConfigLevel(cfg *api.ConsumerConfig) error {
+	if len(cfg.BackOff) > 0 {
+		warnServerFeature(c.nc, "Backoff policies", 2, 7, 1)
+	}
+	if cfg.HeadersOnly {
+		warnServerFeature(c.nc, "Headers only delivery", 2, 6, 2)
+	}
+	if len(cfg.FilterSubjects) > 0 {
+		warnServerFeature(c.nc, "Multiple filter subjects", 2, 10, 0)
+	}
+	if len(iu.RemoveReservedMetadata(cfg.Metadata)) > 0 {
+		warnServerFeature(c.nc, "Consumer metadata", 2, 10, 0)
+	}
+	if cfg.InactiveThreshold > 0 && cfg.Durable != "" {
+		warnServerFeature(c.nc, "Inactive thresholds on durable Consumers", 2, 10, 0)
+	}
+
 	if !cfg.PauseUntil.IsZero() {
 		err := iu.RequireAPILevel(c.mgr, 1, "pausing consumers requires NATS Server 2.11")
 		if err != nil {
@@ -2121,8 +2874,47 @@ func (c *consumerCmd) checkConfigLevel(cfg *api.ConsumerConfig) error {
 	return nil
 }
 
+// errNextStatus indicates a pull request completed with a status message rather than a message
+var errNextStatus = errors.New("pull request completed without a message")
+
+// nextStatusDescription describes a status message received in response to a pull request, false when the status is not known
+func nextStatusDescription(status string, description string) (string, bool) {
+	var meaning string
+
+	switch status {
+	case "404":
+		meaning = "No messages available"
+	case "408":
+		meaning = "Pull request expired before a message was available"
+	case "409":
+		meaning = "Pull request terminated"
+	default:
+		return "", false
+	}
+
+	if description == "" {
+		return fmt.Sprintf("%s (%s)", meaning, status), true
+	}
+
+	return fmt.Sprintf("%s (%s %s)", meaning, status, description), true
+}
+
 func (c *consumerCmd) getNextMsgDirect(stream string, consumer string) error {
-	req := &api.JSApiConsumerGetNextRequest{Batch: 1, Expires: opts().Timeout}
+	req := &api.JSApiConsumerGetNextRequest{Batch: 1, Expires: opts().Timeout, NoWait: c.nextNoWait}
+	if c.nextNoWait {
+		req.Expires = 0
+	}
+
+	if c.nextMaxBytes != "" {
+		maxBytes, err := parseStringAsBytes(c.nextMaxBytes)
+		fisk.FatalIfError(err, "invalid --max-bytes")
+		req.MaxBytes = int(maxBytes)
+	}
+
+	err := validateRequest(req)
+	if err != nil {
+		return err
+	}
 
 	sub, err := c.nc.SubscribeSync(c.nc.NewRespInbox())
 	fisk.FatalIfError(err, "subscribe failed")
@@ -2135,234 +2927,702 @@ func (c *consumerCmd) getNextMsgDirect(stream string, consumer string) error {
 		cons, err := c.mgr.LoadConsumer(stream, consumer)
 		fisk.FatalIfError(err, "could not load consumer %q", consumer)
 
-		if !cons.IsPullMode() {
-			fisk.Fatalf("consumer %q is not a Pull consumer", consumer)
-		}
+		if !cons.IsPullMode() {
+			fisk.Fatalf("consumer %q is not a Pull consumer", consumer)
+		}
+	}
+
+	ackType, err := c.nextAckType()
+	fisk.FatalIfError(err, "invalid acknowledgement")
+
+	// the server reports an expired request with a 408 status, allow some time for it to arrive
+	msg, err := sub.NextMsg(opts().Timeout + time.Second)
+	if err != nil {
+		fatalIfNotPull()
+	}
+	fisk.FatalIfError(err, "no message received")
+
+	if msg.Header != nil && msg.Header.Get("Status") == "503" {
+		fatalIfNotPull()
+	}
+
+	if len(msg.Data) == 0 && msg.Header != nil {
+		status, ok := nextStatusDescription(msg.Header.Get("Status"), msg.Header.Get("Description"))
+		if ok {
+			fmt.Fprintln(os.Stderr, status)
+			return errNextStatus
+		}
+	}
+
+	// valid messages are not shown when only invalid ones are requested
+	hidden := c.schema != nil && !c.schema.inspect(msg.Subject, decodedMsg(msg, c.decoders).Data)
+
+	switch {
+	case hidden:
+	case !c.raw:
+		info, err := jsm.ParseJSMsgMetadata(msg)
+		if err != nil {
+			if msg.Reply == "" {
+				fmt.Printf("--- subject: %s\n", msg.Subject)
+			} else {
+				fmt.Printf("--- subject: %s reply: %s\n", msg.Subject, msg.Reply)
+			}
+			fmt.Println("    stream and consumer sequences and message age are unknown")
+
+		} else {
+			fmt.Printf("[%s] %ssubj: %s / tries: %d / cons seq: %d / str seq: %d / pending: %s\n", time.Now().Format("15:04:05"), c.workerLabel(), msg.Subject, info.Delivered(), info.ConsumerSequence(), info.StreamSequence(), f(info.Pending()))
+			fmt.Printf("    stored: %s / age: %s\n", info.TimeStamp().Format(time.RFC3339Nano), f(time.Since(info.TimeStamp())))
+		}
+
+		fmt.Println()
+		c.display.printMsg(msg.Header, func() { fmt.Println(string(c.displayData(msg))) })
+	case c.jsonl:
+		err = outPutMSGJSONL(c.display.filter(decodedMsg(msg, c.decoders)), c.translate)
+		fisk.FatalIfError(err, "could not render message")
+	default:
+		c.display.printRaw(msg.Header, func() { fmt.Println(string(c.displayData(msg))) })
+	}
+
+	if ackType == "" {
+		return nil
+	}
+
+	if c.ackDelay > 0 {
+		c.delayAck(msg, c.ackDelay)
+	}
+
+	ack, done := nextAckResponse(ackType)
+	if opts().Trace {
+		log.Printf(">>> %s: %s", msg.Reply, string(ack))
+	}
+
+	err = msg.Respond(ack)
+	fisk.FatalIfError(err, "could not %s message", ackType)
+	c.nc.Flush()
+
+	if !c.raw && !hidden {
+		if c.ackDelay > 0 {
+			fmt.Printf("\n%s message after %s delay\n", done, c.ackDelay)
+		} else {
+			fmt.Printf("\n%s message\n", done)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// nextAckTypes are the acknowledgements consumer next can send
+var nextAckTypes = []string{"ack", "nak", "term", "in-progress"}
+
+// nextAckType determines how to acknowledge received messages from --ack-type or the --ack, --nak and --term flags, empty when not acknowledging
+func (c *consumerCmd) nextAckType() (string, error) {
+	if c.ackType != "" {
+		if c.nak || c.term || c.ackSetByUser {
+			return "", fmt.Errorf("--ack-type can not be combined with --ack, --nak or --term")
+		}
+		return c.ackType, nil
+	}
+
+	switch {
+	case c.term && c.nak:
+		return "", fmt.Errorf("can not both NaK and Terminate message")
+	case c.term && c.ackSetByUser && c.ack:
+		return "", fmt.Errorf("can not both Acknowledge and Terminate message")
+	case c.term:
+		return "term", nil
+	case c.nak:
+		return "nak", nil
+	case c.ack:
+		return "ack", nil
+	default:
+		return "", nil
+	}
+}
+
+// nextAckResponse is the protocol message sent for an acknowledgement type and a description of its outcome
+func nextAckResponse(ackType string) ([]byte, string) {
+	switch ackType {
+	case "nak":
+		return api.AckNak, "Negative Acknowledged"
+	case "term":
+		return api.AckTerm, "Terminated"
+	case "in-progress":
+		return api.AckProgress, "Sent progress acknowledgement for"
+	default:
+		return api.AckAck, "Acknowledged"
+	}
+}
+
+// showMsgAge shows the sequences and age of the next message the consumer will deliver, the message is read from
+// the stream rather than pulled so inspecting it does not count as a delivery attempt
+func (c *consumerCmd) showMsgAge() error {
+	cons, err := c.mgr.LoadConsumer(c.stream, c.consumer)
+	if err != nil {
+		return err
+	}
+
+	nfo, err := cons.LatestState()
+	if err != nil {
+		return err
+	}
+
+	filters := nfo.Config.FilterSubjects
+	if nfo.Config.FilterSubject != "" {
+		filters = []string{nfo.Config.FilterSubject}
+	}
+	if len(filters) == 0 {
+		filters = []string{">"}
+	}
+
+	var next *api.StoredMsg
+	for _, filter := range filters {
+		msg, err := nextStreamMsg(c.nc, c.stream, nfo.Delivered.Stream+1, filter)
+		if err != nil {
+			return err
+		}
+
+		if msg != nil && (next == nil || msg.Sequence < next.Sequence) {
+			next = msg
+		}
+	}
+
+	if next == nil {
+		fmt.Printf("%s > %s has no undelivered messages / ack floor str seq: %d / ack pending: %s\n", c.stream, c.consumer, nfo.AckFloor.Stream, f(nfo.NumAckPending))
+		return nil
+	}
+
+	fmt.Printf("%s > %s next message str seq: %d / cons seq: %d / stored: %s / age: %s / pending: %s\n", c.stream, c.consumer, next.Sequence, nfo.Delivered.Consumer+1, next.Time.Format(time.RFC3339), f(time.Since(next.Time)), f(nfo.NumPending))
+
+	if nfo.NumRedelivered > 0 {
+		fmt.Printf("%s > %s has %s messages awaiting redelivery that may be delivered first\n", c.stream, c.consumer, f(nfo.NumRedelivered))
+	}
+
+	return nil
+}
+
+// delayAck waits before acknowledging msg, with auto progress enabled the message is kept from being redelivered using progress acknowledgements
+func (c *consumerCmd) delayAck(msg *nats.Msg, delay time.Duration) {
+	if !c.autoProgress || c.selectedConsumer.AckWait() <= 0 {
+		time.Sleep(delay)
+		return
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(c.selectedConsumer.AckWait() / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			return
+		case <-ticker.C:
+			if opts().Trace {
+				log.Printf(">>> %s: %s", msg.Reply, string(api.AckProgress))
+			}
+
+			err := msg.Respond(api.AckProgress)
+			if err != nil {
+				log.Printf("Could not send progress acknowledgement: %s", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkAckDelay loads the consumer delayed acknowledgements need and warns when the delay could exceed the consumer Ack Wait and cause redeliveries
+func (c *consumerCmd) checkAckDelay() error {
+	ackType, _ := c.nextAckType()
+	if c.ackDelay <= 0 || ackType == "" {
+		return nil
+	}
+
+	if c.selectedConsumer == nil {
+		var err error
+		c.selectedConsumer, err = c.mgr.LoadConsumer(c.stream, c.consumer)
+		if err != nil {
+			return fmt.Errorf("could not load Consumer %s > %s: %w", c.stream, c.consumer, err)
+		}
+	}
+
+	if c.selectedConsumer.AckPolicy() == api.AckNone || c.ackDelay < c.selectedConsumer.AckWait() {
+		return nil
+	}
+
+	if c.autoProgress {
+		if !c.raw {
+			fmt.Printf("Acknowledgement delay of %v exceeds the Consumer Ack Wait of %v, sending progress acknowledgements every %v\n\n", c.ackDelay, c.selectedConsumer.AckWait(), c.selectedConsumer.AckWait()/2)
+		}
+		return nil
+	}
+
+	log.Printf("WARNING: Acknowledgement delay of %v exceeds the Consumer Ack Wait of %v, messages may be redelivered before being acknowledged. Use --auto-progress to prevent redelivery", c.ackDelay, c.selectedConsumer.AckWait())
+
+	return nil
+}
+
+func (c *consumerCmd) subscribeConsumer(consumer *jsm.Consumer) (err error) {
+	if !c.raw {
+		fmt.Printf("Subscribing to topic %s auto acknowledgment: %v\n\n", consumer.DeliverySubject(), c.ack)
+		fmt.Println("Consumer Info:")
+		fmt.Printf("  Ack Policy: %s\n", consumer.AckPolicy().String())
+		if consumer.AckPolicy() != api.AckNone {
+			fmt.Printf("    Ack Wait: %v\n", consumer.AckWait())
+		}
+		fmt.Println()
+	}
+
+	if c.queue && consumer.DeliverGroup() == "" {
+		return fmt.Errorf("consumer %s > %s has no deliver group and cannot be shared", c.stream, c.consumer)
+	}
+
+	var sub *nats.Subscription
+	if consumer.DeliverGroup() == "" {
+		sub, err = c.nc.Subscribe(consumer.DeliverySubject(), c.handleSubMsg)
+	} else {
+		sub, err = c.nc.QueueSubscribe(consumer.DeliverySubject(), consumer.DeliverGroup(), c.handleSubMsg)
+	}
+
+	fisk.FatalIfError(err, "could not subscribe")
+
+	if c.eos != nil {
+		err = c.eos.wait(c.subCtx)
+		drainSubscriptions([]*nats.Subscription{sub}, opts().Timeout)
+		if err == nil {
+			c.logEndOfStream()
+		}
+		return nil
+	}
+
+	<-c.subCtx.Done()
+	drainSubscriptions([]*nats.Subscription{sub}, opts().Timeout)
+
+	return nil
+}
+
+// endOfStreamReached checks if --until-sealed should stop consuming, called when no messages are available
+func (c *consumerCmd) endOfStreamReached() bool {
+	if c.eos == nil {
+		return false
+	}
+
+	done, err := c.eos.done()
+	if err != nil {
+		log.Printf("Could not determine consumption state: %v", err)
+		return false
+	}
+
+	if done {
+		c.logEndOfStream()
+	}
+
+	return done
+}
+
+func (c *consumerCmd) logEndOfStream() {
+	if !c.raw {
+		fmt.Printf("Consumed all messages from %s > %s\n", c.stream, c.consumer)
+	}
+}
+
+// consumptionState is the end of stream state used by --until-sealed
+func (c *consumerCmd) consumptionState(consumer *jsm.Consumer, stream *jsm.Stream) (consumptionState, error) {
+	nfo, err := consumer.LatestState()
+	if err != nil {
+		return consumptionState{}, err
+	}
+
+	sinfo, err := stream.LatestInformation()
+	if err != nil {
+		return consumptionState{}, err
+	}
+
+	return consumptionState{Pending: nfo.NumPending, AckPending: nfo.NumAckPending, Sealed: sinfo.Config.Sealed}, nil
+}
+
+// displayData decodes and translates the message data for output
+func (c *consumerCmd) displayData(msg *nats.Msg) []byte {
+	data := decodedMsg(msg, c.decoders).Data
+	if c.translate == "" {
+		return data
+	}
+
+	out, err := filterDataThroughCmd(data, c.translate, msg.Subject, c.stream)
+	if err != nil {
+		log.Printf("Could not translate message data: %v", err)
+		return data
+	}
+
+	return out
+}
+
+func (c *consumerCmd) handleSubMsg(m *nats.Msg) {
+	if len(m.Data) == 0 && m.Header.Get("Status") == "100" {
+		stalled := m.Header.Get("Nats-Consumer-Stalled")
+		if stalled != "" {
+			c.nc.Publish(stalled, nil)
+		} else {
+			m.Respond(nil)
+		}
+
+		return
+	}
+
+	if c.subCount > 0 {
+		// messages delivered while draining are left unacknowledged for redelivery
+		if c.subReceived >= c.subCount {
+			return
+		}
+
+		c.subReceived++
+		if c.subReceived == c.subCount {
+			defer c.subCancel()
+		}
+	}
+
+	if c.eos != nil {
+		c.eos.received()
+	}
+
+	c.stats.received(len(m.Data))
+
+	if c.capture != nil {
+		c.captureMsg(m)
+		return
+	}
+
+	if c.schema != nil && !c.schema.inspect(m.Subject, decodedMsg(m, c.decoders).Data) {
+		c.ackSubMsg(m)
+		return
+	}
+
+	var msginfo *jsm.MsgInfo
+	var err error
+
+	if len(m.Reply) > 0 {
+		msginfo, err = jsm.ParseJSMsgMetadata(m)
+	}
+
+	fisk.FatalIfError(err, "could not parse JetStream metadata: '%s'", m.Reply)
+
+	if !c.raw {
+		now := time.Now().Format("15:04:05")
+
+		if msginfo != nil {
+			fmt.Printf("[%s] %ssubj: %s / tries: %d / cons seq: %d / str seq: %d / pending: %s\n", now, c.workerLabel(), m.Subject, msginfo.Delivered(), msginfo.ConsumerSequence(), msginfo.StreamSequence(), f(msginfo.Pending()))
+		} else {
+			fmt.Printf("[%s] %s%s reply: %s\n", now, c.workerLabel(), m.Subject, m.Reply)
+		}
+
+		if len(m.Data) == 0 && m.Reply != "" && m.Header.Get("Status") == "100" {
+			m.Respond(nil)
+			return
+		}
+
+		fmt.Println()
+		c.display.printMsg(m.Header, func() {
+			data := string(c.displayData(m))
+			fmt.Printf("%s\n", data)
+			if !strings.HasSuffix(data, "\n") {
+				fmt.Println()
+			}
+		})
+		if c.display.headersOnly {
+			fmt.Println()
+		}
+	} else if c.jsonl {
+		err = outPutMSGJSONL(c.display.filter(decodedMsg(m, c.decoders)), c.translate)
+		if err != nil {
+			log.Printf("Could not render message as JSON: %s", err)
+		}
+	} else {
+		c.display.printRaw(m.Header, func() { fmt.Println(string(c.displayData(m))) })
+	}
+
+	c.ackSubMsg(m)
+}
+
+// ackSubMsg acknowledges a message handled by consumer sub when acknowledgements are enabled
+func (c *consumerCmd) ackSubMsg(m *nats.Msg) {
+	if !c.ack {
+		return
+	}
+
+	err := m.Respond(nil)
+	c.stats.acked(err)
+	if err != nil {
+		fmt.Printf("Acknowledging message via subject %s failed: %s\n", m.Reply, err)
+	}
+}
+
+// captureMsg writes m to the capture files before acknowledging it, messages already captured are only acknowledged
+func (c *consumerCmd) captureMsg(m *nats.Msg) {
+	meta, err := jsm.ParseJSMsgMetadata(m)
+	if err != nil {
+		log.Printf("Could not parse JetStream metadata: '%s': %v", m.Reply, err)
+		return
 	}
 
-	if c.term {
-		if !c.ackSetByUser {
-			c.ack = false
-		}
+	if !c.capture.duplicate(meta.StreamSequence()) {
+		line, err := msgJSONL(c.display.filter(decodedMsg(m, c.decoders)), c.translate)
+		fisk.FatalIfError(err, "could not encode message %d", meta.StreamSequence())
 
-		if c.ack || c.nak {
-			fisk.Fatalf("can not both Acknowledge and Terminate message")
-		}
+		err = c.capture.write(meta.StreamSequence(), line)
+		fisk.FatalIfError(err, "could not capture message %d", meta.StreamSequence())
+	}
 
-		if c.ack && c.nak {
-			fisk.Fatalf("can not both Acknowledge and NaK message")
-		}
+	if !c.ack {
+		return
 	}
 
-	msg, err := sub.NextMsg(opts().Timeout)
+	// the server confirms acknowledgements sent as requests
+	_, err = c.nc.Request(m.Reply, api.AckAck, opts().Timeout)
+	c.stats.acked(err)
 	if err != nil {
-		fatalIfNotPull()
+		log.Printf("Acknowledging message %d failed: %v", meta.StreamSequence(), err)
+		return
 	}
-	fisk.FatalIfError(err, "no message received")
 
-	if msg.Header != nil && msg.Header.Get("Status") == "503" {
-		fatalIfNotPull()
+	err = c.capture.acked(meta.StreamSequence())
+	fisk.FatalIfError(err, "could not record the acknowledgement of message %d", meta.StreamSequence())
+}
+
+// workerLabel is the worker id prefix for message output when one is set
+func (c *consumerCmd) workerLabel() string {
+	if c.workerID == "" {
+		return ""
 	}
 
-	if !c.raw {
-		info, err := jsm.ParseJSMsgMetadata(msg)
-		if err != nil {
-			if msg.Reply == "" {
-				fmt.Printf("--- subject: %s\n", msg.Subject)
-			} else {
-				fmt.Printf("--- subject: %s reply: %s\n", msg.Subject, msg.Reply)
-			}
+	return fmt.Sprintf("[%s] ", c.workerID)
+}
 
+// pullConsumerWorker continuously pulls messages one at a time so that many instances can share a Pull Consumer
+func (c *consumerCmd) pullConsumerWorker(consumer *jsm.Consumer) error {
+	if !c.raw {
+		if c.workerID == "" {
+			fmt.Printf("Pulling from %s > %s auto acknowledgment: %v\n\n", consumer.StreamName(), consumer.Name(), c.ack)
 		} else {
-			fmt.Printf("[%s] subj: %s / tries: %d / cons seq: %d / str seq: %d / pending: %s\n", time.Now().Format("15:04:05"), msg.Subject, info.Delivered(), info.ConsumerSequence(), info.StreamSequence(), f(info.Pending()))
-		}
-
-		if len(msg.Header) > 0 {
-			fmt.Println()
-			fmt.Println("Headers:")
-			fmt.Println()
-			for h, vals := range msg.Header {
-				for _, val := range vals {
-					fmt.Printf("  %s: %s\n", h, val)
-				}
-			}
-
-			fmt.Println()
-			fmt.Println("Data:")
-			fmt.Println()
+			fmt.Printf("Worker %s pulling from %s > %s auto acknowledgment: %v\n\n", c.workerID, consumer.StreamName(), consumer.Name(), c.ack)
 		}
-
-		fmt.Println()
-		fmt.Println(string(msg.Data))
-	} else {
-		fmt.Println(string(msg.Data))
 	}
 
-	if c.term {
-		err = msg.Term()
-		fisk.FatalIfError(err, "could not Terminate message")
-		c.nc.Flush()
-		fmt.Println("\nTerminated message")
+	sub, err := c.nc.SubscribeSync(c.nc.NewRespInbox())
+	if err != nil {
+		return err
 	}
+	defer sub.Unsubscribe()
 
-	if c.ack || c.nak {
-		var stime time.Duration
-		if c.ackWait > 0 {
-			r := rand.New(rand.NewSource(time.Now().UnixNano()))
-			stime = time.Duration(r.Intn(int(c.ackWait)))
+	for {
+		if c.subCtx.Err() != nil {
+			return nil
 		}
 
-		if stime > 0 {
-			time.Sleep(stime)
+		req := &api.JSApiConsumerGetNextRequest{Batch: 1, Expires: opts().Timeout}
+		err = validateRequest(req)
+		if err != nil {
+			return err
 		}
 
-		ack := api.AckAck
-		if c.nak {
-			ack = api.AckNak
-		}
-		if opts().Trace {
-			log.Printf(">>> %s: %s", msg.Reply, string(ack))
+		err = c.mgr.NextMsgRequest(consumer.StreamName(), consumer.Name(), sub.Subject, req)
+		if err != nil {
+			return err
 		}
 
-		err = msg.Respond(ack)
-
-		fisk.FatalIfError(err, "could not Acknowledge message")
-		c.nc.Flush()
-
-		if !c.raw {
-			neg := ""
-			if c.nak {
-				neg = "Negative "
+		nctx, cancel := context.WithTimeout(c.subCtx, opts().Timeout+time.Second)
+		msg, err := sub.NextMsgWithContext(nctx)
+		cancel()
+		if c.subCtx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			if c.endOfStreamReached() {
+				return nil
 			}
-			if stime > 0 {
-				fmt.Printf("\n%sAcknowledged message after %s delay\n", neg, stime)
-			} else {
-				fmt.Printf("\n%sAcknowledged message\n", neg)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		switch msg.Header.Get("Status") {
+		case "":
+			c.handleSubMsg(msg)
+		case "404", "408":
+			if c.endOfStreamReached() {
+				return nil
 			}
-			fmt.Println()
+			continue
+		default:
+			return fmt.Errorf("pull request failed: %s %s", msg.Header.Get("Status"), msg.Header.Get("Description"))
 		}
 	}
-
-	return nil
 }
 
-func (c *consumerCmd) subscribeConsumer(consumer *jsm.Consumer) (err error) {
-	if !c.raw {
-		fmt.Printf("Subscribing to topic %s auto acknowledgment: %v\n\n", consumer.DeliverySubject(), c.ack)
-		fmt.Println("Consumer Info:")
-		fmt.Printf("  Ack Policy: %s\n", consumer.AckPolicy().String())
-		if consumer.AckPolicy() != api.AckNone {
-			fmt.Printf("    Ack Wait: %v\n", consumer.AckWait())
-		}
-		fmt.Println()
+func (c *consumerCmd) subAction(_ *fisk.ParseContext) error {
+	err := c.display.validate()
+	if err != nil {
+		return err
 	}
 
-	handler := func(m *nats.Msg) {
-		if len(m.Data) == 0 && m.Header.Get("Status") == "100" {
-			stalled := m.Header.Get("Nats-Consumer-Stalled")
-			if stalled != "" {
-				c.nc.Publish(stalled, nil)
-			} else {
-				m.Respond(nil)
-			}
+	c.connectAndSetup(true, true, nats.UseOldRequestStyle())
 
-			return
-		}
+	consumer, err := c.mgr.LoadConsumer(c.stream, c.consumer)
+	fisk.FatalIfError(err, "could not load Consumer")
 
-		var msginfo *jsm.MsgInfo
-		var err error
+	if consumer.AckPolicy() == api.AckNone {
+		c.ack = false
+	}
 
-		if len(m.Reply) > 0 {
-			msginfo, err = jsm.ParseJSMsgMetadata(m)
+	if c.ack {
+		err = checkReadOnly("acknowledge messages, use --no-ack")
+		if err != nil {
+			return err
 		}
+	}
 
-		fisk.FatalIfError(err, "could not parse JetStream metadata: '%s'", m.Reply)
-
-		if !c.raw {
-			now := time.Now().Format("15:04:05")
+	if c.jsonl {
+		c.raw = true
+	}
 
-			if msginfo != nil {
-				fmt.Printf("[%s] subj: %s / tries: %d / cons seq: %d / str seq: %d / pending: %s\n", now, m.Subject, msginfo.Delivered(), msginfo.ConsumerSequence(), msginfo.StreamSequence(), f(msginfo.Pending()))
-			} else {
-				fmt.Printf("[%s] %s reply: %s\n", now, m.Subject, m.Reply)
-			}
+	c.schema, err = newPayloadSchema(c.schemaFile, c.invalidOnly)
+	if err != nil {
+		return err
+	}
 
-			if len(m.Header) > 0 {
-				if len(m.Data) == 0 && m.Reply != "" && m.Header.Get("Status") == "100" {
-					m.Respond(nil)
-					return
-				}
+	if c.queue && c.workerID == "" {
+		host, _ := os.Hostname()
+		c.workerID = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
 
-				fmt.Println()
-				fmt.Println("Headers:")
-				fmt.Println()
+	if c.untilSealed {
+		stream, err := c.mgr.LoadStream(consumer.StreamName())
+		if err != nil {
+			return err
+		}
 
-				for h, vals := range m.Header {
-					for _, val := range vals {
-						fmt.Printf("   %s: %s\n", h, val)
-					}
-				}
+		c.eos = newEndOfStream(c.untilGrace, func() (consumptionState, error) {
+			return c.consumptionState(consumer, stream)
+		})
+	}
 
-				fmt.Println()
-				fmt.Println("Data:")
-			}
+	if c.outputDir != "" {
+		if c.jsonl {
+			return fmt.Errorf("--output-dir and --jsonl are mutually exclusive")
+		}
 
-			fmt.Printf("%s\n", string(m.Data))
-			if !strings.HasSuffix(string(m.Data), "\n") {
-				fmt.Println()
-			}
-		} else {
-			fmt.Println(string(m.Data))
+		maxSize, err := parseStringAsBytes(c.outputMaxSize)
+		if err != nil {
+			return err
 		}
 
-		if c.ack {
-			err = m.Respond(nil)
-			if err != nil {
-				fmt.Printf("Acknowledging message via subject %s failed: %s\n", m.Reply, err)
-			}
+		state, err := consumer.LatestState()
+		if err != nil {
+			return err
 		}
-	}
 
-	if consumer.DeliverGroup() == "" {
-		_, err = c.nc.Subscribe(consumer.DeliverySubject(), handler)
-	} else {
-		_, err = c.nc.QueueSubscribe(consumer.DeliverySubject(), consumer.DeliverGroup(), handler)
-	}
+		c.capture, err = newConsumerCapture(c.outputDir, consumer.StreamName(), consumer.Name(), maxSize, state.AckFloor.Stream)
+		if err != nil {
+			return err
+		}
 
-	fisk.FatalIfError(err, "could not subscribe")
+		// captured messages are not shown
+		c.raw = true
 
-	<-ctx.Done()
+		log.Printf("Capturing %s > %s to %s after ack floor %d with %d unacknowledged messages already captured", consumer.StreamName(), consumer.Name(), c.capture.file.Name(), state.AckFloor.Stream, len(c.capture.captured))
+	}
 
-	return nil
-}
+	sctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
 
-func (c *consumerCmd) subAction(_ *fisk.ParseContext) error {
-	c.connectAndSetup(true, true, nats.UseOldRequestStyle())
+	if c.subFor > 0 {
+		sctx, cancel = context.WithTimeout(sctx, c.subFor)
+		defer cancel()
+	}
 
-	consumer, err := c.mgr.LoadConsumer(c.stream, c.consumer)
-	fisk.FatalIfError(err, "could not load Consumer")
+	c.subCtx = sctx
+	c.subCancel = cancel
+	c.stats = newSubStats()
 
-	if consumer.AckPolicy() == api.AckNone {
-		c.ack = false
-	}
+	bounded := c.subFor > 0 || c.subCount > 0
 
 	switch {
+	case consumer.IsPullMode() && (c.queue || c.capture != nil || c.eos != nil || c.schema != nil || bounded):
+		err = c.pullConsumerWorker(consumer)
 	case consumer.IsPullMode():
 		return c.getNextMsgDirect(consumer.StreamName(), consumer.Name())
 	case consumer.IsPushMode():
-		return c.subscribeConsumer(consumer)
+		err = c.subscribeConsumer(consumer)
 	default:
 		return fmt.Errorf("consumer %s > %s is in an unknown state", c.stream, c.consumer)
 	}
+
+	switch {
+	case c.subCount > 0 && c.subReceived >= c.subCount:
+		log.Printf("Stopped after receiving %s messages", f(c.subReceived))
+	case c.subFor > 0 && errors.Is(sctx.Err(), context.DeadlineExceeded):
+		log.Printf("Stopped after receiving messages for %v", c.subFor)
+	}
+
+	fmt.Fprintln(os.Stderr)
+	c.stats.render(os.Stderr, time.Now())
+	c.schema.render(os.Stderr)
+
+	return err
 }
 
 func (c *consumerCmd) nextAction(_ *fisk.ParseContext) error {
+	err := c.display.validate()
+	if err != nil {
+		return err
+	}
+
 	c.connectAndSetup(false, false, nats.UseOldRequestStyle())
 
-	var err error
+	if c.jsonl {
+		c.raw = true
+	}
+
+	if c.showAgeOnly {
+		if c.raw || c.term || c.nak || c.ackSetByUser || c.ackType != "" || c.ackDelay > 0 {
+			return fmt.Errorf("--show-age-only can not be used with output formats or acknowledgement flags")
+		}
+
+		return c.showMsgAge()
+	}
+
+	ackType, err := c.nextAckType()
+	if err != nil {
+		return err
+	}
+
+	if ackType != "" {
+		err := checkReadOnly("acknowledge messages, use --no-ack")
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.checkAckDelay()
+	if err != nil {
+		return err
+	}
+
+	c.schema, err = newPayloadSchema(c.schemaFile, c.invalidOnly)
+	if err != nil {
+		return err
+	}
+	if c.pullCount > 1 {
+		defer c.schema.render(os.Stderr)
+	}
 
 	for i := 0; i < c.pullCount; i++ {
 		err = c.getNextMsgDirect(c.stream, c.consumer)
+		if errors.Is(err, errNextStatus) {
+			return nil
+		}
 		if err != nil {
 			break
 		}
@@ -2397,34 +3657,105 @@ func (c *consumerCmd) connectAndSetup(askStream bool, askConsumer bool, opts ...
 }
 
 func (c *consumerCmd) reportAction(_ *fisk.ParseContext) error {
+	if c.reportPartitioned {
+		if c.reportWhere != "" {
+			return fmt.Errorf("--where is not supported by partitioned reports")
+		}
+		if len(c.metaSelectors) > 0 {
+			return fmt.Errorf("--select is not supported by partitioned reports")
+		}
+		return c.partitionReportAction()
+	}
+
+	where, err := newReportFilter(c.reportWhere)
+	if err != nil {
+		return err
+	}
+
+	if c.reportWatch {
+		if c.outTemplate != "" || c.csv {
+			return fmt.Errorf("--watch can not be used with --template or --csv")
+		}
+		if c.reportInterval <= 0 {
+			return fmt.Errorf("--interval must be greater than 0")
+		}
+	}
+
 	c.connectAndSetup(true, false)
 
+	if c.reportWatch {
+		return c.watchReport(where)
+	}
+
+	out := newPager()
+	defer out.Close()
+
+	_, err = c.consumerReport(out, where, nil, 0)
+
+	return err
+}
+
+// consumerReport writes the report for c.stream to w, when previous holds the states of an earlier report taken since ago the
+// rates of change are shown. The states of the reported consumers are returned keyed by name
+func (c *consumerCmd) consumerReport(w io.Writer, where *reportFilter, previous map[string]*api.ConsumerInfo, since time.Duration) (map[string]*api.ConsumerInfo, error) {
 	s, err := c.mgr.LoadStream(c.stream)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ss, err := s.LatestState()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	leaders := make(map[string]*raftLeader)
 
 	table := iu.NewTableWriter(opts(), fmt.Sprintf("Consumer report for %s with %s consumers", c.stream, f(ss.Consumers)))
-	table.AddHeaders("Consumer", "Mode", "Ack Policy", "Ack Wait", "Ack Pending", "Redelivered", "Unprocessed", "Ack Floor", "Cluster")
-	missing, err := s.EachConsumer(func(cons *jsm.Consumer) {
+	if c.reportWatch {
+		table.AddHeaders("Consumer", "Mode", "Filter", "Ack Policy", "Ack Wait", "Ack Pending", "Redelivered", "Unprocessed", "Ack Floor", "Ack Floor/s", "Redelivered Change", "Unprocessed Change", "Cluster")
+	} else {
+		table.AddHeaders("Consumer", "Mode", "Filter", "Ack Policy", "Ack Wait", "Ack Pending", "Redelivered", "Unprocessed", "Ack Floor", "Cluster")
+	}
+	consumers, missing, err := c.loadConsumersConcurrently(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []*api.ConsumerInfo
+	states := make(map[string]*api.ConsumerInfo)
+
+	for _, cons := range consumers {
 		cs, err := cons.LatestState()
 		if err != nil {
 			log.Printf("Could not obtain consumer state for %s: %s", cons.Name(), err)
-			return
+			continue
+		}
+
+		if !metadataMatches(cs.Config.Metadata, c.metaSelectors) {
+			continue
+		}
+
+		matched, err := where.match(consumerReportRow(&cs))
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
 		}
 
+		infos = append(infos, &cs)
+		states[cs.Name] = &cs
+
 		mode := "Push"
 		if cons.IsPullMode() {
 			mode = "Pull"
 		}
 
+		filter := cs.Config.FilterSubject
+		if len(cs.Config.FilterSubjects) > 0 {
+			filter = strings.Join(cs.Config.FilterSubjects, ", ")
+		}
+
 		if cs.Cluster != nil {
 			if cs.Cluster.Leader != "" {
 				_, ok := leaders[cs.Cluster.Leader]
@@ -2435,8 +3766,15 @@ func (c *consumerCmd) reportAction(_ *fisk.ParseContext) error {
 			}
 		}
 
+		var rates []any
+		if c.reportWatch {
+			rates = c.consumerReportRates(previous[cs.Name], &cs, since)
+		}
+
 		if c.raw {
-			table.AddRow(cons.Name(), mode, cons.AckPolicy().String(), cons.AckWait(), cs.NumAckPending, cs.NumRedelivered, cs.NumPending, cs.AckFloor.Stream, renderCluster(cs.Cluster))
+			row := []any{cons.Name(), mode, filter, cons.AckPolicy().String(), cons.AckWait(), cs.NumAckPending, cs.NumRedelivered, cs.NumPending, cs.AckFloor.Stream}
+			row = append(row, rates...)
+			table.AddRow(append(row, renderCluster(cs.Cluster))...)
 		} else {
 			unprocessed := "0"
 			if cs.NumPending > 0 {
@@ -2447,24 +3785,91 @@ func (c *consumerCmd) reportAction(_ *fisk.ParseContext) error {
 				unprocessed = fmt.Sprintf("%s / %0.0f%%", f(cs.NumPending), upct)
 			}
 
-			table.AddRow(cons.Name(), mode, cons.AckPolicy().String(), f(cons.AckWait()), f(cs.NumAckPending), f(cs.NumRedelivered), unprocessed, f(cs.AckFloor.Stream), renderCluster(cs.Cluster))
+			row := []any{cons.Name(), mode, filter, cons.AckPolicy().String(), f(cons.AckWait()), f(cs.NumAckPending), f(cs.NumRedelivered), unprocessed, f(cs.AckFloor.Stream)}
+			row = append(row, rates...)
+			table.AddRow(append(row, renderCluster(cs.Cluster))...)
 		}
-	})
-	if err != nil {
-		return err
 	}
 
-	fmt.Println(table.Render())
+	if c.outTemplate != "" {
+		return nil, renderOutputTemplateTo(w, c.outTemplate, infos)
+	}
+
+	if c.csv {
+		return nil, c.renderConsumersCSV(w, infos)
+	}
+
+	if c.reportWatch && iu.IsTerminal() {
+		iu.ClearScreen()
+	}
+
+	fmt.Fprintln(w, table.Render())
 
 	if c.reportLeaderDistrib && len(leaders) > 0 {
-		renderRaftLeaders(leaders, "Consumers")
+		renderRaftLeaders(w, leaders, "Consumers")
 	}
 
 	if len(missing) > 0 {
-		c.renderMissing(os.Stdout, missing)
+		c.renderMissing(w, missing)
 	}
 
-	return nil
+	return states, nil
+}
+
+// loadConsumersConcurrently loads the state of every Consumer on the stream using a bounded pool of workers,
+// Consumers whose state could not be loaded are returned as missing
+func (c *consumerCmd) loadConsumersConcurrently(stream *jsm.Stream) ([]*jsm.Consumer, []string, error) {
+	names, err := stream.ConsumerNames()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		consumers []*jsm.Consumer
+		missing   []string
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+	)
+
+	work := make(chan string, len(names))
+	for _, name := range names {
+		work <- name
+	}
+	close(work)
+
+	for i := 0; i < min(max(c.reportWorkers, 1), len(names)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for name := range work {
+				if ctx.Err() != nil {
+					return
+				}
+
+				cons, err := c.mgr.LoadConsumer(stream.Name(), name)
+
+				mu.Lock()
+				if err != nil {
+					if opts().Trace {
+						log.Printf("Could not load Consumer %s: %s", name, err)
+					}
+					missing = append(missing, name)
+				} else {
+					consumers = append(consumers, cons)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	sort.Slice(consumers, func(i, j int) bool {
+		return consumers[i].Name() < consumers[j].Name()
+	})
+
+	return consumers, missing, nil
 }
 
 func (c *consumerCmd) renderMissing(out io.Writer, missing []string) {
@@ -0,0 +1,124 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestImmutableConsumerChanges(t *testing.T) {
+	live := api.ConsumerConfig{AckPolicy: api.AckExplicit, DeliverPolicy: api.DeliverAll, MaxWaiting: 512}
+
+	desired := live
+	desired.MaxAckPending = 100
+	desired.Description = "updated"
+	if changes := immutableConsumerChanges(live, desired); len(changes) != 0 {
+		t.Fatalf("expected no immutable changes got %v", changes)
+	}
+
+	desired.AckPolicy = api.AckNone
+	desired.DeliverSubject = "deliver"
+	if changes := immutableConsumerChanges(live, desired); len(changes) != 2 {
+		t.Fatalf("expected 2 immutable changes got %v", changes)
+	}
+}
+
+func TestCheckPullSettings(t *testing.T) {
+	cases := []struct {
+		cfg api.ConsumerConfig
+		ok  bool
+	}{
+		{cfg: api.ConsumerConfig{MaxWaiting: 512, MaxRequestBatch: 100, MaxRequestExpires: time.Minute, MaxRequestMaxBytes: 1024}, ok: true},
+		{cfg: api.ConsumerConfig{DeliverSubject: "out"}, ok: true},
+		{cfg: api.ConsumerConfig{DeliverSubject: "out", MaxRequestBatch: 100}, ok: false},
+		{cfg: api.ConsumerConfig{DeliverSubject: "out", MaxWaiting: 1}, ok: false},
+		{cfg: api.ConsumerConfig{MaxRequestExpires: -1 * time.Second}, ok: false},
+	}
+
+	for i, tc := range cases {
+		err := checkPullSettings(&tc.cfg)
+		if (err == nil) != tc.ok {
+			t.Fatalf("case %d: expected ok %v got %v", i, tc.ok, err)
+		}
+	}
+}
+
+func TestConsumerNextAckType(t *testing.T) {
+	cases := []struct {
+		cmd    consumerCmd
+		expect string
+		err    bool
+	}{
+		{cmd: consumerCmd{ack: true}, expect: "ack"},
+		{cmd: consumerCmd{ack: false, ackSetByUser: true}, expect: ""},
+		{cmd: consumerCmd{ack: true, nak: true}, expect: "nak"},
+		{cmd: consumerCmd{ack: true, term: true}, expect: "term"},
+		{cmd: consumerCmd{ack: true, ackSetByUser: true, term: true}, err: true},
+		{cmd: consumerCmd{ack: true, nak: true, term: true}, err: true},
+		{cmd: consumerCmd{ack: true, ackType: "in-progress"}, expect: "in-progress"},
+		{cmd: consumerCmd{ack: true, ackType: "ack", nak: true}, err: true},
+	}
+
+	for i, tc := range cases {
+		ackType, err := tc.cmd.nextAckType()
+		if tc.err {
+			if err == nil {
+				t.Fatalf("case %d expected an error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %d failed: %v", i, err)
+		}
+		if ackType != tc.expect {
+			t.Fatalf("case %d expected %q got %q", i, tc.expect, ackType)
+		}
+	}
+
+	for _, ackType := range nextAckTypes {
+		ack, _ := nextAckResponse(ackType)
+		if len(ack) == 0 {
+			t.Fatalf("no response for %s", ackType)
+		}
+	}
+	if ack, _ := nextAckResponse("in-progress"); !bytes.Equal(ack, api.AckProgress) {
+		t.Fatalf("invalid in-progress response %q", ack)
+	}
+}
+
+func TestNextStatusDescription(t *testing.T) {
+	desc, ok := nextStatusDescription("404", "No Messages")
+	if !ok || desc != "No messages available (404 No Messages)" {
+		t.Fatalf("unexpected description: %q", desc)
+	}
+
+	desc, ok = nextStatusDescription("408", "")
+	if !ok || desc != "Pull request expired before a message was available (408)" {
+		t.Fatalf("unexpected description: %q", desc)
+	}
+
+	desc, ok = nextStatusDescription("409", "Message Size Exceeds MaxBytes")
+	if !ok || desc != "Pull request terminated (409 Message Size Exceeds MaxBytes)" {
+		t.Fatalf("unexpected description: %q", desc)
+	}
+
+	_, ok = nextStatusDescription("503", "")
+	if ok {
+		t.Fatalf("expected 503 to be unknown")
+	}
+}
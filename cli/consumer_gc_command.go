@@ -0,0 +1,181 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go/api"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+func configureConsumerGCCommand(cons *fisk.CmdClause, c *consumerCmd) {
+	gc := cons.Command("gc", "Removes idle Consumers that have no interest").Action(c.gcAction)
+	gc.HelpLong(`Finds Consumers without interest, push Consumers without a bound subscriber
+and pull Consumers without waiting pulls, that had no deliveries or
+acknowledgements for the idle period and removes them.
+
+Only ephemeral and named non-durable Consumers are considered by default.
+A durable pull Consumer on a quiet Stream has no waiting pulls between
+fetches, so durable Consumers are only removed when their names match the
+--durable regular expression.
+
+   nats consumer gc ORDERS --idle 1h --dry-run
+   nats consumer gc ORDERS --idle 24h --durable '^test_'`)
+	addGCFlags(gc, c)
+
+	// prune is kept as an alias of gc for those who know the command by that name
+	prune := cons.Command("prune", "Removes idle Consumers that have no interest").Hidden().Action(c.gcAction)
+	addGCFlags(prune, c)
+}
+
+func addGCFlags(cmd *fisk.CmdClause, c *consumerCmd) {
+	cmd.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	cmd.Flag("idle", "Minimum time since the last delivery or acknowledgement").Default("1h").DurationVar(&c.gcIdle)
+	cmd.Flag("durable", "Also consider durable Consumers with names matching a regular expression").PlaceHolder("REGEX").RegexpVar(&c.gcDurables)
+	cmd.Flag("dry-run", "Only list the Consumers that would be removed").UnNegatableBoolVar(&c.dryRun)
+	cmd.Flag("force", "Remove the Consumers without prompting").Short('f').UnNegatableBoolVar(&c.force)
+}
+
+// gcCandidate is an idle consumer selected for removal
+type gcCandidate struct {
+	name         string
+	kind         string
+	lastActivity time.Time
+}
+
+// consumerGCCandidate determines if nfo is idle for at least idle as of now without any interest, returning its last activity time,
+// durable consumers are only considered when their names match durables
+func consumerGCCandidate(nfo *api.ConsumerInfo, idle time.Duration, durables *regexp.Regexp, now time.Time) (time.Time, bool) {
+	if nfo.Config.Durable != "" && (durables == nil || !durables.MatchString(nfo.Name)) {
+		return time.Time{}, false
+	}
+
+	if nfo.Config.DeliverSubject != "" {
+		if nfo.PushBound {
+			return time.Time{}, false
+		}
+	} else if nfo.NumWaiting > 0 {
+		return time.Time{}, false
+	}
+
+	last := nfo.Created
+	if nfo.Delivered.Last != nil && nfo.Delivered.Last.After(last) {
+		last = *nfo.Delivered.Last
+	}
+	if nfo.AckFloor.Last != nil && nfo.AckFloor.Last.After(last) {
+		last = *nfo.AckFloor.Last
+	}
+
+	return last, now.Sub(last) >= idle
+}
+
+func (c *consumerCmd) gcAction(_ *fisk.ParseContext) error {
+	if c.gcIdle <= 0 {
+		return fmt.Errorf("--idle must be greater than 0")
+	}
+
+	c.connectAndSetup(true, false)
+
+	stream, err := c.mgr.LoadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	consumers, missing, err := c.loadConsumersConcurrently(stream)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var candidates []gcCandidate
+	for _, cons := range consumers {
+		nfo, err := cons.LatestState()
+		if err != nil {
+			log.Printf("Could not obtain Consumer state for %s: %v", cons.Name(), err)
+			continue
+		}
+
+		last, ok := consumerGCCandidate(&nfo, c.gcIdle, c.gcDurables, now)
+		if !ok {
+			continue
+		}
+
+		kind := "Pull"
+		if nfo.Config.DeliverSubject != "" {
+			kind = "Push"
+		}
+		if nfo.Config.Durable != "" {
+			kind += " Durable"
+		} else {
+			kind += " Ephemeral"
+		}
+
+		candidates = append(candidates, gcCandidate{name: nfo.Name, kind: kind, lastActivity: last})
+	}
+
+	if len(missing) > 0 {
+		log.Printf("Could not load %s Consumers, they were not considered", f(len(missing)))
+	}
+
+	if len(candidates) == 0 {
+		fmt.Printf("No Consumers on Stream %s were idle for %s without interest\n", c.stream, f(c.gcIdle))
+		return nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastActivity.Before(candidates[j].lastActivity)
+	})
+
+	table := iu.NewTableWriter(opts(), fmt.Sprintf("%s idle Consumers on Stream %s", f(len(candidates)), c.stream))
+	table.AddHeaders("Name", "Type", "Last Activity", "Idle")
+	for _, cand := range candidates {
+		table.AddRow(cand.name, cand.kind, f(cand.lastActivity.Local()), f(now.Sub(cand.lastActivity)))
+	}
+	fmt.Println(table.Render())
+
+	if c.dryRun {
+		return nil
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really delete %s idle Consumers from Stream %s", f(len(candidates)), c.stream), false)
+		fisk.FatalIfError(err, "could not obtain confirmation")
+
+		if !ok {
+			return nil
+		}
+	}
+
+	var failed int
+	for _, cand := range candidates {
+		err = c.mgr.DeleteConsumer(c.stream, cand.name)
+		if err != nil {
+			log.Printf("Could not delete Consumer %s > %s: %v", c.stream, cand.name, err)
+			failed++
+		}
+	}
+
+	fmt.Printf("Removed %s of %s idle Consumers from Stream %s\n", f(len(candidates)-failed), f(len(candidates)), c.stream)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to remove %s Consumers", f(failed))
+	}
+
+	return nil
+}
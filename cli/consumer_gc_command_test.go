@@ -0,0 +1,61 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestConsumerGCCandidate(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-2 * time.Hour)
+	recent := now.Add(-time.Minute)
+
+	nfo := &api.ConsumerInfo{Created: old}
+	if last, ok := consumerGCCandidate(nfo, time.Hour, nil, now); !ok || !last.Equal(old) {
+		t.Fatalf("expected idle pull consumer to be a candidate")
+	}
+
+	nfo.Delivered.Last = &recent
+	if _, ok := consumerGCCandidate(nfo, time.Hour, nil, now); ok {
+		t.Fatalf("expected recently delivered consumer to be kept")
+	}
+
+	nfo = &api.ConsumerInfo{Created: old, NumWaiting: 1}
+	if _, ok := consumerGCCandidate(nfo, time.Hour, nil, now); ok {
+		t.Fatalf("expected consumer with waiting pulls to be kept")
+	}
+
+	nfo = &api.ConsumerInfo{Created: old, PushBound: true, Config: api.ConsumerConfig{DeliverSubject: "x"}}
+	if _, ok := consumerGCCandidate(nfo, time.Hour, nil, now); ok {
+		t.Fatalf("expected bound push consumer to be kept")
+	}
+
+	nfo.PushBound = false
+	nfo.Name = "X"
+	nfo.Config.Durable = "X"
+	if _, ok := consumerGCCandidate(nfo, time.Hour, nil, now); ok {
+		t.Fatalf("expected durable consumer to be kept")
+	}
+	if _, ok := consumerGCCandidate(nfo, time.Hour, regexp.MustCompile("^Y$"), now); ok {
+		t.Fatalf("expected durable consumer not matching the pattern to be kept")
+	}
+	if _, ok := consumerGCCandidate(nfo, time.Hour, regexp.MustCompile("^X$"), now); !ok {
+		t.Fatalf("expected durable consumer to be a candidate")
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/AlecAivazis/survey/v2"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// consumerNamePartPattern is what every part of a templated consumer name has to match
+const consumerNamePartPattern = `[a-zA-Z0-9_]+`
+
+var consumerNamePartRe = regexp.MustCompile(`^` + consumerNamePartPattern + `$`)
+
+// consumerNameTemplate is the consumer naming template configured for the context in use, empty when none
+func consumerNameTemplate() (string, error) {
+	name := selectedContextName()
+	if name == "" {
+		return "", nil
+	}
+
+	cfg, err := iu.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.ConsumerNameTemplates[name], nil
+}
+
+// parseConsumerNameTemplate parses tmpl allowing only literal text and {{.Field}} placeholders
+func parseConsumerNameTemplate(tmpl string) (*template.Template, []parse.Node, error) {
+	t, err := template.New("name").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid consumer name template: %w", err)
+	}
+
+	if t.Tree == nil || t.Tree.Root == nil {
+		return nil, nil, fmt.Errorf("consumer name template is empty")
+	}
+
+	for _, node := range t.Tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+		case *parse.ActionNode:
+			if consumerNameTemplateField(n) == "" {
+				return nil, nil, fmt.Errorf("consumer name templates only support {{.Field}} placeholders, found %s", n)
+			}
+		default:
+			return nil, nil, fmt.Errorf("consumer name templates only support {{.Field}} placeholders, found %s", n)
+		}
+	}
+
+	return t, t.Tree.Root.Nodes, nil
+}
+
+func consumerNameTemplateField(n *parse.ActionNode) string {
+	if n.Pipe == nil || len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+		return ""
+	}
+
+	field, ok := n.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok || len(field.Ident) != 1 {
+		return ""
+	}
+
+	return field.Ident[0]
+}
+
+// consumerNameParts lists the unique placeholders in the template in the order they appear
+func consumerNameParts(tmpl string) ([]string, error) {
+	_, nodes, err := parseConsumerNameTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []string
+	seen := map[string]bool{}
+	for _, node := range nodes {
+		if n, ok := node.(*parse.ActionNode); ok {
+			field := consumerNameTemplateField(n)
+			if !seen[field] {
+				seen[field] = true
+				parts = append(parts, field)
+			}
+		}
+	}
+
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("consumer name template has no placeholders")
+	}
+
+	return parts, nil
+}
+
+// composeConsumerName renders the template using the supplied parts
+func composeConsumerName(tmpl string, parts map[string]string) (string, error) {
+	t, _, err := parseConsumerNameTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	for k, v := range parts {
+		if !consumerNamePartRe.MatchString(v) {
+			return "", fmt.Errorf("invalid value %q for %s, only letters, numbers and _ are allowed", v, k)
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = t.Execute(buf, parts)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// validateConsumerName ensures name follows the convention described by the template
+func validateConsumerName(tmpl string, name string) error {
+	_, nodes, err := parseConsumerNameTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+
+	pattern := strings.Builder{}
+	pattern.WriteString("^")
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			pattern.WriteString(regexp.QuoteMeta(string(n.Text)))
+		case *parse.ActionNode:
+			pattern.WriteString(consumerNamePartPattern)
+		}
+	}
+	pattern.WriteString("$")
+
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return err
+	}
+
+	if !re.MatchString(name) {
+		return fmt.Errorf("consumer name %q does not follow the naming convention %s configured for this context", name, tmpl)
+	}
+
+	return nil
+}
+
+// askTemplatedConsumerName prompts for every part of the template and composes the name
+func askTemplatedConsumerName(tmpl string) (string, error) {
+	parts, err := consumerNameParts(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	values := map[string]string{}
+	for _, part := range parts {
+		val := ""
+		err = iu.AskOne(&survey.Input{
+			Message: fmt.Sprintf("Consumer name %s", part),
+			Help:    fmt.Sprintf("Consumer names are composed using the template %s configured for this context", tmpl),
+		}, &val, survey.WithValidator(survey.Required), survey.WithValidator(func(ans any) error {
+			if !consumerNamePartRe.MatchString(ans.(string)) {
+				return fmt.Errorf("only letters, numbers and _ are allowed")
+			}
+			return nil
+		}))
+		if err != nil {
+			return "", err
+		}
+
+		values[part] = val
+	}
+
+	return composeConsumerName(tmpl, values)
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConsumerNameTemplates(t *testing.T) {
+	tmpl := "{{.Team}}-{{.App}}-{{.Purpose}}"
+
+	parts, err := consumerNameParts(tmpl)
+	if err != nil {
+		t.Fatalf("parts failed: %v", err)
+	}
+	if !cmp.Equal(parts, []string{"Team", "App", "Purpose"}) {
+		t.Fatalf("unexpected parts: %v", parts)
+	}
+
+	name, err := composeConsumerName(tmpl, map[string]string{"Team": "billing", "App": "invoicer", "Purpose": "retry"})
+	if err != nil {
+		t.Fatalf("compose failed: %v", err)
+	}
+	if name != "billing-invoicer-retry" {
+		t.Fatalf("unexpected name %q", name)
+	}
+
+	_, err = composeConsumerName(tmpl, map[string]string{"Team": "bill.ing", "App": "invoicer", "Purpose": "retry"})
+	if err == nil {
+		t.Fatalf("expected invalid part to fail")
+	}
+
+	if validateConsumerName(tmpl, "billing-invoicer-retry") != nil {
+		t.Fatalf("expected valid name to pass")
+	}
+	if validateConsumerName(tmpl, "billing-invoicer") == nil {
+		t.Fatalf("expected name with missing part to fail")
+	}
+
+	_, err = consumerNameParts("{{.Team | printf}}")
+	if err == nil {
+		t.Fatalf("expected pipelines to be rejected")
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// metadata stored on each consumer of a partitioned set used to group them in reports
+const (
+	partitionSetMetadata      = "partition_set"
+	partitionMetadata         = "partition"
+	partitionCountMetadata    = "partition_count"
+	partitionTemplateMetadata = "partition_filter_template"
+)
+
+type partitionedConsumer struct {
+	partition int
+	name      string
+	state     api.ConsumerInfo
+}
+
+func configurePartitionedConsumerCommand(cons *fisk.CmdClause, c *consumerCmd, addCreateFlags func(*fisk.CmdClause, bool)) {
+	addPart := cons.Command("add-partitioned", "Creates a set of Consumers each filtering one partition of a Stream").Action(c.addPartitionedAction)
+	addPart.HelpLong(`Creates one Consumer per partition named BASE-0, BASE-1 and so forth, each
+filtering the subject produced by the filter template for its partition.
+
+The template is a Go template with {{.Partition}} and {{.Partitions}} set:
+
+   nats consumer add-partitioned ORDERS PROCESSOR --partitions 10 --filter-template 'orders.p{{.Partition}}.>'
+
+All Consumers share the same configuration and are tagged with metadata so
+'nats consumer report --partitioned' can group them and find missing partitions.`)
+	addPart.Arg("stream", "Stream name").Required().StringVar(&c.stream)
+	addPart.Arg("base", "Base name for the Consumers").Required().StringVar(&c.consumer)
+	addPart.Flag("partitions", "The number of partitions to create Consumers for").Required().IntVar(&c.partitions)
+	addPart.Flag("filter-template", "Go template producing the filter subject for each partition").Required().StringVar(&c.partitionTemplate)
+	addPart.Flag("defaults", "Accept default values for all prompts").UnNegatableBoolVar(&c.acceptDefaults)
+	addPart.Flag("allow-loop", "Allow push delivery subjects that overlap with the Stream subjects").UnNegatableBoolVar(&c.allowLoop)
+	addCreateFlags(addPart, false)
+}
+
+// renderPartitionFilters renders the filter template for every partition ensuring each produce a unique subject
+func renderPartitionFilters(tmpl string, partitions int) ([]string, error) {
+	if partitions < 1 {
+		return nil, fmt.Errorf("at least 1 partition is required")
+	}
+
+	t, err := template.New("filter").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter template: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	filters := make([]string, partitions)
+
+	for i := 0; i < partitions; i++ {
+		var buf bytes.Buffer
+		err = t.Execute(&buf, map[string]int{"Partition": i, "Partitions": partitions})
+		if err != nil {
+			return nil, fmt.Errorf("could not render filter for partition %d: %w", i, err)
+		}
+
+		filter := strings.TrimSpace(buf.String())
+		if filter == "" {
+			return nil, fmt.Errorf("filter template produced an empty subject for partition %d", i)
+		}
+		if seen[filter] {
+			return nil, fmt.Errorf("filter template produced duplicate subject %q, use {{.Partition}} in the template", filter)
+		}
+
+		seen[filter] = true
+		filters[i] = filter
+	}
+
+	return filters, nil
+}
+
+func partitionConsumerName(base string, partition int) string {
+	return fmt.Sprintf("%s-%d", base, partition)
+}
+
+func (c *consumerCmd) addPartitionedAction(_ *fisk.ParseContext) error {
+	if c.ephemeral {
+		return fmt.Errorf("partitioned Consumers must be durable")
+	}
+
+	if len(c.filterSubjects) > 0 {
+		return fmt.Errorf("--filter can not be used with partitioned Consumers, use --filter-template")
+	}
+
+	filters, err := renderPartitionFilters(c.partitionTemplate, c.partitions)
+	if err != nil {
+		return err
+	}
+
+	base := c.consumer
+	c.filterSubjects = []string{filters[0]}
+
+	cfg, err := c.prepareConfig()
+	if err != nil {
+		return err
+	}
+
+	err = checkPullSettings(cfg)
+	if err != nil {
+		return err
+	}
+
+	c.connectAndSetup(true, false)
+
+	err = c.checkCreateConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	table := iu.NewTableWriter(opts(), "Partitioned Consumer set %s on %s", base, c.stream)
+	table.AddHeaders("Partition", "Consumer", "Filter", "Result")
+
+	configs := make([]api.ConsumerConfig, len(filters))
+	for i, filter := range filters {
+		pcfg := *cfg
+		pcfg.Name = ""
+		pcfg.Durable = partitionConsumerName(base, i)
+		pcfg.FilterSubject = filter
+		pcfg.FilterSubjects = nil
+		pcfg.Metadata = make(map[string]string)
+		for k, v := range cfg.Metadata {
+			pcfg.Metadata[k] = v
+		}
+		pcfg.Metadata[partitionSetMetadata] = base
+		pcfg.Metadata[partitionMetadata] = strconv.Itoa(i)
+		pcfg.Metadata[partitionCountMetadata] = strconv.Itoa(c.partitions)
+		pcfg.Metadata[partitionTemplateMetadata] = c.partitionTemplate
+
+		// every partition is validated before any are created so a set is not left partially created
+		err = validateRequest(pcfg)
+		if err != nil {
+			return fmt.Errorf("partition %d: %w", i, err)
+		}
+
+		configs[i] = pcfg
+	}
+
+	failed := 0
+	for i, pcfg := range configs {
+		result := "Created"
+		_, err = c.mgr.NewConsumerFromDefault(c.stream, pcfg)
+		if err != nil {
+			result = err.Error()
+			failed++
+		}
+
+		table.AddRow(i, pcfg.Durable, pcfg.FilterSubject, result)
+	}
+
+	fmt.Println(table.Render())
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d partitioned Consumers could not be created", failed, c.partitions)
+	}
+
+	return nil
+}
+
+func (c *consumerCmd) partitionReportAction() error {
+	c.connectAndSetup(true, false)
+
+	out := newPager()
+	defer out.Close()
+
+	stream, err := c.mgr.LoadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	sets := make(map[string][]*partitionedConsumer)
+	counts := make(map[string]int)
+	templates := make(map[string]string)
+
+	missing, err := stream.EachConsumer(func(cons *jsm.Consumer) {
+		cs, err := cons.LatestState()
+		if err != nil {
+			log.Printf("could not obtain consumer %s state: %s", cons.Name(), err)
+			return
+		}
+
+		set, ok := cs.Config.Metadata[partitionSetMetadata]
+		if !ok {
+			return
+		}
+
+		partition, err := strconv.Atoi(cs.Config.Metadata[partitionMetadata])
+		if err != nil {
+			log.Printf("Consumer %s has an invalid partition number %q", cons.Name(), cs.Config.Metadata[partitionMetadata])
+			return
+		}
+
+		count, _ := strconv.Atoi(cs.Config.Metadata[partitionCountMetadata])
+		counts[set] = max(counts[set], count)
+		if tmpl := cs.Config.Metadata[partitionTemplateMetadata]; tmpl != "" {
+			templates[set] = tmpl
+		}
+
+		sets[set] = append(sets[set], &partitionedConsumer{partition: partition, name: cons.Name(), state: cs})
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(sets) == 0 {
+		fmt.Fprintf(out, "No partitioned Consumers found on Stream %s\n", c.stream)
+		return nil
+	}
+
+	var names []string
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c.renderPartitionedSet(out, name, sets[name], counts[name], templates[name])
+	}
+
+	if len(missing) > 0 {
+		c.renderMissing(out, missing)
+	}
+
+	return nil
+}
+
+func (c *consumerCmd) renderPartitionedSet(w io.Writer, name string, consumers []*partitionedConsumer, count int, tmpl string) {
+	sort.Slice(consumers, func(i, j int) bool {
+		return consumers[i].partition < consumers[j].partition
+	})
+
+	var filters []string
+	if tmpl != "" {
+		filters, _ = renderPartitionFilters(tmpl, count)
+	}
+
+	present := make(map[int]bool)
+	problems := 0
+
+	table := iu.NewTableWriter(opts(), "Partitioned Consumer set %s with %d partitions", name, count)
+	table.AddHeaders("Partition", "Consumer", "Filter", "Unprocessed", "Ack Pending", "Redelivered", "Ack Floor", "Status")
+
+	for _, pc := range consumers {
+		cs := pc.state
+		present[pc.partition] = true
+
+		filter := cs.Config.FilterSubject
+		if len(cs.Config.FilterSubjects) > 0 {
+			filter = strings.Join(cs.Config.FilterSubjects, ", ")
+		}
+
+		status := "OK"
+		switch {
+		case pc.partition >= count:
+			status = "outside partition count"
+			problems++
+		case len(filters) == count && filter != filters[pc.partition]:
+			status = fmt.Sprintf("expected filter %s", filters[pc.partition])
+			problems++
+		}
+
+		if c.raw {
+			table.AddRow(pc.partition, pc.name, filter, cs.NumPending, cs.NumAckPending, cs.NumRedelivered, cs.AckFloor.Stream, status)
+		} else {
+			table.AddRow(pc.partition, pc.name, filter, f(cs.NumPending), f(cs.NumAckPending), f(cs.NumRedelivered), f(cs.AckFloor.Stream), status)
+		}
+	}
+
+	for i := 0; i < count; i++ {
+		if present[i] {
+			continue
+		}
+
+		filter := ""
+		if len(filters) == count {
+			filter = filters[i]
+		}
+
+		table.AddRow(i, partitionConsumerName(name, i), filter, "", "", "", "", "missing")
+		problems++
+	}
+
+	fmt.Fprintln(w, table.Render())
+
+	if problems > 0 {
+		fmt.Fprintf(w, "%d problems found in partitioned Consumer set %s\n\n", problems, name)
+	}
+}
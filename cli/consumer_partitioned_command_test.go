@@ -0,0 +1,45 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRenderPartitionFilters(t *testing.T) {
+	filters, err := renderPartitionFilters("orders.p{{.Partition}}.>", 3)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !cmp.Equal(filters, []string{"orders.p0.>", "orders.p1.>", "orders.p2.>"}) {
+		t.Fatalf("unexpected filters: %#v", filters)
+	}
+
+	_, err = renderPartitionFilters("orders.>", 3)
+	if err == nil {
+		t.Fatalf("expected an error for duplicate subjects")
+	}
+
+	_, err = renderPartitionFilters("orders.{{.Partition}}", 0)
+	if err == nil {
+		t.Fatalf("expected an error for 0 partitions")
+	}
+
+	_, err = renderPartitionFilters("orders.{{.Unknown}}", 2)
+	if err == nil {
+		t.Fatalf("expected an error for unknown template keys")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go/api"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+func configureConsumerPeerRemoveCommand(conCluster *fisk.CmdClause, c *consumerCmd) {
+	peerRemove := conCluster.Command("peer-remove", "Removes a peer from the Consumer cluster").Alias("pr").Action(c.removePeerAction)
+	peerRemove.HelpLong(`Moves the replicas of a Consumer off a peer, for example before decommissioning
+a server, and waits for a replacement peer to be assigned and become current.
+
+The server places Consumer replicas on the peers of their Stream, so the peer
+is removed from the Stream which moves the replicas of all its Consumers.
+
+   nats consumer cluster peer-remove ORDERS NEW n3-c1`)
+	peerRemove.Arg("stream", "Stream to act on").HintAction(completeStreamNames).StringVar(&c.stream)
+	peerRemove.Arg("consumer", "Consumer to act on").HintAction(completeConsumerNames(&c.stream)).StringVar(&c.consumer)
+	peerRemove.Arg("peer", "The name of the server to remove").StringVar(&c.peerName)
+	peerRemove.Flag("replica-wait", "How long to wait for a replacement peer to become current, 0 to not wait").Default("10m").PlaceHolder("DURATION").DurationVar(&c.replicaWait)
+	peerRemove.Flag("force", "Remove the peer without prompting").Short('f').UnNegatableBoolVar(&c.force)
+}
+
+// clusterPeerNames lists the leader and replicas of a RAFT group
+func clusterPeerNames(ci *api.ClusterInfo) []string {
+	if ci == nil {
+		return nil
+	}
+
+	var peers []string
+	if ci.Leader != "" {
+		peers = append(peers, ci.Leader)
+	}
+	for _, r := range ci.Replicas {
+		peers = append(peers, r.Name)
+	}
+
+	return peers
+}
+
+func (c *consumerCmd) removePeerAction(_ *fisk.ParseContext) error {
+	c.connectAndSetup(true, true)
+
+	consumer, err := c.mgr.LoadConsumer(c.stream, c.consumer)
+	if err != nil {
+		return err
+	}
+
+	info, err := consumer.LatestState()
+	if err != nil {
+		return err
+	}
+
+	if info.Cluster == nil {
+		return fmt.Errorf("consumer %q > %q is not clustered", consumer.StreamName(), consumer.Name())
+	}
+
+	peers := clusterPeerNames(info.Cluster)
+	if len(peers) == 1 && !c.force {
+		return fmt.Errorf("removing the only peer on a consumer will result in the loss of its state, use --force to force")
+	}
+
+	if c.peerName == "" {
+		err = iu.AskOne(&survey.Select{
+			Message: "Select a Peer",
+			Options: peers,
+		}, &c.peerName)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !slices.Contains(peers, c.peerName) {
+		return fmt.Errorf("peer %q is not part of consumer %q > %q, peers are %s", c.peerName, consumer.StreamName(), consumer.Name(), f(peers))
+	}
+
+	stream, err := c.mgr.LoadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	if !c.force {
+		fmt.Printf("Consumer replicas follow their Stream, removing %q from Stream %s moves the replicas of all its Consumers\n\n", c.peerName, c.stream)
+
+		ok, err := askConfirmation(fmt.Sprintf("Really remove peer %s from Stream %s", c.peerName, c.stream), false)
+		fisk.FatalIfError(err, "could not obtain confirmation")
+
+		if !ok {
+			return nil
+		}
+	}
+
+	log.Printf("Removing peer %q", c.peerName)
+
+	err = stream.RemoveRAFTPeer(c.peerName)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Requested removal of peer %q", c.peerName)
+
+	if c.replicaWait > 0 {
+		err = waitForReplicas(fmt.Sprintf("Consumer %s > %s", c.stream, consumer.Name()), len(peers), "", c.replicaWait, func() (*api.ClusterInfo, error) {
+			state, err := consumer.LatestState()
+			if err != nil {
+				return nil, err
+			}
+
+			if slices.Contains(clusterPeerNames(state.Cluster), c.peerName) {
+				return nil, fmt.Errorf("peer %q is still assigned", c.peerName)
+			}
+
+			return state.Cluster, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	c.showConsumer(os.Stdout, consumer)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestClusterPeerNames(t *testing.T) {
+	if peers := clusterPeerNames(nil); peers != nil {
+		t.Fatalf("expected no peers, got %v", peers)
+	}
+
+	ci := &api.ClusterInfo{Leader: "n1", Replicas: []*api.PeerInfo{{Name: "n2"}, {Name: "n3"}}}
+	if peers := clusterPeerNames(ci); !cmp.Equal(peers, []string{"n1", "n2", "n3"}) {
+		t.Fatalf("invalid peers %v", peers)
+	}
+
+	ci.Leader = ""
+	if peers := clusterPeerNames(ci); !cmp.Equal(peers, []string{"n2", "n3"}) {
+		t.Fatalf("invalid leaderless peers %v", peers)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+// consumerReportDelta is the change in a consumer state between two reports
+type consumerReportDelta struct {
+	// drainRate is the rate at which the ack floor advanced, an approximation of the processing rate
+	drainRate float64
+	// redeliveredChange is the change in messages awaiting redelivery, negative when redeliveries complete
+	redeliveredChange int64
+	// pendingChange is the change in unprocessed messages, negative when the backlog is draining
+	pendingChange int64
+}
+
+// consumerReportDeltas calculates the change between prev and cur which were taken since apart.
+//
+// The drain rate is derived from the movement of the stream ack floor, it under reports while a gap
+// holds the floor back and over reports when a gap closes, and counts sequences not matched by the
+// filter, but unlike the delivered counters it is not inflated by redeliveries.
+func consumerReportDeltas(prev *api.ConsumerInfo, cur *api.ConsumerInfo, since time.Duration) consumerReportDelta {
+	return consumerReportDelta{
+		drainRate:         max(float64(cur.AckFloor.Stream)-float64(prev.AckFloor.Stream), 0) / since.Seconds(),
+		redeliveredChange: int64(cur.NumRedelivered) - int64(prev.NumRedelivered),
+		pendingChange:     int64(cur.NumPending) - int64(prev.NumPending),
+	}
+}
+
+// consumerReportRates are the rate columns of the report for cur, empty until a previous state is known
+func (c *consumerCmd) consumerReportRates(prev *api.ConsumerInfo, cur *api.ConsumerInfo, since time.Duration) []any {
+	if prev == nil || since <= 0 {
+		return []any{"", "", ""}
+	}
+
+	d := consumerReportDeltas(prev, cur, since)
+
+	if c.raw {
+		return []any{d.drainRate, d.redeliveredChange, d.pendingChange}
+	}
+
+	return []any{fmt.Sprintf("%.1f", d.drainRate), signedChange(d.redeliveredChange), signedChange(d.pendingChange)}
+}
+
+// signedChange formats a change with an explicit sign when it is an increase
+func signedChange(change int64) string {
+	if change > 0 {
+		return "+" + f(change)
+	}
+
+	return f(change)
+}
+
+// watchReport shows the report every c.reportInterval with the rates of change since the previous report
+func (c *consumerCmd) watchReport(where *reportFilter) error {
+	previous, err := c.consumerReport(os.Stdout, where, nil, 0)
+	if err != nil {
+		return err
+	}
+	lastTs := time.Now()
+
+	ticker := time.NewTicker(c.reportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			states, err := c.consumerReport(os.Stdout, where, previous, now.Sub(lastTs))
+			if err != nil {
+				log.Printf("Could not produce the Consumer report: %v", err)
+				continue
+			}
+
+			previous = states
+			lastTs = now
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestConsumerReportDeltas(t *testing.T) {
+	prev := &api.ConsumerInfo{
+		Delivered:      api.SequenceInfo{Consumer: 100},
+		AckFloor:       api.SequenceInfo{Stream: 1000},
+		NumAckPending:  10,
+		NumRedelivered: 2,
+		NumPending:     500,
+	}
+	cur := &api.ConsumerInfo{
+		Delivered:      api.SequenceInfo{Consumer: 200},
+		AckFloor:       api.SequenceInfo{Stream: 1090},
+		NumAckPending:  20,
+		NumRedelivered: 1,
+		NumPending:     420,
+	}
+
+	d := consumerReportDeltas(prev, cur, 10*time.Second)
+	if d.drainRate != 9 {
+		t.Fatalf("expected drain rate 9 got %v", d.drainRate)
+	}
+	if d.redeliveredChange != -1 {
+		t.Fatalf("expected redelivered change -1 got %v", d.redeliveredChange)
+	}
+	if d.pendingChange != -80 {
+		t.Fatalf("expected pending change -80 got %v", d.pendingChange)
+	}
+
+	// redeliveries do not move the ack floor
+	d = consumerReportDeltas(cur, &api.ConsumerInfo{Delivered: api.SequenceInfo{Consumer: 300}, AckFloor: api.SequenceInfo{Stream: 1090}, NumAckPending: 20, NumRedelivered: 11, NumPending: 520}, 5*time.Second)
+	if d.drainRate != 0 || d.redeliveredChange != 10 || d.pendingChange != 100 {
+		t.Fatalf("unexpected deltas: %+v", d)
+	}
+}
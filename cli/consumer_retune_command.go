@@ -0,0 +1,320 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// consumerRetune is the planned update for a single consumer
+type consumerRetune struct {
+	name    string
+	live    api.ConsumerConfig
+	desired *api.ConsumerConfig
+	changes []consumerSettingChange
+	err     error
+}
+
+type consumerSettingChange struct {
+	setting string
+	current string
+	desired string
+}
+
+func configureConsumerRetuneCommand(cons *fisk.CmdClause, c *consumerCmd, addCreateFlags func(*fisk.CmdClause, bool)) {
+	retune := cons.Command("retune", "Applies configuration changes to many Consumers on a Stream").Action(c.retuneAction)
+	retune.HelpLong(`Applies the same changes to every durable Consumer on a Stream matching --select,
+changes are given using the same flags as 'nats consumer edit' or as a partial
+JSON or YAML configuration in a patch file:
+
+   nats consumer retune ORDERS --select '^processor' --wait 1m --max-deliver 10
+   nats consumer retune ORDERS --patch slo.yaml
+
+A combined plan is shown before any Consumers are updated.`)
+	retune.Arg("stream", "Stream name").StringVar(&c.stream)
+	retune.Flag("select", "Only retune Consumers with names matching a regular expression").PlaceHolder("REGEX").RegexpVar(&c.retuneSelect)
+	retune.Flag("patch", "JSON or YAML file holding the configuration settings to change").PlaceHolder("FILE").ExistingFileVar(&c.retunePatch)
+	retune.Flag("set", "Sets a value used when rendering the patch file as a template").PlaceHolder("KEY=VALUE").StringsVar(&c.configValues)
+	retune.Flag("values", "JSON or YAML file holding values used when rendering the patch file as a template").PlaceHolder("FILE").ExistingFileVar(&c.configValuesFile)
+	retune.Flag("workers", "Number of Consumers to update concurrently").Default("10").IntVar(&c.reportWorkers)
+	retune.Flag("dry-run", "Only shows the plan, do not update any Consumers").UnNegatableBoolVar(&c.dryRun)
+	retune.Flag("force", "Update without prompting").Short('f').UnNegatableBoolVar(&c.force)
+	addCreateFlags(retune, true)
+}
+
+func (c *consumerCmd) retuneAction(_ *fisk.ParseContext) error {
+	c.connectAndSetup(true, false)
+
+	stream, err := c.mgr.LoadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	consumers, missing, err := c.loadConsumersConcurrently(stream)
+	if err != nil {
+		return err
+	}
+
+	var patch []byte
+	if c.retunePatch != "" {
+		patch, err = readConfigFile(c.retunePatch, c.configValuesFile, c.configValues, false)
+		if err != nil {
+			return fmt.Errorf("could not load patch file %s: %w", c.retunePatch, err)
+		}
+	}
+
+	var plan []*consumerRetune
+	for _, cons := range consumers {
+		if !cons.IsDurable() {
+			continue
+		}
+		if c.retuneSelect != nil && !c.retuneSelect.MatchString(cons.Name()) {
+			continue
+		}
+
+		plan = append(plan, c.planRetune(cons, patch))
+	}
+
+	if len(missing) > 0 {
+		c.renderMissing(os.Stdout, missing)
+	}
+
+	changed := 0
+	var first *consumerRetune
+	for _, p := range plan {
+		if p.err == nil && len(p.changes) > 0 {
+			changed++
+			if first == nil {
+				first = p
+			}
+		}
+	}
+
+	c.renderRetunePlan(plan)
+
+	if changed == 0 {
+		fmt.Println("No Consumers require changes")
+		return nil
+	}
+
+	if c.dryRun {
+		return nil
+	}
+
+	// all consumers receive the same changes so checking one avoids repeating warnings
+	err = c.checkConfigLevel(first.desired)
+	if err != nil {
+		return err
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really update %d Consumers on Stream %s", changed, c.stream), false)
+		fisk.FatalIfError(err, "could not obtain confirmation")
+
+		if !ok {
+			return nil
+		}
+	}
+
+	failed := c.applyRetune(plan)
+
+	fmt.Printf("Updated %d of %d Consumers\n", changed-len(failed), changed)
+
+	if len(failed) > 0 {
+		fmt.Println()
+		table := iu.NewTableWriter(opts(), "Failed updates")
+		table.AddHeaders("Consumer", "Error")
+		for _, p := range failed {
+			table.AddRow(p.name, p.err)
+		}
+		fmt.Println(table.Render())
+
+		return fmt.Errorf("%d Consumers could not be updated", len(failed))
+	}
+
+	return nil
+}
+
+// planRetune calculates the desired configuration for a consumer from the flags and patch
+func (c *consumerCmd) planRetune(cons *jsm.Consumer, patch []byte) *consumerRetune {
+	p := &consumerRetune{name: cons.Name(), live: cons.Configuration()}
+	p.live.Metadata = iu.RemoveReservedMetadata(p.live.Metadata)
+
+	// lazy deep copy
+	lj, err := json.Marshal(p.live)
+	if err != nil {
+		p.err = err
+		return p
+	}
+
+	var base api.ConsumerConfig
+	err = json.Unmarshal(lj, &base)
+	if err != nil {
+		p.err = err
+		return p
+	}
+
+	if len(patch) > 0 {
+		err = json.Unmarshal(patch, &base)
+		if err != nil {
+			p.err = fmt.Errorf("invalid patch: %w", err)
+			return p
+		}
+	}
+
+	p.desired, err = c.copyAndEditConsumer(base)
+	if err != nil {
+		p.err = err
+		return p
+	}
+	p.desired.Metadata = iu.RemoveReservedMetadata(p.desired.Metadata)
+
+	if len(p.desired.BackOff) > 0 && p.desired.AckWait != p.live.AckWait {
+		p.err = fmt.Errorf("consumers with backoff policies do not support editing Ack Wait")
+		return p
+	}
+
+	rejected := immutableConsumerChanges(p.live, *p.desired)
+	if len(rejected) > 0 {
+		p.err = fmt.Errorf("%s", rejected[0])
+		return p
+	}
+
+	p.changes = consumerSettingChanges(p.live, *p.desired)
+
+	return p
+}
+
+// consumerSettingChanges lists the top level configuration settings that differ between live and desired
+func consumerSettingChanges(live api.ConsumerConfig, desired api.ConsumerConfig) []consumerSettingChange {
+	var changes []consumerSettingChange
+
+	lv := reflect.ValueOf(live)
+	dv := reflect.ValueOf(desired)
+	for i := 0; i < lv.NumField(); i++ {
+		if reflect.DeepEqual(lv.Field(i).Interface(), dv.Field(i).Interface()) {
+			continue
+		}
+
+		// nil and empty lists or maps are considered equal
+		current := consumerSettingString(lv.Field(i))
+		wanted := consumerSettingString(dv.Field(i))
+		if current == wanted {
+			continue
+		}
+
+		changes = append(changes, consumerSettingChange{
+			setting: lv.Type().Field(i).Name,
+			current: current,
+			desired: wanted,
+		})
+	}
+
+	return changes
+}
+
+func consumerSettingString(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "unset"
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Map || v.Kind() == reflect.Slice {
+		if v.Len() == 0 {
+			return "unset"
+		}
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+func (c *consumerCmd) renderRetunePlan(plan []*consumerRetune) {
+	table := iu.NewTableWriter(opts(), "Retune plan for %d Consumers on Stream %s", len(plan), c.stream)
+	table.AddHeaders("Consumer", "Setting", "Current", "New")
+
+	for _, p := range plan {
+		switch {
+		case p.err != nil:
+			table.AddRow(p.name, "", "", fmt.Sprintf("error: %v", p.err))
+		case len(p.changes) == 0:
+			table.AddRow(p.name, "", "", "unchanged")
+		default:
+			for i, change := range p.changes {
+				name := p.name
+				if i > 0 {
+					name = ""
+				}
+				table.AddRow(name, change.setting, change.current, change.desired)
+			}
+		}
+	}
+
+	fmt.Println(table.Render())
+	fmt.Println()
+}
+
+// applyRetune updates all changed consumers concurrently returning those that failed
+func (c *consumerCmd) applyRetune(plan []*consumerRetune) []*consumerRetune {
+	var (
+		failed []*consumerRetune
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+	)
+
+	work := make(chan *consumerRetune, len(plan))
+	for _, p := range plan {
+		if p.err == nil && len(p.changes) > 0 {
+			work <- p
+		}
+	}
+	close(work)
+
+	for i := 0; i < min(max(c.reportWorkers, 1), len(plan)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for p := range work {
+				_, err := c.mgr.NewConsumerFromDefault(c.stream, *p.desired)
+
+				if err != nil {
+					mu.Lock()
+					p.err = err
+					failed = append(failed, p)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].name < failed[j].name
+	})
+
+	return failed
+}
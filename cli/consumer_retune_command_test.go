@@ -0,0 +1,41 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestConsumerSettingChanges(t *testing.T) {
+	live := api.ConsumerConfig{Durable: "C1", AckWait: 30 * time.Second, MaxDeliver: 5}
+	desired := live
+	desired.AckWait = time.Minute
+	desired.Metadata = map[string]string{}
+
+	changes := consumerSettingChanges(live, desired)
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change got %+v", changes)
+	}
+
+	if changes[0].setting != "AckWait" || changes[0].current != "30s" || changes[0].desired != "1m0s" {
+		t.Fatalf("unexpected change %+v", changes[0])
+	}
+
+	if len(consumerSettingChanges(live, live)) != 0 {
+		t.Fatalf("expected no changes")
+	}
+}
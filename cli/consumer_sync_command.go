@@ -0,0 +1,270 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+)
+
+func configureConsumerSyncCommand(cons *fisk.CmdClause, c *consumerCmd) {
+	sync := cons.Command("sync-position", "Moves a Consumer on a disaster recovery Stream to the position of a Consumer on its origin Stream").Action(c.syncPositionAction)
+	sync.HelpLong(`Reads the Ack Floor of a Consumer on the origin Stream and recreates the
+equivalent Consumer on a Stream mirroring or sourcing it, in another cluster
+or account reachable using a saved context, so that it continues after the
+last message acknowledged on the origin.
+
+   nats consumer sync-position ORDERS PROCESSOR --dr-context dr
+   nats consumer sync-position ORDERS PROCESSOR --dr-context dr --dr-stream ORDERS_DR
+
+Mirrors keep the sequences of the origin Stream, for Streams sourcing the origin
+the position is found using the Nats-Stream-Source header of sourced messages.
+
+When the Consumer does not exist on the recovery Stream it is created using the
+configuration of the origin Consumer.`)
+	sync.Arg("stream", "Origin Stream name").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	sync.Arg("consumer", "Origin Consumer name").HintAction(completeConsumerNames(&c.stream)).Required().StringVar(&c.consumer)
+	sync.Flag("dr-context", "Saved context used to connect to the disaster recovery cluster").Required().PlaceHolder("NAME").StringVar(&c.syncContext)
+	sync.Flag("dr-stream", "Stream holding the recovery copy, defaults to the origin Stream name").PlaceHolder("STREAM").StringVar(&c.syncStream)
+	sync.Flag("dr-consumer", "Consumer to position on the recovery Stream, defaults to the origin Consumer name").PlaceHolder("CONSUMER").StringVar(&c.syncConsumer)
+	sync.Flag("dry-run", "Only shows the position that would be set").UnNegatableBoolVar(&c.dryRun)
+	sync.Flag("force", "Recreate the Consumer without prompting").Short('f').UnNegatableBoolVar(&c.force)
+}
+
+func (c *consumerCmd) syncPositionAction(_ *fisk.ParseContext) error {
+	if c.syncStream == "" {
+		c.syncStream = c.stream
+	}
+	if c.syncConsumer == "" {
+		c.syncConsumer = c.consumer
+	}
+
+	c.connectAndSetup(false, false)
+
+	origin, err := c.mgr.LoadConsumer(c.stream, c.consumer)
+	if err != nil {
+		return err
+	}
+	if !origin.IsDurable() {
+		return fmt.Errorf("only durable Consumers can be synchronized")
+	}
+
+	state, err := origin.LatestState()
+	if err != nil {
+		return err
+	}
+	floor := state.AckFloor.Stream
+
+	dnc, dmgr, _, err := connectMigrateContext(c.syncContext)
+	if err != nil {
+		return fmt.Errorf("could not connect to context %s: %w", c.syncContext, err)
+	}
+	defer dnc.Close()
+
+	dr, err := dmgr.LoadStream(c.syncStream)
+	if err != nil {
+		return fmt.Errorf("could not load recovery Stream %s: %w", c.syncStream, err)
+	}
+
+	start, err := c.syncStartSequence(dr, floor)
+	if err != nil {
+		return err
+	}
+
+	known, err := dmgr.IsKnownConsumer(c.syncStream, c.syncConsumer)
+	if err != nil {
+		return err
+	}
+
+	var existing *jsm.Consumer
+	var cfg api.ConsumerConfig
+	if known {
+		existing, err = dmgr.LoadConsumer(c.syncStream, c.syncConsumer)
+		if err != nil {
+			return err
+		}
+		cfg = existing.Configuration()
+	} else {
+		cfg = origin.Configuration()
+		cfg.Durable = c.syncConsumer
+		if cfg.Name != "" {
+			cfg.Name = c.syncConsumer
+		}
+	}
+
+	cfg.DeliverPolicy = api.DeliverByStartSequence
+	cfg.OptStartSeq = start
+	cfg.OptStartTime = nil
+
+	fmt.Printf("Origin %s > %s has an Ack Floor of stream sequence %d\n", c.stream, c.consumer, floor)
+	fmt.Printf("Recovery %s > %s will start at stream sequence %d\n", c.syncStream, c.syncConsumer, start)
+
+	if c.dryRun {
+		return nil
+	}
+
+	if !c.force {
+		action := "create"
+		if existing != nil {
+			action = "recreate"
+		}
+
+		ok, err := askConfirmation(fmt.Sprintf("Really %s Consumer %s > %s in context %s starting at sequence %d", action, c.syncStream, c.syncConsumer, c.syncContext, start), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	if existing != nil {
+		err = existing.Delete()
+		if err != nil {
+			return fmt.Errorf("could not remove Consumer: %w", err)
+		}
+	}
+
+	_, err = dmgr.NewConsumerFromDefault(c.syncStream, cfg)
+	if err != nil {
+		if existing != nil {
+			orig, _ := json.Marshal(existing.Configuration())
+			return fmt.Errorf("could not recreate Consumer, the original configuration was %s: %w", orig, err)
+		}
+		return fmt.Errorf("could not create Consumer: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("Consumer %s > %s was positioned at stream sequence %d\n", c.syncStream, c.syncConsumer, start)
+
+	return nil
+}
+
+// syncStartSequence translates the origin ack floor into the first sequence of the recovery stream that should be delivered
+func (c *consumerCmd) syncStartSequence(dr *jsm.Stream, floor uint64) (uint64, error) {
+	if dr.IsMirror() {
+		if dr.Mirror().Name != c.stream {
+			return 0, fmt.Errorf("stream %s mirrors %s not %s", dr.Name(), dr.Mirror().Name, c.stream)
+		}
+
+		return floor + 1, nil
+	}
+
+	sourced := false
+	for _, source := range dr.Sources() {
+		if source.Name == c.stream {
+			sourced = true
+			break
+		}
+	}
+	if !sourced {
+		return 0, fmt.Errorf("stream %s does not mirror or source %s", dr.Name(), c.stream)
+	}
+
+	state, err := dr.State()
+	if err != nil {
+		return 0, err
+	}
+
+	if state.Msgs == 0 {
+		return state.LastSeq + 1, nil
+	}
+
+	return findSourcedStart(state.FirstSeq, state.LastSeq, floor, func(seq uint64) (uint64, bool, error) {
+		msg, err := dr.ReadMessage(seq)
+		if err != nil {
+			if jsm.IsNatsError(err, 10037) {
+				// deleted message
+				return 0, false, nil
+			}
+			return 0, false, err
+		}
+
+		if len(msg.Header) == 0 {
+			return 0, false, nil
+		}
+
+		hdrs, err := decodeHeadersMsg(msg.Header)
+		if err != nil {
+			return 0, false, nil
+		}
+
+		name, oseq, ok := parseStreamSourceHeader(hdrs.Get("Nats-Stream-Source"))
+		if !ok || name != c.stream {
+			return 0, false, nil
+		}
+
+		return oseq, true, nil
+	})
+}
+
+// parseStreamSourceHeader extracts the origin stream name and sequence from a Nats-Stream-Source header
+func parseStreamSourceHeader(hdr string) (string, uint64, bool) {
+	fields := strings.Fields(hdr)
+	if len(fields) < 2 {
+		return "", 0, false
+	}
+
+	// external sources are identified as name:hash
+	name, _, _ := strings.Cut(fields[0], ":")
+
+	seq, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return name, seq, true
+}
+
+// findSourcedStart finds the sequence following the last message between first and last sourced from the origin at or before floor,
+// origin reports the origin sequence for a message and false for messages from other sources or deleted messages.
+// Messages from a source are stored in origin order so a binary search is used
+func findSourcedStart(first uint64, last uint64, floor uint64, origin func(seq uint64) (uint64, bool, error)) (uint64, error) {
+	lo, hi := first, last+1
+
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		// find the next message from the origin at or after mid
+		pos := mid
+		var oseq uint64
+		found := false
+		for ; pos < hi; pos++ {
+			s, ok, err := origin(pos)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				oseq = s
+				found = true
+				break
+			}
+		}
+
+		switch {
+		case !found || oseq > floor:
+			hi = mid
+		default:
+			lo = pos + 1
+		}
+	}
+
+	return lo, nil
+}
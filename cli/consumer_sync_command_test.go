@@ -0,0 +1,48 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+)
+
+func TestFindSourcedStart(t *testing.T) {
+	// recovery stream sequences 1..10, 0 marks messages from other sources
+	sourced := []uint64{0, 5, 0, 6, 7, 0, 0, 8, 9, 0, 10}
+	origin := func(seq uint64) (uint64, bool, error) {
+		if sourced[seq] == 0 {
+			return 0, false, nil
+		}
+		return sourced[seq], true, nil
+	}
+
+	cases := map[uint64]uint64{0: 1, 4: 1, 5: 2, 6: 4, 7: 5, 8: 8, 9: 9, 10: 11, 20: 11}
+	for floor, expected := range cases {
+		start, err := findSourcedStart(1, 10, floor, origin)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if start != expected {
+			t.Fatalf("floor %d: expected %d got %d", floor, expected, start)
+		}
+	}
+
+	name, seq, ok := parseStreamSourceHeader("ORDERS:a1b2c3 1234 > >")
+	if !ok || name != "ORDERS" || seq != 1234 {
+		t.Fatalf("invalid parse: %s %d %v", name, seq, ok)
+	}
+	if _, _, ok = parseStreamSourceHeader("ORDERS"); ok {
+		t.Fatalf("expected invalid header to fail")
+	}
+}
@@ -44,6 +44,14 @@ type ctxCommand struct {
 	nsc              string
 	force            bool
 	validateErrors   int
+	nameTemplate     string
+	nameTemplateSet  bool
+	readOnly         bool
+	readOnlySet      bool
+	validation       string
+	validationSet    bool
+	credHelper       string
+	credHelperSet    bool
 }
 
 func configureCtxCommand(app commandHost) {
@@ -57,6 +65,10 @@ func configureCtxCommand(app commandHost) {
 	save.Flag("description", "Set a friendly description for this context").StringVar(&c.description)
 	save.Flag("select", "Select the saved context as the default one").UnNegatableBoolVar(&c.activate)
 	save.Flag("nsc", "URL to a nsc user, eg. nsc://<operator>/<account>/<user>").StringVar(&c.nsc)
+	save.Flag("consumer-name-template", "Template Consumer names must follow like {{.Team}}-{{.App}}, empty to remove").PlaceHolder("TEMPLATE").IsSetByUser(&c.nameTemplateSet).StringVar(&c.nameTemplate)
+	save.Flag("read-only", "Refuse to run commands that modify JetStream assets while using this context").IsSetByUser(&c.readOnlySet).BoolVar(&c.readOnly)
+	save.Flag("validation", "JetStream API schema validation level while using this context (off, responses, strict)").PlaceHolder("LEVEL").IsSetByUser(&c.validationSet).EnumVar(&c.validation, validationOff, validationResponses, validationStrict)
+	save.Flag("credential-helper", "Command whose output supplies credentials or a token when connecting, empty to remove").PlaceHolder("COMMAND").IsSetByUser(&c.credHelperSet).StringVar(&c.credHelper)
 
 	dupe := context.Command("copy", "Copies an existing context").Alias("cp").Action(c.copyCommand)
 	dupe.Arg("source", "The name of the context to copy from").Required().StringVar(&c.source)
@@ -64,6 +76,10 @@ func configureCtxCommand(app commandHost) {
 	dupe.Flag("description", "Set a friendly description for this context").StringVar(&c.description)
 	dupe.Flag("select", "Select the saved context as the default one").UnNegatableBoolVar(&c.activate)
 	dupe.Flag("nsc", "URL to a nsc user, eg. nsc://<operator>/<account>/<user>").StringVar(&c.nsc)
+	dupe.Flag("consumer-name-template", "Template Consumer names must follow like {{.Team}}-{{.App}}, empty to remove").PlaceHolder("TEMPLATE").IsSetByUser(&c.nameTemplateSet).StringVar(&c.nameTemplate)
+	dupe.Flag("read-only", "Refuse to run commands that modify JetStream assets while using this context").IsSetByUser(&c.readOnlySet).BoolVar(&c.readOnly)
+	dupe.Flag("validation", "JetStream API schema validation level while using this context (off, responses, strict)").PlaceHolder("LEVEL").IsSetByUser(&c.validationSet).EnumVar(&c.validation, validationOff, validationResponses, validationStrict)
+	dupe.Flag("credential-helper", "Command whose output supplies credentials or a token when connecting, empty to remove").PlaceHolder("COMMAND").IsSetByUser(&c.credHelperSet).StringVar(&c.credHelper)
 
 	edit := context.Command("edit", "Edit a context in your EDITOR").Alias("vi").Action(c.editCommand)
 	edit.Arg("name", "The context name to edit").Required().StringVar(&c.name)
@@ -488,6 +504,12 @@ func (c *ctxCommand) showCommand(_ *fisk.ParseContext) error {
 	cols.AddRowIfNotEmpty("Inbox Prefix", cfg.InboxPrefix())
 	cols.AddRowIfNotEmpty("Path", cfg.Path())
 	cols.AddRowIfNotEmpty("Color Scheme", cfg.ColorScheme())
+	if icfg, err := iu.LoadConfig(); err == nil {
+		cols.AddRowIfNotEmpty("Consumer Names", icfg.ConsumerNameTemplates[c.name])
+		cols.AddRowIf("Read Only", true, icfg.ReadOnlyContexts[c.name])
+		cols.AddRowIfNotEmpty("Validation", icfg.ValidationLevels[c.name])
+		cols.AddRowIfNotEmpty("Credential Helper", icfg.CredentialHelpers[c.name])
+	}
 
 	checkConn := func() error {
 		opts, err := cfg.NATSOptions()
@@ -526,6 +548,13 @@ func (c *ctxCommand) showCommand(_ *fisk.ParseContext) error {
 	return nil
 }
 func (c *ctxCommand) createCommand(pc *fisk.ParseContext) error {
+	if c.nameTemplateSet && c.nameTemplate != "" {
+		_, err := consumerNameParts(c.nameTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
 	lname := ""
 	load := false
 	opts := opts()
@@ -582,6 +611,11 @@ func (c *ctxCommand) createCommand(pc *fisk.ParseContext) error {
 		return err
 	}
 
+	err = c.saveContextSettings(lname)
+	if err != nil {
+		return err
+	}
+
 	if c.activate {
 		return c.selectCommand(pc)
 	}
@@ -612,7 +646,110 @@ func (c *ctxCommand) removeCommand(_ *fisk.ParseContext) error {
 		}
 	}
 
-	return natscontext.DeleteContext(c.name)
+	err := natscontext.DeleteContext(c.name)
+	if err != nil {
+		return err
+	}
+
+	c.nameTemplate = ""
+	c.nameTemplateSet = true
+	c.readOnly = false
+	c.readOnlySet = true
+	c.validation = ""
+	c.validationSet = true
+	c.credHelper = ""
+	c.credHelperSet = true
+
+	return c.saveContextSettings("")
+}
+
+// saveContextSettings stores the consumer naming template, read-only, validation and credential helper settings for the
+// context, copying them from source when not set
+func (c *ctxCommand) saveContextSettings(source string) error {
+	cfg, err := iu.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+
+	tmpl, ok := cfg.ConsumerNameTemplates[source]
+	if c.nameTemplateSet {
+		tmpl = c.nameTemplate
+		ok = true
+	}
+	if ok && cfg.ConsumerNameTemplates[c.name] != tmpl {
+		if cfg.ConsumerNameTemplates == nil {
+			cfg.ConsumerNameTemplates = map[string]string{}
+		}
+
+		if tmpl == "" {
+			delete(cfg.ConsumerNameTemplates, c.name)
+		} else {
+			cfg.ConsumerNameTemplates[c.name] = tmpl
+		}
+		changed = true
+	}
+
+	readOnly := cfg.ReadOnlyContexts[source]
+	if c.readOnlySet {
+		readOnly = c.readOnly
+	}
+	if cfg.ReadOnlyContexts[c.name] != readOnly {
+		if cfg.ReadOnlyContexts == nil {
+			cfg.ReadOnlyContexts = map[string]bool{}
+		}
+
+		if readOnly {
+			cfg.ReadOnlyContexts[c.name] = true
+		} else {
+			delete(cfg.ReadOnlyContexts, c.name)
+		}
+		changed = true
+	}
+
+	level := cfg.ValidationLevels[source]
+	if c.validationSet {
+		level = c.validation
+	}
+	if level == validationResponses {
+		level = ""
+	}
+	if cfg.ValidationLevels[c.name] != level {
+		if cfg.ValidationLevels == nil {
+			cfg.ValidationLevels = map[string]string{}
+		}
+
+		if level == "" {
+			delete(cfg.ValidationLevels, c.name)
+		} else {
+			cfg.ValidationLevels[c.name] = level
+		}
+		changed = true
+	}
+
+	helper := cfg.CredentialHelpers[source]
+	if c.credHelperSet {
+		helper = c.credHelper
+	}
+	if cfg.CredentialHelpers[c.name] != helper {
+		if cfg.CredentialHelpers == nil {
+			cfg.CredentialHelpers = map[string]string{}
+		}
+
+		if helper == "" {
+			delete(cfg.CredentialHelpers, c.name)
+		} else {
+			cfg.CredentialHelpers[c.name] = helper
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return iu.SaveConfig(cfg)
 }
 
 func (c *ctxCommand) switchPreviousCtx(pc *fisk.ParseContext) error {
@@ -0,0 +1,142 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/google/shlex"
+	"github.com/nats-io/jsm.go/natscontext"
+	"github.com/nats-io/nats.go"
+	iu "github.com/nats-io/natscli/internal/util"
+	"github.com/nats-io/nkeys"
+)
+
+// credentialHelperCreds are the credentials supplied by a credential helper, either a user JWT and seed or a token
+type credentialHelperCreds struct {
+	jwt   string
+	kp    nkeys.KeyPair
+	token string
+}
+
+var (
+	// credentialHelperResults caches the credentials by context so each helper runs once per invocation of the cli
+	credentialHelperResults = make(map[string]*credentialHelperCreds)
+	credentialHelperMu      sync.Mutex
+)
+
+// credentialHelper is the credential helper command configured for the context name
+func credentialHelper(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	cfg, err := iu.LoadConfig()
+	if err != nil {
+		return ""
+	}
+
+	return cfg.CredentialHelpers[name]
+}
+
+// parseCredentialHelperOutput parses the output of a credential helper which is either a decorated credentials file
+// or a token
+func parseCredentialHelperOutput(out []byte) (*credentialHelperCreds, error) {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return nil, fmt.Errorf("credential helper produced no output")
+	}
+
+	if !bytes.Contains(out, []byte("-----BEGIN NATS USER JWT-----")) {
+		return &credentialHelperCreds{token: string(out)}, nil
+	}
+
+	jwt, err := nkeys.ParseDecoratedJWT(out)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials from credential helper: %w", err)
+	}
+
+	kp, err := nkeys.ParseDecoratedNKey(out)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials from credential helper: %w", err)
+	}
+
+	return &credentialHelperCreds{jwt: jwt, kp: kp}, nil
+}
+
+// runCredentialHelper runs command with the context name and server URL in its environment and parses its output
+func runCredentialHelper(command string, name string, url string) (*credentialHelperCreds, error) {
+	parts, err := shlex.Split(command)
+	if err != nil {
+		return nil, fmt.Errorf("the credential helper command line could not be parsed: %w", err)
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("credential helper command is empty")
+	}
+
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
+	cmd.Env = append(os.Environ(), "NATS_CONTEXT="+name, "NATS_URL="+url)
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("credential helper failed: %w", err)
+	}
+	defer func() {
+		for i := range out {
+			out[i] = 'x'
+		}
+	}()
+
+	return parseCredentialHelperOutput(out)
+}
+
+// credentialHelperOptions configures connections to the context name configured by nctx using credentials from its
+// credential helper
+func credentialHelperOptions(name string, nctx *natscontext.Context) ([]nats.Option, error) {
+	command := credentialHelper(name)
+	if command == "" {
+		return nil, nil
+	}
+
+	if nctx.User() != "" || nctx.Creds() != "" || nctx.NKey() != "" || nctx.Token() != "" {
+		return nil, fmt.Errorf("context %s has both credentials and a credential helper", name)
+	}
+
+	credentialHelperMu.Lock()
+	defer credentialHelperMu.Unlock()
+
+	creds, ok := credentialHelperResults[name]
+	if !ok {
+		var err error
+		creds, err = runCredentialHelper(command, name, nctx.ServerURL())
+		if err != nil {
+			return nil, err
+		}
+		credentialHelperResults[name] = creds
+	}
+
+	if creds.token != "" {
+		return []nats.Option{nats.Token(creds.token)}, nil
+	}
+
+	return []nats.Option{nats.UserJWT(
+		func() (string, error) { return creds.jwt, nil },
+		func(nonce []byte) ([]byte, error) { return creds.kp.Sign(nonce) },
+	)}, nil
+}
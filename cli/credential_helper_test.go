@@ -0,0 +1,54 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nats-io/nkeys"
+)
+
+func TestParseCredentialHelperOutput(t *testing.T) {
+	_, err := parseCredentialHelperOutput([]byte(" \n"))
+	if err == nil {
+		t.Fatalf("expected empty output to fail")
+	}
+
+	creds, err := parseCredentialHelperOutput([]byte("s3cret\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.token != "s3cret" || creds.jwt != "" {
+		t.Fatalf("expected a token: %#v", creds)
+	}
+
+	user, err := nkeys.CreateUser()
+	if err != nil {
+		t.Fatalf("could not create user: %v", err)
+	}
+	seed, err := user.Seed()
+	if err != nil {
+		t.Fatalf("could not get seed: %v", err)
+	}
+
+	out := fmt.Sprintf("-----BEGIN NATS USER JWT-----\neyJhbGciOiJlZDI1NTE5LW5rZXkifQ.e30.c2ln\n------END NATS USER JWT------\n\n-----BEGIN USER NKEY SEED-----\n%s\n------END USER NKEY SEED------\n", seed)
+	creds, err = parseCredentialHelperOutput([]byte(out))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.token != "" || creds.jwt != "eyJhbGciOiJlZDI1NTE5LW5rZXkifQ.e30.c2ln" || creds.kp == nil {
+		t.Fatalf("expected credentials: %#v", creds)
+	}
+}
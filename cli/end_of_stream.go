@@ -0,0 +1,108 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/choria-io/fisk"
+)
+
+const untilSealedHelp = "Stops once all messages were consumed and the Stream is sealed or idle for the --grace period"
+
+// addUntilSealedFlags adds the --until-sealed and --grace flags to cmd
+func addUntilSealedFlags(cmd *fisk.CmdClause, untilSealed *bool, grace *time.Duration) {
+	cmd.Flag("until-sealed", untilSealedHelp).UnNegatableBoolVar(untilSealed)
+	cmd.Flag("grace", "How long the Stream has to be idle before --until-sealed stops consuming an unsealed Stream").Default("10s").DurationVar(grace)
+}
+
+// consumptionState is the part of the consumer and stream state that determines if all messages were consumed
+type consumptionState struct {
+	Pending    uint64
+	AckPending int
+	Sealed     bool
+}
+
+// endOfStream detects when a consumer has nothing left to deliver and the stream will not receive more messages
+type endOfStream struct {
+	grace time.Duration
+	state func() (consumptionState, error)
+	last  time.Time
+	mu    sync.Mutex
+}
+
+func newEndOfStream(grace time.Duration, state func() (consumptionState, error)) *endOfStream {
+	return &endOfStream{grace: grace, state: state, last: time.Now()}
+}
+
+// received records that a message arrived, restarting the idle grace period
+func (e *endOfStream) received() {
+	e.mu.Lock()
+	e.last = time.Now()
+	e.mu.Unlock()
+}
+
+func (e *endOfStream) idle() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return time.Since(e.last)
+}
+
+// consumptionDone determines if all messages were consumed and the stream is sealed or idle for at least grace
+func consumptionDone(state consumptionState, idle time.Duration, grace time.Duration) bool {
+	if state.Pending > 0 || state.AckPending > 0 {
+		return false
+	}
+
+	return state.Sealed || idle >= grace
+}
+
+// done polls the current state and determines if consumption is complete
+func (e *endOfStream) done() (bool, error) {
+	state, err := e.state()
+	if err != nil {
+		return false, err
+	}
+
+	return consumptionDone(state, e.idle(), e.grace), nil
+}
+
+// wait blocks until consumption is complete or ctx is done
+func (e *endOfStream) wait(ctx context.Context) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			done, err := e.done()
+			if err != nil {
+				if opts().Trace {
+					log.Printf("Could not determine consumption state: %v", err)
+				}
+				continue
+			}
+
+			if done {
+				return nil
+			}
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsumptionDone(t *testing.T) {
+	cases := []struct {
+		state consumptionState
+		idle  time.Duration
+		done  bool
+	}{
+		{state: consumptionState{Pending: 1, Sealed: true}, idle: time.Hour, done: false},
+		{state: consumptionState{AckPending: 1, Sealed: true}, idle: time.Hour, done: false},
+		{state: consumptionState{Sealed: true}, idle: 0, done: true},
+		{state: consumptionState{}, idle: time.Second, done: false},
+		{state: consumptionState{}, idle: 10 * time.Second, done: true},
+	}
+
+	for i, tc := range cases {
+		if done := consumptionDone(tc.state, tc.idle, 10*time.Second); done != tc.done {
+			t.Fatalf("case %d: expected %v got %v", i, tc.done, done)
+		}
+	}
+}
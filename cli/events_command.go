@@ -30,6 +30,7 @@ import (
 
 type eventsCmd struct {
 	json  bool
+	jsonl bool
 	ce    bool
 	short bool
 
@@ -54,6 +55,7 @@ func configureEventsCommand(app commandHost) {
 	addCheat("events", events)
 	events.Flag("all", "Show all events").Short('a').UnNegatableBoolVar(&c.showAll)
 	events.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+	events.Flag("jsonl", "Produce a single line of JSON per event including the subject and headers").UnNegatableBoolVar(&c.jsonl)
 	events.Flag("cloudevent", "Produce CloudEvents v1 output").UnNegatableBoolVar(&c.ce)
 	events.Flag("short", "Short event format").UnNegatableBoolVar(&c.short)
 	events.Flag("filter", "Filter across the entire event using regular expressions").Default(".").StringVar(&c.bodyF)
@@ -70,13 +72,37 @@ func init() {
 }
 
 func (c *eventsCmd) handleJsEvent(msg jetstream.Msg) {
+	if c.jsonl {
+		c.handleJSONLEvent(&nats.Msg{Subject: msg.Subject(), Reply: msg.Reply(), Header: msg.Headers(), Data: msg.Data()})
+		return
+	}
+
 	c.handleNATSEventData(msg.Subject(), msg.Data())
 }
 
 func (c *eventsCmd) handleNATSEvent(msg *nats.Msg) {
+	if c.jsonl {
+		c.handleJSONLEvent(msg)
+		return
+	}
+
 	c.handleNATSEventData(msg.Subject, msg.Data)
 }
 
+func (c *eventsCmd) handleJSONLEvent(msg *nats.Msg) {
+	if !c.bodyFRe.MatchString(strings.ToUpper(string(msg.Data))) {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	err := outPutMSGJSONL(msg, "")
+	if err != nil {
+		log.Printf("Could not render event as JSON: %s", err)
+	}
+}
+
 func (c *eventsCmd) handleNATSEventData(subject string, data []byte) {
 	if !c.bodyFRe.MatchString(strings.ToUpper(string(data))) {
 		return
@@ -143,7 +169,10 @@ func (c *eventsCmd) Printf(f string, arg ...any) {
 }
 
 func (c *eventsCmd) eventsAction(_ *fisk.ParseContext) error {
-	if c.ce {
+	if c.ce && c.jsonl {
+		return fmt.Errorf("cannot produce both CloudEvents and JSON Lines output")
+	}
+	if c.ce || c.jsonl {
 		c.json = true
 	}
 
@@ -0,0 +1,122 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// latencyConn delays reads and writes on a connection to simulate slow links
+type latencyConn struct {
+	net.Conn
+	read  time.Duration
+	write time.Duration
+}
+
+// Read delays delivering data once it arrived, sleeping before reading would overlap with the time spent waiting for
+// data and add no latency to an idle connection
+func (c *latencyConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 && c.read > 0 {
+		time.Sleep(c.read)
+	}
+
+	return n, err
+}
+
+func (c *latencyConn) Write(b []byte) (int, error) {
+	if c.write > 0 {
+		time.Sleep(c.write)
+	}
+
+	return c.Conn.Write(b)
+}
+
+// latencyDialer wraps connections made by the configured dialer in a latencyConn
+type latencyDialer struct {
+	dialer nats.CustomDialer
+	read   time.Duration
+	write  time.Duration
+}
+
+func (d *latencyDialer) Dial(network, address string) (net.Conn, error) {
+	conn, err := d.dialer.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &latencyConn{Conn: conn, read: d.read, write: d.write}, nil
+}
+
+// parseInjectLatency parses a specification like read=50ms,write=20ms
+func parseInjectLatency(spec string) (read time.Duration, write time.Duration, err error) {
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		dir, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return 0, 0, fmt.Errorf("invalid latency %q, expected read=DURATION or write=DURATION", part)
+		}
+
+		d, err := time.ParseDuration(strings.TrimSpace(val))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s latency: %w", dir, err)
+		}
+		if d < 0 {
+			return 0, 0, fmt.Errorf("invalid %s latency: cannot be negative", dir)
+		}
+
+		switch strings.TrimSpace(dir) {
+		case "read":
+			read = d
+		case "write":
+			write = d
+		default:
+			return 0, 0, fmt.Errorf("invalid latency direction %q, expected read or write", dir)
+		}
+	}
+
+	return read, write, nil
+}
+
+// injectLatency delays reads and writes on connections made using the dialer configured by earlier options
+func injectLatency(spec string) nats.Option {
+	return func(o *nats.Options) error {
+		read, write, err := parseInjectLatency(spec)
+		if err != nil {
+			return err
+		}
+
+		dialer := o.CustomDialer
+		switch {
+		case dialer != nil:
+		case o.Dialer != nil:
+			dialer = o.Dialer
+		default:
+			dialer = &net.Dialer{Timeout: o.Timeout}
+		}
+
+		o.CustomDialer = &latencyDialer{dialer: dialer, read: read, write: write}
+
+		return nil
+	}
+}
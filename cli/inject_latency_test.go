@@ -0,0 +1,70 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestLatencyConnRead(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	latency := 50 * time.Millisecond
+	conn := &latencyConn{Conn: client, read: latency}
+
+	written := make(chan time.Time, 1)
+	go func() {
+		// the reader is idle longer than the latency so a delay before reading would not be noticed
+		time.Sleep(2 * latency)
+		written <- time.Now()
+		server.Write([]byte("PING\r\n"))
+	}()
+
+	buf := make([]byte, 10)
+	n, err := conn.Read(buf)
+	if err != nil || string(buf[:n]) != "PING\r\n" {
+		t.Fatalf("unexpected read %q: %v", buf[:n], err)
+	}
+
+	delay := time.Since(<-written)
+	if delay < latency {
+		t.Fatalf("expected data to be delayed by at least %v, was delayed %v", latency, delay)
+	}
+}
+
+func TestParseInjectLatency(t *testing.T) {
+	read, write, err := parseInjectLatency("read=50ms, write=20ms")
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if read != 50*time.Millisecond || write != 20*time.Millisecond {
+		t.Fatalf("invalid latencies read %v write %v", read, write)
+	}
+
+	read, write, err = parseInjectLatency("write=1s")
+	if err != nil || read != 0 || write != time.Second {
+		t.Fatalf("invalid write only latency: %v %v %v", read, write, err)
+	}
+
+	for _, spec := range []string{"read", "read=x", "up=1s", "read=-1s"} {
+		_, _, err = parseInjectLatency(spec)
+		if err == nil {
+			t.Fatalf("expected %q to fail", spec)
+		}
+	}
+}
@@ -46,23 +46,31 @@ func (v SchemaValidator) ValidateStruct(data any, schemaType string) (ok bool, e
 
 	err = sch.Validate(d)
 	if err != nil {
-		if verr, ok := err.(*jsonschema.ValidationError); ok {
-			for _, e := range verr.BasicOutput().Errors {
-				if e.KeywordLocation == "" || e.Error == "oneOf failed" || e.Error == "allOf failed" {
-					continue
-				}
+		return false, schemaValidationErrors(err)
+	}
 
-				if e.InstanceLocation == "" {
-					errs = append(errs, e.Error)
-				} else {
-					errs = append(errs, fmt.Sprintf("%s: %s", e.InstanceLocation, e.Error))
-				}
-			}
-			return false, errs
+	return true, nil
+}
+
+// schemaValidationErrors describes the failures in a schema validation error
+func schemaValidationErrors(err error) []string {
+	verr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return []string{fmt.Sprintf("could not validate: %s", err)}
+	}
+
+	var errs []string
+	for _, e := range verr.BasicOutput().Errors {
+		if e.KeywordLocation == "" || e.Error == "oneOf failed" || e.Error == "allOf failed" {
+			continue
+		}
+
+		if e.InstanceLocation == "" {
+			errs = append(errs, e.Error)
 		} else {
-			return false, []string{fmt.Sprintf("could not validate: %s", err)}
+			errs = append(errs, fmt.Sprintf("%s: %s", e.InstanceLocation, e.Error))
 		}
 	}
 
-	return true, nil
+	return errs
 }
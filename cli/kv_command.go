@@ -65,6 +65,7 @@ type kvCommand struct {
 	mirrorDomain          string
 	sources               []string
 	compression           bool
+	sourceDomain          string
 }
 
 func configureKVCommand(app commandHost) {
@@ -79,9 +80,9 @@ for an indefinite period or a per-bucket configured TTL.
 	kv := app.Command("kv", help)
 	addCheat("kv", kv)
 
-	add := kv.Command("add", "Adds a new KV Store Bucket").Alias("new").Action(c.addAction)
+	add := kv.Command("add", "Adds a new KV Store Bucket").Alias("new").Alias("create").Action(c.addAction)
 	add.Arg("bucket", "The bucket to act on").Required().StringVar(&c.bucket)
-	add.Flag("history", "How many historic values to keep per key").Default("1").Uint64Var(&c.history)
+	add.Flag("history", "How many historic values to keep per key, up to 64").Default("1").Uint64Var(&c.history)
 	add.Flag("ttl", "How long to keep values for").DurationVar(&c.ttl)
 	add.Flag("replicas", "How many replicas of the data to store").Default("1").UintVar(&c.replicas)
 	add.Flag("max-value-size", "Maximum size for any single value").PlaceHolder("BYTES").StringVar(&c.maxValueSizeString)
@@ -97,6 +98,7 @@ for an indefinite period or a per-bucket configured TTL.
 	add.Flag("mirror", "Creates a mirror of a different bucket").StringVar(&c.mirror)
 	add.Flag("mirror-domain", "When mirroring find the bucket in a different domain").StringVar(&c.mirrorDomain)
 	add.Flag("source", "Source from a different bucket").PlaceHolder("BUCKET").StringsVar(&c.sources)
+	add.Flag("source-domain", "When sourcing find the buckets in a different domain").PlaceHolder("DOMAIN").StringVar(&c.sourceDomain)
 
 	add.PreAction(c.parseLimitStrings)
 
@@ -142,8 +144,11 @@ for an indefinite period or a per-bucket configured TTL.
 	revert.Arg("revision", "The revision to revert to").Required().Uint64Var(&c.revision)
 	revert.Flag("force", "Force reverting without prompting").BoolVar(&c.force)
 
-	status := kv.Command("info", "View the status of a KV store").Alias("view").Alias("status").Action(c.infoAction)
-	status.Arg("bucket", "The bucket to act on").StringVar(&c.bucket)
+	info := kv.Command("info", "View the status of a KV store").Alias("view").Action(c.infoAction)
+	info.Arg("bucket", "The bucket to act on").StringVar(&c.bucket)
+
+	status := kv.Command("status", "View the status of a KV store or the replication status of all buckets").Action(c.statusAction)
+	status.Arg("bucket", "The bucket to act on, shows all buckets when not set").StringVar(&c.bucket)
 
 	watch := kv.Command("watch", "Watch the bucket or a specific key for updated").Action(c.watchAction)
 	watch.Arg("bucket", "The bucket to act on").Required().StringVar(&c.bucket)
@@ -469,6 +474,19 @@ func (c *kvCommand) deleteAction(pc *fisk.ParseContext) error {
 }
 
 func (c *kvCommand) addAction(_ *fisk.ParseContext) error {
+	if c.history < 1 || c.history > 64 {
+		return fmt.Errorf("history must be between 1 and 64")
+	}
+	if c.replicas < 1 || c.replicas > 5 {
+		return fmt.Errorf("replicas must be between 1 and 5")
+	}
+	if c.mirror != "" && len(c.sources) > 0 {
+		return fmt.Errorf("buckets can not both mirror and source other buckets")
+	}
+	if (c.repubSource != "" || c.repubHeadersOnly) && c.repubDest == "" {
+		return fmt.Errorf("republishing requires a --republish-destination")
+	}
+
 	_, js, err := prepareJSHelper()
 	if err != nil {
 		return err
@@ -517,7 +535,8 @@ func (c *kvCommand) addAction(_ *fisk.ParseContext) error {
 
 	for _, source := range c.sources {
 		cfg.Sources = append(cfg.Sources, &jetstream.StreamSource{
-			Name: source,
+			Name:   source,
+			Domain: c.sourceDomain,
 		})
 	}
 
@@ -719,6 +738,76 @@ func (c *kvCommand) infoAction(_ *fisk.ParseContext) error {
 	return c.showStatus(store)
 }
 
+func (c *kvCommand) statusAction(pc *fisk.ParseContext) error {
+	if c.bucket != "" {
+		return c.infoAction(pc)
+	}
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	if err != nil {
+		return err
+	}
+
+	var found []*jsm.Stream
+	_, err = mgr.EachStream(nil, func(s *jsm.Stream) {
+		if s.IsKVBucket() {
+			found = append(found, s)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(found) == 0 {
+		fmt.Println("No Key-Value buckets found")
+		return nil
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Name() < found[j].Name()
+	})
+
+	table := util.NewTableWriter(opts(), "Key-Value Bucket Replication")
+	table.AddHeaders("Bucket", "Replicas", "Cluster", "Replication", "Origin", "Lag", "Last Seen")
+	for _, s := range found {
+		nfo, err := s.LatestInformation()
+		if err != nil {
+			return err
+		}
+
+		var origins, lag, seen []string
+		if nfo.Mirror != nil {
+			origins = append(origins, fmt.Sprintf("mirror of %s", strings.TrimPrefix(nfo.Mirror.Name, "KV_")))
+			lag = append(lag, f(nfo.Mirror.Lag))
+			seen = append(seen, kvSourceLastSeen(nfo.Mirror.Active))
+		}
+		for _, source := range nfo.Sources {
+			origins = append(origins, fmt.Sprintf("source %s", strings.TrimPrefix(source.Name, "KV_")))
+			lag = append(lag, f(source.Lag))
+			seen = append(seen, kvSourceLastSeen(source.Active))
+		}
+
+		cluster := ""
+		if nfo.Cluster != nil {
+			cluster = nfo.Cluster.Name
+		}
+
+		table.AddRow(strings.TrimPrefix(s.Name(), "KV_"), nfo.Config.Replicas, cluster, renderCluster(nfo.Cluster), strings.Join(origins, "\n"), strings.Join(lag, "\n"), strings.Join(seen, "\n"))
+	}
+
+	fmt.Println(table.Render())
+
+	return nil
+}
+
+func kvSourceLastSeen(active time.Duration) string {
+	if active > 0 && active < math.MaxInt64 {
+		return f(active)
+	}
+
+	return "never"
+}
+
 func (c *kvCommand) watchAction(_ *fisk.ParseContext) error {
 	_, _, store, err := c.loadBucket()
 	if err != nil {
@@ -826,6 +915,9 @@ func (c *kvCommand) showStatus(store jetstream.KeyValue) error {
 	cols.AddRow("History Kept", status.History())
 	cols.AddRow("Values Stored", status.Values())
 	cols.AddRow("Compressed", status.IsCompressed())
+	if nfo != nil {
+		cols.AddRow("Replicas", nfo.Config.Replicas)
+	}
 	cols.AddRow("Backing Store Kind", status.BackingStore())
 
 	if nfo != nil {
@@ -28,12 +28,14 @@ import (
 
 	"github.com/HdrHistogram/hdrhistogram-go"
 	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go/natscontext"
 	"github.com/nats-io/nats.go"
 	histwriter "github.com/tylertreat/hdrhistogram-writer"
 )
 
 type latencyCmd struct {
 	serverB       string
+	contextB      string
 	targetPubRate int
 	msgSize       int
 	testDuration  time.Duration
@@ -46,7 +48,8 @@ func configureLatencyCommand(app commandHost) {
 
 	latency := app.Command("latency", "Perform latency tests between two NATS servers").Alias("lat").Action(c.latencyAction)
 	addCheat("latency", latency)
-	latency.Flag("server-b", "The second server to subscribe on").Required().StringVar(&c.serverB)
+	latency.Flag("server-b", "The second server to subscribe on").StringVar(&c.serverB)
+	latency.Flag("context-b", "The context to use for the subscriber, allows testing across clusters").PlaceHolder("NAME").StringVar(&c.contextB)
 	latency.Flag("size", "Message size").Default("8").IntVar(&c.msgSize)
 	latency.Flag("rate", "Rate of messages per second").Default("1000").IntVar(&c.targetPubRate)
 	latency.Flag("duration", "Test duration").Default("5s").DurationVar(&c.testDuration)
@@ -65,15 +68,16 @@ func (c *latencyCmd) latencyAction(_ *fisk.ParseContext) error {
 		return fmt.Errorf("message Payload Size must be at least %d bytes", 8)
 	}
 
+	if c.serverB != "" && c.contextB != "" {
+		return fmt.Errorf("--server-b and --context-b cannot be used together")
+	}
+
 	c1, err := newNatsConn("", natsOpts()...)
 	if err != nil {
 		return fmt.Errorf("first connection failed: %v", err)
 	}
 
-	// reset to not use the stored conn or context
-	opts().Conn = nil
-
-	c2, err := newNatsConn(c.serverB, natsOpts()...)
+	c2, err := c.subscriberConn()
 	if err != nil {
 		return fmt.Errorf("second connection failed: %v", err)
 	}
@@ -228,6 +232,28 @@ func (c *latencyCmd) latencyAction(_ *fisk.ParseContext) error {
 	return nil
 }
 
+// subscriberConn connects to the server or context the subscriber should use, defaulting to the publisher server
+func (c *latencyCmd) subscriberConn() (*nats.Conn, error) {
+	if c.contextB == "" {
+		// reset to not use the stored conn or context
+		opts().Conn = nil
+
+		return newNatsConn(c.serverB, natsOpts()...)
+	}
+
+	nctx, err := natscontext.New(c.contextB, true)
+	if err != nil {
+		return nil, err
+	}
+
+	copts, err := contextNatsOpts(c.contextB, nctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return nats.Connect(nctx.ServerURL(), copts...)
+}
+
 // Just pretty print the byte sizes.
 func (c *latencyCmd) byteSize(n int) string {
 	sizes := []string{"B", "K", "M", "G", "T"}
@@ -0,0 +1,113 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/nats.go"
+)
+
+// msgDisplay controls which parts of a message are shown by commands that print messages
+type msgDisplay struct {
+	headersOnly bool
+	noHeaders   bool
+}
+
+// addMsgDisplayFlags adds the --headers-only and --no-headers flags to cmd
+func addMsgDisplayFlags(cmd *fisk.CmdClause, d *msgDisplay) {
+	cmd.Flag("headers-only", "Do not render any data, shows only headers").UnNegatableBoolVar(&d.headersOnly)
+	cmd.Flag("no-headers", "Do not render any headers, shows only data").UnNegatableBoolVar(&d.noHeaders)
+}
+
+func (d msgDisplay) validate() error {
+	if d.headersOnly && d.noHeaders {
+		return fmt.Errorf("--headers-only and --no-headers are mutually exclusive")
+	}
+
+	return nil
+}
+
+// filter returns a copy of msg holding only the parts that should be shown, used for JSON and dumped output
+func (d msgDisplay) filter(msg *nats.Msg) *nats.Msg {
+	if !d.headersOnly && !d.noHeaders {
+		return msg
+	}
+
+	res := nats.NewMsg(msg.Subject)
+	res.Reply = msg.Reply
+	res.Sub = msg.Sub
+	if !d.noHeaders {
+		res.Header = msg.Header
+	}
+	if !d.headersOnly {
+		res.Data = msg.Data
+	}
+
+	return res
+}
+
+// writeHeaders writes hdr sorted by name, one value per line prefixed by indent, headers named in skip are not shown
+func (d msgDisplay) writeHeaders(w io.Writer, hdr nats.Header, indent string, skip ...string) int {
+	if d.noHeaders {
+		return 0
+	}
+
+	keys := make([]string, 0, len(hdr))
+	for k := range hdr {
+		if slices.Contains(skip, k) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := 0
+	for _, k := range keys {
+		for _, v := range hdr[k] {
+			// source headers are separated using form feeds
+			fmt.Fprintf(w, "%s%s: %s\n", indent, k, strings.ReplaceAll(v, "\f", "\u240A"))
+			lines++
+		}
+	}
+
+	return lines
+}
+
+// printMsg renders the indented headers and, unless only headers are shown, the body using body
+func (d msgDisplay) printMsg(hdr nats.Header, body func(), skip ...string) {
+	if d.writeHeaders(os.Stdout, hdr, "  ", skip...) > 0 && !d.headersOnly {
+		fmt.Println()
+	}
+
+	if !d.headersOnly {
+		body()
+	}
+}
+
+// printRaw renders the headers when only headers are shown otherwise the body using body
+func (d msgDisplay) printRaw(hdr nats.Header, body func()) {
+	if d.headersOnly {
+		d.writeHeaders(os.Stdout, hdr, "")
+		return
+	}
+
+	body()
+}
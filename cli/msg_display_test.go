@@ -0,0 +1,59 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestMsgDisplay(t *testing.T) {
+	if (msgDisplay{headersOnly: true, noHeaders: true}).validate() == nil {
+		t.Fatalf("expected --headers-only and --no-headers to be mutually exclusive")
+	}
+
+	msg := nats.NewMsg("orders.new")
+	msg.Header.Add("Zed", "1")
+	msg.Header.Add("Alpha", "2")
+	msg.Header.Add("Nats-Stream-Source", "ORDERS\fx")
+	msg.Data = []byte("body")
+
+	buf := bytes.NewBuffer(nil)
+	msgDisplay{}.writeHeaders(buf, msg.Header, "  ", "Zed")
+	expected := "  Alpha: 2\n  Nats-Stream-Source: ORDERS\u240Ax\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	if (msgDisplay{noHeaders: true}).writeHeaders(buf, msg.Header, "") != 0 || buf.Len() != 0 {
+		t.Fatalf("expected no headers to be written")
+	}
+
+	filtered := msgDisplay{headersOnly: true}.filter(msg)
+	if len(filtered.Data) != 0 || len(filtered.Header) != 3 {
+		t.Fatalf("expected only headers got %+v", filtered)
+	}
+
+	filtered = msgDisplay{noHeaders: true}.filter(msg)
+	if string(filtered.Data) != "body" || len(filtered.Header) != 0 {
+		t.Fatalf("expected only data got %+v", filtered)
+	}
+
+	if (msgDisplay{}).filter(msg) != msg {
+		t.Fatalf("expected unfiltered message")
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/choria-io/fisk"
+)
+
+// notifier alerts operators watching long-running commands about leader changes and threshold breaches
+type notifier struct {
+	desktop bool
+	bell    bool
+
+	values   map[string]string
+	breaches map[string]bool
+	warned   bool
+	mu       sync.Mutex
+}
+
+// addNotifyFlags adds the --notify and --bell flags to cmd
+func addNotifyFlags(cmd *fisk.CmdClause, n *notifier) {
+	cmd.Flag("notify", "Show a desktop notification when a leader changes or a threshold is breached").UnNegatableBoolVar(&n.desktop)
+	cmd.Flag("bell", "Ring the terminal bell when a leader changes or a threshold is breached").UnNegatableBoolVar(&n.bell)
+}
+
+func (n *notifier) enabled() bool {
+	return n.desktop || n.bell
+}
+
+// changed records value for key and reports if it differs from a previously recorded value
+func (n *notifier) changed(key string, value string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.values == nil {
+		n.values = map[string]string{}
+	}
+
+	prev, seen := n.values[key]
+	n.values[key] = value
+
+	return seen && prev != value
+}
+
+// breached records the breach state for key and reports if it just started
+func (n *notifier) breached(key string, breach bool) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.breaches == nil {
+		n.breaches = map[string]bool{}
+	}
+
+	prev := n.breaches[key]
+	n.breaches[key] = breach
+
+	return breach && !prev
+}
+
+// notify alerts using the enabled methods, desktop notifications are sent in the background
+func (n *notifier) notify(title string, format string, a ...any) {
+	if !n.enabled() {
+		return
+	}
+
+	msg := fmt.Sprintf(format, a...)
+
+	if n.bell {
+		fmt.Fprint(os.Stderr, "\a")
+	}
+
+	if !n.desktop {
+		return
+	}
+
+	cmd, args, ok := desktopNotifyCommand(runtime.GOOS, title, msg)
+	if !ok {
+		n.warnOnce("Desktop notifications are not supported on %s", runtime.GOOS)
+		return
+	}
+
+	go func() {
+		err := exec.Command(cmd, args...).Run()
+		if err != nil {
+			n.warnOnce("Could not show desktop notification using %s: %v", cmd, err)
+		}
+	}()
+}
+
+func (n *notifier) warnOnce(format string, a ...any) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.warned {
+		return
+	}
+	n.warned = true
+
+	log.Printf(format, a...)
+}
+
+// desktopNotifyCommand determines the command used to show a desktop notification on goos
+func desktopNotifyCommand(goos string, title string, msg string) (string, []string, bool) {
+	switch goos {
+	case "darwin":
+		return "osascript", []string{"-e", fmt.Sprintf("display notification %s with title %s", strconv.Quote(msg), strconv.Quote(title))}, true
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "notify-send", []string{title, msg}, true
+	default:
+		return "", nil, false
+	}
+}
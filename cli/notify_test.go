@@ -0,0 +1,46 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+)
+
+func TestNotifier(t *testing.T) {
+	n := &notifier{}
+
+	if n.changed("leader", "n1") {
+		t.Fatalf("first value should not be a change")
+	}
+	if n.changed("leader", "n1") {
+		t.Fatalf("same value should not be a change")
+	}
+	if !n.changed("leader", "n2") {
+		t.Fatalf("expected a change")
+	}
+
+	for i, tc := range []struct{ breach, notify bool }{{false, false}, {true, true}, {true, false}, {false, false}, {true, true}} {
+		if n.breached("pending", tc.breach) != tc.notify {
+			t.Fatalf("case %d: expected %v", i, tc.notify)
+		}
+	}
+
+	cmd, args, ok := desktopNotifyCommand("darwin", "Stream ORDERS", `Leader "n2"`)
+	if !ok || cmd != "osascript" || args[1] != `display notification "Leader \"n2\"" with title "Stream ORDERS"` {
+		t.Fatalf("invalid darwin command: %s %v", cmd, args)
+	}
+	if _, _, ok = desktopNotifyCommand("plan9", "x", "y"); ok {
+		t.Fatalf("expected unsupported platform")
+	}
+}
@@ -16,6 +16,7 @@ package cli
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"github.com/jedib0t/go-pretty/v6/progress"
 	"io"
@@ -55,6 +56,18 @@ type objCommand struct {
 	replicas    uint
 	ttl         time.Duration
 	compression bool
+	linkBucket  string
+	linkObject  string
+	destBucket  string
+	destContext string
+
+	syncDir       string
+	syncDirection string
+	syncWatch     bool
+	syncInterval  time.Duration
+	syncCache     map[string]objectSyncCached
+	syncSkipped   map[string]bool
+	dryRun        bool
 }
 
 func configureObjectCommand(app commandHost) {
@@ -122,6 +135,31 @@ NOTE: This is an experimental feature.
 
 	watch := obj.Command("watch", "Watch a bucket for changes").Action(c.watchAction)
 	watch.Arg("bucket", "The bucket to act on").Required().StringVar(&c.bucket)
+
+	link := obj.Command("link", "Creates a link to an object or an entire bucket").Action(c.linkAction)
+	link.Arg("bucket", "The bucket to create the link in").Required().StringVar(&c.bucket)
+	link.Arg("name", "The name of the link").Required().StringVar(&c.file)
+	link.Arg("target-bucket", "The bucket holding the object to link to").Required().StringVar(&c.linkBucket)
+	link.Arg("target-object", "The object to link to, links the entire bucket when not set").StringVar(&c.linkObject)
+	link.Flag("force", "Act without confirmation").Short('f').UnNegatableBoolVar(&c.force)
+
+	cp := obj.Command("cp", "Copies objects between buckets preserving their metadata").Alias("copy").Action(c.copyAction)
+	cp.HelpLong(`Copies one or all objects from a bucket to another bucket, optionally in
+another account or cluster described by a saved context.
+
+Descriptions, headers and metadata are preserved and the digest of every
+copy is verified against the source object. Links are not copied.
+
+   nats object cp STAGING PROD release.tar.gz
+   nats object cp STAGING PROD --to-context prod-eu`)
+	cp.Arg("bucket", "The bucket to copy from").Required().StringVar(&c.bucket)
+	cp.Arg("destination", "The bucket to copy to").Required().StringVar(&c.destBucket)
+	cp.Arg("file", "The object to copy, copies all objects when not set").StringVar(&c.file)
+	cp.Flag("name", "Override the name of the copied object").StringVar(&c.overrideName)
+	cp.Flag("to-context", "Copy to a bucket using a different saved context").PlaceHolder("NAME").StringVar(&c.destContext)
+	cp.Flag("force", "Replace existing objects without prompting").Short('f').UnNegatableBoolVar(&c.force)
+
+	configureObjectSyncCommand(obj, c)
 }
 
 func init() {
@@ -169,6 +207,199 @@ func (c *objCommand) watchAction(_ *fisk.ParseContext) error {
 	return nil
 }
 
+func (c *objCommand) linkAction(_ *fisk.ParseContext) error {
+	_, js, obj, err := c.loadBucket()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts().Timeout)
+	defer cancel()
+
+	nfo, err := obj.GetInfo(ctx, c.file)
+	if err == nil && !nfo.Deleted && !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Replace existing file %s > %s with a link", c.bucket, c.file), false)
+		fisk.FatalIfError(err, "could not obtain confirmation")
+
+		if !ok {
+			return nil
+		}
+	}
+
+	target, err := js.ObjectStore(ctx, c.linkBucket)
+	if err != nil {
+		return fmt.Errorf("could not load bucket %s: %w", c.linkBucket, err)
+	}
+
+	if c.linkObject == "" {
+		nfo, err = obj.AddBucketLink(ctx, c.file, target)
+	} else {
+		var tnfo *jetstream.ObjectInfo
+		tnfo, err = target.GetInfo(ctx, c.linkObject)
+		if err != nil {
+			return fmt.Errorf("could not load object %s > %s: %w", c.linkBucket, c.linkObject, err)
+		}
+
+		nfo, err = obj.AddLink(ctx, c.file, tnfo)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.showObjectInfo(nfo)
+
+	return nil
+}
+
+func (c *objCommand) copyAction(_ *fisk.ParseContext) error {
+	if c.overrideName != "" && c.file == "" {
+		return fmt.Errorf("--name can only be used when copying a single object")
+	}
+
+	_, js, src, err := c.loadBucket()
+	if err != nil {
+		return err
+	}
+
+	djs := js
+	if c.destContext != "" {
+		dnc, _, cjs, err := connectMigrateContext(c.destContext)
+		if err != nil {
+			return fmt.Errorf("could not connect to context %s: %w", c.destContext, err)
+		}
+		defer dnc.Close()
+		djs = cjs
+	}
+
+	lctx, cancel := context.WithTimeout(ctx, opts().Timeout)
+	defer cancel()
+
+	dest, err := djs.ObjectStore(lctx, c.destBucket)
+	if err != nil {
+		return fmt.Errorf("could not load destination bucket %s: %w", c.destBucket, err)
+	}
+
+	if c.destContext == "" && c.destBucket == c.bucket && (c.overrideName == "" || c.overrideName == c.file) {
+		return fmt.Errorf("source and destination objects cannot be the same")
+	}
+
+	var objects []*jetstream.ObjectInfo
+	if c.file != "" {
+		nfo, err := src.GetInfo(lctx, c.file)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, nfo)
+	} else {
+		objects, err = src.List(lctx)
+		if err != nil && !errors.Is(err, jetstream.ErrNoObjectsFound) {
+			return err
+		}
+	}
+
+	table := iu.NewTableWriter(opts(), "Copied objects from %s to %s", c.bucket, c.destBucket)
+	table.AddHeaders("Object", "Size", "Result")
+
+	copied := 0
+	for _, nfo := range objects {
+		if nfo.Deleted {
+			continue
+		}
+
+		if nfo.Opts != nil && nfo.Opts.Link != nil {
+			table.AddRow(nfo.Name, "", "skipped link")
+			continue
+		}
+
+		name := nfo.Name
+		if c.overrideName != "" {
+			name = c.overrideName
+		}
+
+		if !c.force {
+			exists, err := objectExists(dest, name)
+			if err != nil {
+				return fmt.Errorf("could not check for existing object %s > %s: %w", c.destBucket, name, err)
+			}
+
+			if exists {
+				ok, err := askConfirmation(fmt.Sprintf("Replace existing file %s > %s", c.destBucket, name), false)
+				fisk.FatalIfError(err, "could not obtain confirmation")
+
+				if !ok {
+					table.AddRow(name, humanize.IBytes(nfo.Size), "skipped existing")
+					continue
+				}
+			}
+		}
+
+		err = c.copyObject(src, dest, nfo, name)
+		if err != nil {
+			return fmt.Errorf("copying %s failed: %w", nfo.Name, err)
+		}
+
+		table.AddRow(name, humanize.IBytes(nfo.Size), "copied")
+		copied++
+	}
+
+	if len(objects) == 0 {
+		fmt.Printf("No objects found in bucket %s\n", c.bucket)
+		return nil
+	}
+
+	fmt.Println(table.Render())
+	fmt.Printf("Copied %s objects\n", f(copied))
+
+	return nil
+}
+
+// objectExists checks if name exists in bucket using its own timeout so it can be called between prompts, errors
+// other than the object not being found are returned
+func objectExists(bucket jetstream.ObjectStore, name string) (bool, error) {
+	tctx, cancel := context.WithTimeout(ctx, opts().Timeout)
+	defer cancel()
+
+	nfo, err := bucket.GetInfo(tctx, name)
+	switch {
+	case errors.Is(err, jetstream.ErrObjectNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return !nfo.Deleted, nil
+}
+
+// copyObject copies a single object between buckets and verifies the digest of the copy
+func (c *objCommand) copyObject(src jetstream.ObjectStore, dest jetstream.ObjectStore, nfo *jetstream.ObjectInfo, name string) error {
+	res, err := src.Get(ctx, nfo.Name)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	meta := jetstream.ObjectMeta{
+		Name:        name,
+		Description: nfo.Description,
+		Headers:     nfo.Headers,
+		Metadata:    nfo.Metadata,
+	}
+	if nfo.Opts != nil && nfo.Opts.ChunkSize > 0 {
+		meta.Opts = &jetstream.ObjectMetaOptions{ChunkSize: nfo.Opts.ChunkSize}
+	}
+
+	copied, err := dest.Put(ctx, meta, res)
+	if err != nil {
+		return err
+	}
+
+	if copied.Digest != nfo.Digest {
+		return fmt.Errorf("digest mismatch, expected %s got %s", nfo.Digest, copied.Digest)
+	}
+
+	return nil
+}
+
 func (c *objCommand) sealAction(_ *fisk.ParseContext) error {
 	if !c.force {
 		ok, err := askConfirmation(fmt.Sprintf("Really seal Bucket %s, sealed buckets can not be unsealed or modified", c.bucket), false)
@@ -343,7 +574,6 @@ func (c *objCommand) showBucketInfo(store jetstream.ObjectStore) error {
 
 func (c *objCommand) showObjectInfo(nfo *jetstream.ObjectInfo) {
 	digest := strings.SplitN(nfo.Digest, "=", 2)
-	digestBytes, _ := base64.URLEncoding.DecodeString(digest[1])
 
 	cols := newColumns(fmt.Sprintf("Object information for %s > %s", nfo.Bucket, nfo.Name))
 	defer cols.Frender(os.Stdout)
@@ -354,7 +584,17 @@ func (c *objCommand) showObjectInfo(nfo *jetstream.ObjectInfo) {
 	cols.AddRow("Size", fiBytes(nfo.Size))
 	cols.AddRow("Modification Time", nfo.ModTime)
 	cols.AddRow("Chunks", nfo.Chunks)
-	cols.AddRowf("Digest", "%s %x", digest[0], digestBytes)
+	if nfo.Opts != nil && nfo.Opts.Link != nil {
+		if nfo.Opts.Link.Name == "" {
+			cols.AddRowf("Link", "bucket %s", nfo.Opts.Link.Bucket)
+		} else {
+			cols.AddRowf("Link", "%s > %s", nfo.Opts.Link.Bucket, nfo.Opts.Link.Name)
+		}
+	}
+	if len(digest) == 2 {
+		digestBytes, _ := base64.URLEncoding.DecodeString(digest[1])
+		cols.AddRowf("Digest", "%s %x", digest[0], digestBytes)
+	}
 	cols.AddRowIf("Deleted", nfo.Deleted, nfo.Deleted)
 	if len(nfo.Headers) > 0 {
 		var vals []string
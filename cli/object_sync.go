@@ -0,0 +1,419 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/dustin/go-humanize"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nuid"
+)
+
+const (
+	objectSyncBoth     = "both"
+	objectSyncUpload   = "upload"
+	objectSyncDownload = "download"
+
+	// objectSyncTempPrefix prefixes incomplete downloads, they are ignored when syncing
+	objectSyncTempPrefix = ".nats-sync-"
+)
+
+// objectSyncFile is the state of a file or object being synchronized
+type objectSyncFile struct {
+	digest  string
+	size    uint64
+	modTime time.Time
+}
+
+// objectSyncCached is a local file digest that is reused while the file size and modification time are unchanged
+type objectSyncCached struct {
+	size    int64
+	modTime time.Time
+	digest  string
+}
+
+func configureObjectSyncCommand(obj *fisk.CmdClause, c *objCommand) {
+	objSync := obj.Command("sync", "Synchronizes a local directory with a bucket").Action(c.syncAction)
+	objSync.HelpLong(`Compares the digests of files in a directory with the objects in a bucket and
+only transfers those that differ. Files are stored using their path relative to
+the directory as object name.
+
+When syncing in both directions and a file and object differ the most recently
+modified one is kept. Deletes are not synchronized and links are ignored, as are
+objects with names that are not clean relative paths or that start with the
+.nats-sync- prefix used for incomplete downloads.
+
+Downloads are written to temporary files that are renamed once complete, an
+interrupted sync resumes by transferring only the remaining changes.
+
+   nats object sync ./artifacts ARTIFACTS
+   nats object sync ./artifacts ARTIFACTS --direction download --watch`)
+	objSync.Arg("directory", "The local directory to synchronize").Required().StringVar(&c.syncDir)
+	objSync.Arg("bucket", "The bucket to synchronize").Required().StringVar(&c.bucket)
+	objSync.Flag("direction", "Which way to synchronize changes (both, upload, download)").Default(objectSyncBoth).EnumVar(&c.syncDirection, objectSyncBoth, objectSyncUpload, objectSyncDownload)
+	objSync.Flag("watch", "Continuously synchronize changes").UnNegatableBoolVar(&c.syncWatch)
+	objSync.Flag("interval", "How often to check for changes when watching").Default("10s").PlaceHolder("DURATION").DurationVar(&c.syncInterval)
+	objSync.Flag("dry-run", "Show the changes that would be made without transferring anything").UnNegatableBoolVar(&c.dryRun)
+}
+
+// objectSyncPlan determines the names of files to upload and objects to download to synchronize local and remote
+func objectSyncPlan(local map[string]objectSyncFile, remote map[string]objectSyncFile, direction string) (uploads []string, downloads []string) {
+	canUpload := direction != objectSyncDownload
+	canDownload := direction != objectSyncUpload
+
+	for name, l := range local {
+		r, ok := remote[name]
+		switch {
+		case !ok:
+			if canUpload {
+				uploads = append(uploads, name)
+			}
+		case l.digest == r.digest:
+		case !canDownload:
+			uploads = append(uploads, name)
+		case !canUpload:
+			downloads = append(downloads, name)
+		case r.modTime.After(l.modTime):
+			downloads = append(downloads, name)
+		default:
+			uploads = append(uploads, name)
+		}
+	}
+
+	if canDownload {
+		for name := range remote {
+			if _, ok := local[name]; !ok {
+				downloads = append(downloads, name)
+			}
+		}
+	}
+
+	sort.Strings(uploads)
+	sort.Strings(downloads)
+
+	return uploads, downloads
+}
+
+// objectSyncName checks that the object name is the same name a local file would be uploaded as, others would never be in sync
+func objectSyncName(name string) error {
+	if name == "" || name == "." || path.Clean(name) != name || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return fmt.Errorf("object name %q is not a clean relative path", name)
+	}
+
+	if strings.HasPrefix(path.Base(name), objectSyncTempPrefix) {
+		return fmt.Errorf("object name %q uses the reserved %s prefix", name, objectSyncTempPrefix)
+	}
+
+	return nil
+}
+
+// objectSyncPath is the local path in dir for the object name, objects that can not be synchronized are refused
+func objectSyncPath(dir string, name string) (string, error) {
+	err := objectSyncName(name)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(name)), nil
+}
+
+// localSyncState digests the regular files in c.syncDir keyed by their object name
+func (c *objCommand) localSyncState() (map[string]objectSyncFile, error) {
+	if c.syncCache == nil {
+		c.syncCache = make(map[string]objectSyncCached)
+	}
+
+	state := make(map[string]objectSyncFile)
+
+	err := filepath.WalkDir(c.syncDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() || strings.HasPrefix(d.Name(), objectSyncTempPrefix) {
+			return nil
+		}
+
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(c.syncDir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+
+		cached, ok := c.syncCache[name]
+		if !ok || cached.size != stat.Size() || !cached.modTime.Equal(stat.ModTime()) {
+			digest, err := objectSyncDigest(path)
+			if err != nil {
+				return err
+			}
+
+			cached = objectSyncCached{size: stat.Size(), modTime: stat.ModTime(), digest: digest}
+			c.syncCache[name] = cached
+		}
+
+		state[name] = objectSyncFile{digest: cached.digest, size: uint64(stat.Size()), modTime: stat.ModTime()}
+
+		return nil
+	})
+
+	return state, err
+}
+
+// objectSyncDigest is the digest of the file at path in the format used by the object store
+func objectSyncDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+
+	return jetstream.GetObjectDigestValue(h), nil
+}
+
+// remoteSyncState lists the objects in the bucket keyed by name, links are not synchronized and objects with names
+// that can not be synchronized are logged once and skipped
+func (c *objCommand) remoteSyncState(obj jetstream.ObjectStore) (map[string]objectSyncFile, error) {
+	if c.syncSkipped == nil {
+		c.syncSkipped = make(map[string]bool)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts().Timeout)
+	defer cancel()
+
+	state := make(map[string]objectSyncFile)
+
+	list, err := obj.List(ctx)
+	if errors.Is(err, jetstream.ErrNoObjectsFound) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nfo := range list {
+		if nfo.Deleted || (nfo.Opts != nil && nfo.Opts.Link != nil) {
+			continue
+		}
+
+		err = objectSyncName(nfo.Name)
+		if err != nil {
+			if !c.syncSkipped[nfo.Name] {
+				log.Printf("Skipping object: %v", err)
+				c.syncSkipped[nfo.Name] = true
+			}
+			continue
+		}
+
+		state[nfo.Name] = objectSyncFile{digest: nfo.Digest, size: nfo.Size, modTime: nfo.ModTime}
+	}
+
+	return state, nil
+}
+
+func (c *objCommand) syncUpload(obj jetstream.ObjectStore, name string) error {
+	f, err := os.Open(filepath.Join(c.syncDir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = obj.Put(ctx, jetstream.ObjectMeta{Name: name}, f)
+
+	return err
+}
+
+func (c *objCommand) syncDownload(obj jetstream.ObjectStore, name string, remote objectSyncFile) error {
+	target, err := objectSyncPath(c.syncDir, name)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	res, err := obj.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	// created like os.Create would so the download gets the usual umask based mode rather than the 0600 of os.CreateTemp
+	tf, err := os.OpenFile(filepath.Join(filepath.Dir(target), objectSyncTempPrefix+nuid.Next()), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tf.Name())
+
+	_, err = io.Copy(tf, res)
+	if err != nil {
+		tf.Close()
+		return err
+	}
+
+	err = tf.Close()
+	if err != nil {
+		return err
+	}
+
+	// replaced files keep their mode as they would when overwritten in place
+	stat, err := os.Stat(target)
+	if err == nil {
+		err = os.Chmod(tf.Name(), stat.Mode().Perm())
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.Rename(tf.Name(), target)
+	if err != nil {
+		return err
+	}
+
+	// keeps the file from being considered newer than the object when syncing in both directions
+	return os.Chtimes(target, remote.modTime, remote.modTime)
+}
+
+// syncOnce performs a single synchronization, failed transfers are logged and retried on the next synchronization
+func (c *objCommand) syncOnce(obj jetstream.ObjectStore) error {
+	local, err := c.localSyncState()
+	if err != nil {
+		return err
+	}
+
+	remote, err := c.remoteSyncState(obj)
+	if err != nil {
+		return err
+	}
+
+	uploads, downloads := objectSyncPlan(local, remote, c.syncDirection)
+	if len(uploads) == 0 && len(downloads) == 0 {
+		if !c.syncWatch {
+			fmt.Printf("%s and bucket %s are in sync\n", c.syncDir, c.bucket)
+		}
+		return nil
+	}
+
+	failed := 0
+
+	for _, name := range uploads {
+		if c.dryRun {
+			fmt.Printf("Would upload %s (%s)\n", name, humanize.IBytes(local[name].size))
+			continue
+		}
+
+		err = c.syncUpload(obj, name)
+		if err != nil {
+			log.Printf("Could not upload %s: %v", name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Uploaded %s (%s)\n", name, humanize.IBytes(local[name].size))
+	}
+
+	for _, name := range downloads {
+		if c.dryRun {
+			fmt.Printf("Would download %s (%s)\n", name, humanize.IBytes(remote[name].size))
+			continue
+		}
+
+		err = c.syncDownload(obj, name, remote[name])
+		if err != nil {
+			log.Printf("Could not download %s: %v", name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Downloaded %s (%s)\n", name, humanize.IBytes(remote[name].size))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d transfers failed", failed, len(uploads)+len(downloads))
+	}
+
+	return nil
+}
+
+func (c *objCommand) syncAction(_ *fisk.ParseContext) error {
+	if c.syncDirection != objectSyncDownload && !c.dryRun {
+		err := checkReadOnly("upload objects")
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.syncWatch && c.syncInterval <= 0 {
+		return fmt.Errorf("--interval must be greater than 0")
+	}
+
+	stat, err := os.Stat(c.syncDir)
+	if err != nil {
+		return err
+	}
+	if !stat.IsDir() {
+		return fmt.Errorf("%s is not a directory", c.syncDir)
+	}
+
+	_, _, obj, err := c.loadBucket()
+	if err != nil {
+		return err
+	}
+
+	err = c.syncOnce(obj)
+	if !c.syncWatch {
+		return err
+	}
+	if err != nil {
+		log.Printf("Synchronization failed: %v", err)
+	}
+
+	ticker := time.NewTicker(c.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err = c.syncOnce(obj)
+			if err != nil {
+				log.Printf("Synchronization failed: %v", err)
+			}
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
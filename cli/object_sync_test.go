@@ -0,0 +1,75 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestObjectSyncPlan(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	now := time.Now()
+
+	local := map[string]objectSyncFile{
+		"same":         {digest: "a", modTime: old},
+		"local-only":   {digest: "b", modTime: old},
+		"local-newer":  {digest: "c", modTime: now},
+		"remote-newer": {digest: "d", modTime: old},
+	}
+	remote := map[string]objectSyncFile{
+		"same":         {digest: "a", modTime: now},
+		"remote-only":  {digest: "e", modTime: old},
+		"local-newer":  {digest: "x", modTime: old},
+		"remote-newer": {digest: "y", modTime: now},
+	}
+
+	uploads, downloads := objectSyncPlan(local, remote, objectSyncBoth)
+	if !cmp.Equal(uploads, []string{"local-newer", "local-only"}) {
+		t.Fatalf("unexpected uploads: %v", uploads)
+	}
+	if !cmp.Equal(downloads, []string{"remote-newer", "remote-only"}) {
+		t.Fatalf("unexpected downloads: %v", downloads)
+	}
+
+	uploads, downloads = objectSyncPlan(local, remote, objectSyncUpload)
+	if !cmp.Equal(uploads, []string{"local-newer", "local-only", "remote-newer"}) || len(downloads) != 0 {
+		t.Fatalf("unexpected upload plan: %v %v", uploads, downloads)
+	}
+
+	uploads, downloads = objectSyncPlan(local, remote, objectSyncDownload)
+	if len(uploads) != 0 || !cmp.Equal(downloads, []string{"local-newer", "remote-newer", "remote-only"}) {
+		t.Fatalf("unexpected download plan: %v %v", uploads, downloads)
+	}
+}
+
+func TestObjectSyncPath(t *testing.T) {
+	path, err := objectSyncPath("dir", "builds/app.tar.gz")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != filepath.Join("dir", "builds", "app.tar.gz") {
+		t.Fatalf("unexpected path: %q", path)
+	}
+
+	for _, name := range []string{"", "../escape", "builds/../../escape", "/etc/passwd", ".", "a/../b", "./a", "a//b", ".nats-sync-1", "builds/.nats-sync-1"} {
+		_, err = objectSyncPath("dir", name)
+		if err == nil {
+			t.Fatalf("expected %q to be refused", name)
+		}
+	}
+}
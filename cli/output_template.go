@@ -0,0 +1,105 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/dustin/go-humanize"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+const outputTemplateHelp = "Renders the output using a Go template held in a file or given as a string"
+
+// addOutputTemplateFlag adds the --template flag to cmd storing the template in tmpl
+func addOutputTemplateFlag(cmd *fisk.CmdClause, tmpl *string) {
+	cmd.Flag("template", outputTemplateHelp).PlaceHolder("FILE|TEMPLATE").StringVar(tmpl)
+}
+
+var outputTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		j, err := json.Marshal(v)
+		return string(j), err
+	},
+	"csv": func(fields ...any) (string, error) {
+		var sb strings.Builder
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			record[i] = fmt.Sprint(field)
+		}
+
+		w := csv.NewWriter(&sb)
+		err := w.Write(record)
+		w.Flush()
+
+		return strings.TrimSuffix(sb.String(), "\n"), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"bytes": func(v uint64) string {
+		return humanize.IBytes(v)
+	},
+	"count": func(v any) string {
+		return f(v)
+	},
+	"ago": func(t time.Time) string {
+		return f(time.Since(t))
+	},
+	"time": func(t time.Time) string {
+		return t.Format(time.RFC3339)
+	},
+}
+
+// loadOutputTemplate parses tmpl which is either a file holding the template or the template itself
+func loadOutputTemplate(tmpl string) (*template.Template, error) {
+	body := tmpl
+	if iu.FileExists(tmpl) {
+		b, err := os.ReadFile(tmpl)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+
+	t, err := template.New("output").Funcs(outputTemplateFuncs).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+
+	return t, nil
+}
+
+// renderOutputTemplateTo renders data through the template tmpl into w
+func renderOutputTemplateTo(w io.Writer, tmpl string, data any) error {
+	t, err := loadOutputTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+
+	return t.Execute(w, data)
+}
+
+// renderOutputTemplate renders data through the template tmpl to stdout
+func renderOutputTemplate(tmpl string, data any) error {
+	return renderOutputTemplateTo(os.Stdout, tmpl, data)
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestRenderOutputTemplate(t *testing.T) {
+	infos := []*api.StreamInfo{
+		{Config: api.StreamConfig{Name: "ORDERS", Subjects: []string{"orders.>"}}, State: api.StreamState{Msgs: 10, Bytes: 2048}},
+		{Config: api.StreamConfig{Name: "a,b"}, State: api.StreamState{Msgs: 1}},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := renderOutputTemplateTo(buf, `{{range .}}{{csv .Config.Name .State.Msgs (bytes .State.Bytes) (join .Config.Subjects " ")}}
+{{end}}`, infos)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	expected := "ORDERS,10,2.0 KiB,orders.>\n\"a,b\",1,0 B,\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q got %q", expected, buf.String())
+	}
+
+	_, err = loadOutputTemplate("{{.Invalid")
+	if err == nil {
+		t.Fatalf("expected invalid templates to fail")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/google/shlex"
+	iu "github.com/nats-io/natscli/internal/util"
+	terminal "golang.org/x/term"
+)
+
+var (
+	pagerExitHook func()
+	pagerMu       sync.Mutex
+)
+
+// pager collects the output of a command and shows it using $PAGER or a basic internal pager when it is taller than
+// the terminal, output is written to stdout unchanged when paging is disabled or not possible
+type pager struct {
+	out    *os.File
+	buf    *bytes.Buffer
+	width  int
+	height int
+	mu     sync.Mutex
+}
+
+// newPager creates a pager for the output of a command, create it after any interactive prompts and close it once all
+// output was written to it
+func newPager() *pager {
+	p := &pager{out: os.Stdout}
+
+	if opts().NoPager || opts().Trace || !iu.IsTerminal() {
+		return p
+	}
+
+	width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil || height < 5 {
+		return p
+	}
+
+	p.width = width
+	p.height = height
+	p.buf = bytes.NewBuffer(nil)
+
+	// commands exiting without returning, like on fatal errors, show their output without paging
+	setPagerExitHook(p.flush)
+
+	return p
+}
+
+func (p *pager) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.buf == nil {
+		return p.out.Write(b)
+	}
+
+	return p.buf.Write(b)
+}
+
+// flush writes the collected output without paging it
+func (p *pager) flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.buf == nil {
+		return
+	}
+
+	p.out.Write(p.buf.Bytes())
+	p.buf = nil
+}
+
+// Close shows the collected output, paging it when it is taller than the terminal
+func (p *pager) Close() error {
+	setPagerExitHook(nil)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.buf == nil {
+		return nil
+	}
+
+	output := p.buf.Bytes()
+	p.buf = nil
+
+	if outputLines(output, p.width) < p.height {
+		_, err := p.out.Write(output)
+		return err
+	}
+
+	err := runPager(output, p.out)
+	if err != nil {
+		_, err = p.out.Write(output)
+	}
+
+	return err
+}
+
+func setPagerExitHook(hook func()) {
+	pagerMu.Lock()
+	pagerExitHook = hook
+	pagerMu.Unlock()
+}
+
+// flushPagedOutput writes output collected by an active pager without paging it
+func flushPagedOutput() {
+	pagerMu.Lock()
+	hook := pagerExitHook
+	pagerExitHook = nil
+	pagerMu.Unlock()
+
+	if hook != nil {
+		hook()
+	}
+}
+
+// outputLines calculates how many terminal lines output takes up allowing for wrapping of long lines
+func outputLines(output []byte, width int) int {
+	lines := 0
+	for _, line := range bytes.Split(bytes.TrimSuffix(output, []byte("\n")), []byte("\n")) {
+		lines++
+		if width > 0 {
+			lines += (utf8.RuneCount(line) - 1) / width
+		}
+	}
+
+	return lines
+}
+
+func runPager(output []byte, stdout *os.File) error {
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = "less -FRX"
+	}
+
+	parts, err := shlex.Split(pager)
+	if err != nil || len(parts) == 0 {
+		return internalPager(output, stdout)
+	}
+
+	path, err := exec.LookPath(parts[0])
+	if err != nil {
+		return internalPager(output, stdout)
+	}
+
+	cmd := exec.Command(path, parts[1:]...)
+	cmd.Stdin = bytes.NewReader(output)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// internalPager shows a screen of output at a time waiting for enter, q stops paging
+func internalPager(output []byte, stdout *os.File) error {
+	_, height, err := terminal.GetSize(int(stdout.Fd()))
+	if err != nil {
+		return err
+	}
+
+	input := bufio.NewReader(os.Stdin)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(output)+1)
+
+	shown := 0
+	for scanner.Scan() {
+		if shown == height-1 {
+			fmt.Fprint(stdout, "-- More -- (enter to continue, q to quit)")
+			answer, _ := input.ReadString('\n')
+			fmt.Fprint(stdout, "\033[1A\033[2K\r")
+			if len(answer) > 0 && (answer[0] == 'q' || answer[0] == 'Q') {
+				return nil
+			}
+			shown = 0
+		}
+
+		fmt.Fprintln(stdout, scanner.Text())
+		shown++
+	}
+
+	return scanner.Err()
+}
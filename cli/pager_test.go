@@ -0,0 +1,38 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+)
+
+func TestOutputLines(t *testing.T) {
+	cases := []struct {
+		output string
+		width  int
+		expect int
+	}{
+		{"one\ntwo\n", 80, 2},
+		{"one\n\nthree", 80, 3},
+		{"0123456789\n", 5, 2},
+		{"01234567890\n", 5, 3},
+	}
+
+	for _, tc := range cases {
+		lines := outputLines([]byte(tc.output), tc.width)
+		if lines != tc.expect {
+			t.Fatalf("expected %d lines for %q got %d", tc.expect, tc.output, lines)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/choria-io/fisk"
+	"github.com/fatih/color"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// payloadSchema validates message payloads against a user supplied JSON Schema and counts the results
+type payloadSchema struct {
+	schema      *jsonschema.Schema
+	invalidOnly bool
+	valid       atomic.Uint64
+	invalid     atomic.Uint64
+}
+
+// addPayloadSchemaFlags adds the flags used to validate received payloads against a JSON Schema
+func addPayloadSchemaFlags(cmd *fisk.CmdClause, file *string, invalidOnly *bool) {
+	cmd.Flag("validate-schema", "Validates message payloads against a JSON Schema").PlaceHolder("FILE").ExistingFileVar(file)
+	cmd.Flag("invalid-only", "Only show messages that fail --validate-schema").UnNegatableBoolVar(invalidOnly)
+}
+
+// newPayloadSchema loads the JSON Schema in file, returns nil when file is empty
+func newPayloadSchema(file string, invalidOnly bool) (*payloadSchema, error) {
+	if file == "" {
+		if invalidOnly {
+			return nil, fmt.Errorf("--invalid-only requires --validate-schema")
+		}
+
+		return nil, nil
+	}
+
+	sch, err := jsonschema.Compile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not load schema %s: %w", file, err)
+	}
+
+	return &payloadSchema{schema: sch, invalidOnly: invalidOnly}, nil
+}
+
+// validate checks data against the schema and describes the failures, empty when valid
+func (p *payloadSchema) validate(data []byte) []string {
+	var d any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	err := dec.Decode(&d)
+	if err != nil {
+		return []string{fmt.Sprintf("payload is not valid JSON: %s", err)}
+	}
+
+	err = p.schema.Validate(d)
+	if err != nil {
+		return schemaValidationErrors(err)
+	}
+
+	return nil
+}
+
+// inspect validates the payload of a message received on subject, showing any failures, and reports if the message should be shown
+func (p *payloadSchema) inspect(subject string, data []byte) bool {
+	errs := p.validate(data)
+	if len(errs) == 0 {
+		p.valid.Add(1)
+		return !p.invalidOnly
+	}
+
+	p.invalid.Add(1)
+	log.Printf("%s", color.RedString("Invalid payload received on subject %s", subject))
+	for _, e := range errs {
+		log.Printf("%s", color.RedString("   %s", e))
+	}
+
+	return true
+}
+
+// render shows the validation counts, nothing is shown for a nil payloadSchema
+func (p *payloadSchema) render(w io.Writer) {
+	if p == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "Validated %s messages against the schema, %s were invalid\n", f(p.valid.Load()+p.invalid.Load()), f(p.invalid.Load()))
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPayloadSchema(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "schema.json")
+	err := os.WriteFile(file, []byte(`{"type":"object","required":["id"],"properties":{"id":{"type":"integer"}}}`), 0600)
+	if err != nil {
+		t.Fatalf("could not write schema: %v", err)
+	}
+
+	_, err = newPayloadSchema("", true)
+	if err == nil {
+		t.Fatalf("expected --invalid-only without a schema to fail")
+	}
+
+	schema, err := newPayloadSchema(file, true)
+	if err != nil {
+		t.Fatalf("could not load schema: %v", err)
+	}
+
+	if errs := schema.validate([]byte(`{"id":10}`)); len(errs) != 0 {
+		t.Fatalf("expected valid payload: %v", errs)
+	}
+
+	if errs := schema.validate([]byte(`{"id":"10"}`)); len(errs) != 1 {
+		t.Fatalf("expected one validation error: %v", errs)
+	}
+
+	if errs := schema.validate([]byte(`not json`)); len(errs) != 1 || !strings.HasPrefix(errs[0], "payload is not valid JSON") {
+		t.Fatalf("expected a JSON error: %v", errs)
+	}
+}
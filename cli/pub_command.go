@@ -14,10 +14,13 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	iu "github.com/nats-io/natscli/internal/util"
@@ -25,13 +28,16 @@ import (
 	"github.com/choria-io/fisk"
 	"github.com/jedib0t/go-pretty/v6/progress"
 	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nuid"
 	terminal "golang.org/x/term"
 )
 
 type pubCmd struct {
 	subject      string
 	body         string
+	bodyIsSet    bool
 	req          bool
 	replyTo      string
 	raw          bool
@@ -42,9 +48,32 @@ type pubCmd struct {
 	replyTimeout time.Duration
 	forceStdin   bool
 	translate    string
+	decoders     []string
 	jetstream    bool
+	file         string
+	chunkSizeS   string
+	chunkSize    int64
+	msgId        string
+	expectStream string
+	expectSeq    uint64
+	expectSeqSet bool
+	expectReply  bool
+
+	expectReplyTimeout time.Duration
+	expectSubjSeq      uint64
+	expectSubjSeqSet   bool
+	ackWait            time.Duration
+	ackWindow          int
+	retries            int
+	retryWait          time.Duration
 }
 
+const (
+	chunkIdHeader    = "Nats-Chunk-Id"
+	chunkSeqHeader   = "Nats-Chunk-Sequence"
+	chunkTotalHeader = "Nats-Chunk-Total"
+)
+
 func configurePubCommand(app commandHost) {
 	c := &pubCmd{}
 
@@ -66,19 +95,58 @@ Available template functions are:
    Time             the current time
    ID               an unique ID
    Random(min, max) random string at least min long, at most max
+
+Payloads can be read from a file or STDIN and split into multiple
+messages, each chunk carries the Nats-Chunk-Id, Nats-Chunk-Sequence
+and Nats-Chunk-Total headers so receivers can reassemble them:
+
+   nats pub test --file data.bin --chunk-size 64KB
+   cat data.bin | nats pub test --force-stdin --chunk-size 64KB
+
+Templates are not applied to the body when reading from a file or
+when chunking.
+
+When publishing to JetStream the Nats-Msg-Id header can be set using
+the same templates to test deduplication, and expected stream and last
+sequence headers can be set to test optimistic concurrency control:
+
+   nats pub orders.new --jetstream --msg-id "order-{{Count}}" --count 10
+   nats pub orders.new --expect-stream ORDERS --expect-last-seq 10
+
+The expected last sequence is updated from the acknowledgement after
+every message so it can be combined with --count.
+
+Publishes that find no JetStream responders, for example during a
+leader election, are retried with an increasing backoff and the
+latency of every acknowledged publish is shown. When publishing many
+messages acknowledgements can be awaited asynchronously in a window:
+
+   nats pub orders.new --jetstream --count 100000 --ack-window 500
 `
 
 	pub := app.Command("publish", "Generic data publish utility").Alias("pub").Action(c.publish)
 	addCheat("pub", pub)
 	pub.HelpLong(pubHelp)
 	pub.Arg("subject", "Subject to publish to").Required().StringVar(&c.subject)
-	pub.Arg("body", "Message body").Default("!nil!").StringVar(&c.body)
+	pub.Arg("body", "Message body").PreAction(c.setBodyIsSet).StringVar(&c.body)
 	pub.Flag("reply", "Sets a custom reply to subject").StringVar(&c.replyTo)
 	pub.Flag("header", "Adds headers to the message using K:V format").Short('H').StringsVar(&c.hdrs)
 	pub.Flag("count", "Publish multiple messages").Default("1").IntVar(&c.cnt)
 	pub.Flag("sleep", "When publishing multiple messages, sleep between publishes").DurationVar(&c.sleep)
 	pub.Flag("force-stdin", "Force reading from stdin").UnNegatableBoolVar(&c.forceStdin)
+	pub.Flag("file", "Reads the message body from a file").PlaceHolder("FILE").ExistingFileVar(&c.file)
+	pub.Flag("chunk-size", "Splits the body into multiple messages of this size").PlaceHolder("BYTES").StringVar(&c.chunkSizeS)
 	pub.Flag("jetstream", "Publish messages to jetstream").Short('J').UnNegatableBoolVar(&c.jetstream)
+	pub.Flag("msg-id", "Sets the Nats-Msg-Id header for JetStream deduplication, supports templates").PlaceHolder("TEMPLATE").StringVar(&c.msgId)
+	pub.Flag("expect-stream", "Only store the message if it is received by this Stream").PlaceHolder("STREAM").StringVar(&c.expectStream)
+	pub.Flag("expect-reply", "Sets a reply subject and verifies that a reply is received, exits with code 2 when replies are missing").UnNegatableBoolVar(&c.expectReply)
+	pub.Flag("reply-timeout", "How long to wait for each reply when using --expect-reply").Default("5s").DurationVar(&c.expectReplyTimeout)
+	pub.Flag("expect-last-seq", "Only store the message if this is the last sequence in the Stream").PlaceHolder("SEQ").IsSetByUser(&c.expectSeqSet).Uint64Var(&c.expectSeq)
+	pub.Flag("expect-last-subject-seq", "Only store the message if this is the last sequence for the subject in the Stream").PlaceHolder("SEQ").IsSetByUser(&c.expectSubjSeqSet).Uint64Var(&c.expectSubjSeq)
+	pub.Flag("ack-wait", "How long to wait for JetStream acknowledgements, defaults to the connection timeout").PlaceHolder("DURATION").DurationVar(&c.ackWait)
+	pub.Flag("ack-window", "How many JetStream acknowledgements may be outstanding when publishing multiple messages").Default("1").IntVar(&c.ackWindow)
+	pub.Flag("retries", "How many times to retry JetStream publishes that have no responders").Default("2").IntVar(&c.retries)
+	pub.Flag("retry-wait", "Initial time to wait between JetStream publish retries, doubles on every retry").Default("250ms").DurationVar(&c.retryWait)
 
 	requestHelp := `Body and Header values of the messages may use Go templates to 
 create unique messages.
@@ -103,7 +171,7 @@ Available template functions are:
 	req := app.Command("request", "Generic request-reply request utility").Alias("req").Action(c.publish)
 	req.HelpLong(requestHelp)
 	req.Arg("subject", "Subject to subscribe to").Required().StringVar(&c.subject)
-	req.Arg("body", "Message body").Default("!nil!").StringVar(&c.body)
+	req.Arg("body", "Message body").PreAction(c.setBodyIsSet).StringVar(&c.body)
 	req.Flag("wait", "Wait for a reply from a service").Short('w').Default("true").Hidden().BoolVar(&c.req)
 	req.Flag("raw", "Show just the output received").Short('r').UnNegatableBoolVar(&c.raw)
 	req.Flag("header", "Adds headers to the message using K:V format").Short('H').StringsVar(&c.hdrs)
@@ -111,18 +179,56 @@ Available template functions are:
 	req.Flag("replies", "Wait for multiple replies from services. 0 waits until timeout").Default("1").IntVar(&c.replyCount)
 	req.Flag("reply-timeout", "Maximum timeout between incoming replies.").Default("300ms").DurationVar(&c.replyTimeout)
 	req.Flag("translate", "Translate the message data by running it through the given command before output").StringVar(&c.translate)
+	req.Flag("decode", fmt.Sprintf("Decodes the message data before output, can be repeated to decode in order (%s)", strings.Join(payloadDecoders, ", "))).PlaceHolder("DECODER").EnumsVar(&c.decoders, payloadDecoders...)
 }
 
 func init() {
 	registerCommand("pub", 11, configurePubCommand)
 }
 
+// setBodyIsSet records that a body was given on the command line, an empty body is valid
+func (c *pubCmd) setBodyIsSet(_ *fisk.ParseContext) error {
+	c.bodyIsSet = true
+	return nil
+}
+
 func (c *pubCmd) prepareMsg(subj string, body []byte, seq int) (*nats.Msg, error) {
+	// subjects rendered from templates are only known here, refuse them before sending rather than on the connection
+	err := checkReadOnlySubject(subj)
+	if err != nil {
+		return nil, err
+	}
+
 	msg := nats.NewMsg(subj)
 	msg.Reply = c.replyTo
 	msg.Data = body
 
-	return msg, parseStringsToMsgHeader(c.hdrs, seq, msg)
+	err = parseStringsToMsgHeader(c.hdrs, seq, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.msgId != "" {
+		id, err := pubReplyBodyTemplate(c.msgId, "", seq)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse message id template: %w", err)
+		}
+		msg.Header.Set(api.JSMsgId, string(id))
+	}
+
+	if c.expectStream != "" {
+		msg.Header.Set(api.JSExpectedStream, c.expectStream)
+	}
+
+	if c.expectSeqSet {
+		msg.Header.Set(api.JSExpectedLastSeq, strconv.FormatUint(c.expectSeq, 10))
+	}
+
+	if c.expectSubjSeqSet {
+		msg.Header.Set(api.JSExpectedLastSubjSeq, strconv.FormatUint(c.expectSubjSeq, 10))
+	}
+
+	return msg, nil
 }
 
 func (c *pubCmd) doReq(nc *nats.Conn, progress *progress.Tracker) error {
@@ -193,7 +299,7 @@ func (c *pubCmd) doReq(nc *nats.Conn, progress *progress.Tracker) error {
 
 			switch {
 			case c.raw:
-				outPutMSGBody(m.Data, c.translate, m.Subject, "")
+				outPutMSGBody(decodedMsg(m, c.decoders).Data, c.translate, m.Subject, "")
 			case logOutput:
 				log.Printf("Received with rtt %v", rtt)
 
@@ -206,7 +312,7 @@ func (c *pubCmd) doReq(nc *nats.Conn, progress *progress.Tracker) error {
 					fmt.Println()
 				}
 
-				outPutMSGBody(m.Data, c.translate, m.Subject, "")
+				outPutMSGBody(decodedMsg(m, c.decoders).Data, c.translate, m.Subject, "")
 			}
 
 			rc++
@@ -259,34 +365,252 @@ func (c *pubCmd) doJetstream(nc *nats.Conn, progress *progress.Tracker) error {
 
 		msg.Subject = string(subj)
 
-		resp, err := nc.RequestMsg(msg, opts().Timeout)
+		ack, latency, err := c.jsPublish(nc, msg)
 		if err != nil {
 			return err
 		}
 
+		if progress != nil {
+			progress.Increment(1)
+		} else {
+			c.showPubAck(ack, latency)
+		}
+
+		// If applicable, account for the wait duration in a publish sleep.
+		if c.cnt > 1 && c.sleep > 0 {
+			st := c.sleep - time.Since(start)
+			if st > 0 {
+				time.Sleep(st)
+			}
+		}
+	}
+
+	return nil
+}
+
+// pubRetryBackoff is the time to wait before retry number attempt, starting at wait and doubling on every attempt up to a minute
+func pubRetryBackoff(wait time.Duration, attempt int) time.Duration {
+	backoff := wait
+	for i := 0; i < attempt && backoff < time.Minute; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, time.Minute)
+}
+
+// jsPublish publishes msg to JetStream and waits for the acknowledgement, retrying when there are no responders
+func (c *pubCmd) jsPublish(nc *nats.Conn, msg *nats.Msg) (*api.PubAck, time.Duration, error) {
+	wait := c.ackWait
+	if wait <= 0 {
+		wait = opts().Timeout
+	}
+
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		resp, err := nc.RequestMsg(msg, wait)
+		latency := time.Since(start)
+
+		switch {
+		case errors.Is(err, nats.ErrNoResponders) && attempt < c.retries:
+			backoff := pubRetryBackoff(c.retryWait, attempt)
+			log.Printf("No JetStream responders for %q, retrying in %v", msg.Subject, backoff)
+			time.Sleep(backoff)
+			continue
+
+		case errors.Is(err, nats.ErrTimeout):
+			return nil, latency, fmt.Errorf("no acknowledgement received within %v", wait)
+
+		case err != nil:
+			return nil, latency, err
+		}
+
+		if opts().Trace {
+			fmt.Printf("<<< %+v\n", string(resp.Data))
+		}
+
 		ack, err := jsm.ParsePubAck(resp)
+		if err != nil {
+			return nil, latency, err
+		}
+
+		c.expectSeq = ack.Sequence
+		c.expectSubjSeq = ack.Sequence
+
+		return ack, latency, nil
+	}
+}
+
+func (c *pubCmd) showPubAck(ack *api.PubAck, latency time.Duration) {
+	fmt.Printf(">>> Stream: %s Sequence: %s", ack.Stream, f(ack.Sequence))
+	if ack.Domain != "" {
+		fmt.Printf(" Domain: %q", ack.Domain)
+	}
+	if ack.Duplicate {
+		fmt.Printf(" Duplicate: true")
+	}
+	if latency > 0 {
+		fmt.Printf(" Latency: %v", latency.Round(time.Microsecond))
+	}
+	fmt.Println()
+}
+
+// doJetstreamWindowed publishes to JetStream asynchronously allowing up to c.ackWindow acknowledgements to be outstanding
+func (c *pubCmd) doJetstreamWindowed(nc *nats.Conn, progress *progress.Tracker) error {
+	wait := c.ackWait
+	if wait <= 0 {
+		wait = opts().Timeout
+	}
+
+	js, err := nc.JetStream(nats.PublishAsyncMaxPending(c.ackWindow))
+	if err != nil {
+		return err
+	}
+
+	var pending []nats.PubAckFuture
+
+	// the oldest outstanding acknowledgement is awaited so pending is in publish order
+	awaitOldest := func() error {
+		future := pending[0]
+		pending = pending[1:]
+
+		select {
+		case pa := <-future.Ok():
+			ack := &api.PubAck{Stream: pa.Stream, Sequence: pa.Sequence, Domain: pa.Domain, Duplicate: pa.Duplicate}
+			if progress != nil {
+				progress.Increment(1)
+			} else {
+				c.showPubAck(ack, 0)
+			}
+
+			return nil
+
+		case err := <-future.Err():
+			return fmt.Errorf("publish to %q failed: %w", future.Msg().Subject, err)
+
+		case <-time.After(wait):
+			return fmt.Errorf("no acknowledgement received within %v", wait)
+		}
+	}
+
+	start := time.Now()
+
+	for i := 1; i <= c.cnt; i++ {
+		body, err := pubReplyBodyTemplate(c.body, "", i)
+		if err != nil {
+			log.Printf("Could not parse body template: %s", err)
+		}
+
+		subj, err := pubReplyBodyTemplate(c.subject, "", i)
+		if err != nil {
+			log.Printf("Could not parse subject template: %s", err)
+		}
+
+		msg, err := c.prepareMsg(string(subj), body, i)
 		if err != nil {
 			return err
 		}
 
-		if opts().Trace {
-			fmt.Printf("<<< %+v\n", string(resp.Data))
+		future, err := js.PublishMsgAsync(msg, nats.RetryAttempts(c.retries), nats.RetryWait(c.retryWait))
+		if err != nil {
+			return err
 		}
+		pending = append(pending, future)
 
-		if progress != nil {
-			progress.Increment(1)
-		} else {
-			fmt.Printf(">>> Stream: %s Sequence: %s", ack.Stream, f(ack.Sequence))
-			if ack.Domain != "" {
-				fmt.Printf(" Domain: %q", ack.Domain)
+		if len(pending) >= c.ackWindow {
+			err = awaitOldest()
+			if err != nil {
+				return err
+			}
+		}
+
+		if c.sleep > 0 {
+			time.Sleep(c.sleep)
+		}
+	}
+
+	for len(pending) > 0 {
+		err = awaitOldest()
+		if err != nil {
+			return err
+		}
+	}
+
+	if progress == nil {
+		elapsed := time.Since(start)
+		log.Printf("Published %s messages in %v (%s msgs/sec)", f(c.cnt), elapsed.Round(time.Millisecond), f(float64(c.cnt)/elapsed.Seconds()))
+	}
+
+	return nil
+}
+
+// doExpectReply publishes messages with a reply subject and verifies a reply is received for each, requesting exit code 2 when any are missing
+func (c *pubCmd) doExpectReply(nc *nats.Conn, progress *progress.Tracker) error {
+	missing := 0
+
+	for i := 1; i <= c.cnt; i++ {
+		body, err := pubReplyBodyTemplate(c.body, "", i)
+		if err != nil {
+			log.Printf("Could not parse body template: %s", err)
+		}
+
+		subj, err := pubReplyBodyTemplate(c.subject, "", i)
+		if err != nil {
+			log.Printf("Could not parse subject template: %s", err)
+		}
+
+		msg, err := c.prepareMsg(string(subj), body, i)
+		if err != nil {
+			return err
+		}
+
+		msg.Reply = nc.NewRespInbox()
+
+		sub, err := nc.SubscribeSync(msg.Reply)
+		if err != nil {
+			return err
+		}
+		sub.AutoUnsubscribe(1)
+
+		start := time.Now()
+		err = nc.PublishMsg(msg)
+		if err != nil {
+			return err
+		}
+
+		reply, err := sub.NextMsg(c.expectReplyTimeout)
+		rtt := time.Since(start)
+
+		switch {
+		case errors.Is(err, nats.ErrTimeout):
+			missing++
+			if progress == nil {
+				log.Printf("Published %d bytes to %q, no reply received within %v", len(body), msg.Subject, c.expectReplyTimeout)
 			}
-			if ack.Duplicate {
-				fmt.Printf(" Duplicate: true")
+
+		case err != nil:
+			return err
+
+		case reply.Header.Get("Status") == "503":
+			missing++
+			if progress == nil {
+				log.Printf("Published %d bytes to %q, no responders are available", len(body), msg.Subject)
+			}
+
+		case progress == nil:
+			log.Printf("Published %d bytes to %q, received %d bytes reply with rtt %v", len(body), msg.Subject, len(reply.Data), rtt)
+			for h, vals := range reply.Header {
+				for _, val := range vals {
+					log.Printf("%s: %s", h, val)
+				}
 			}
-			fmt.Println()
 		}
 
-		// If applicable, account for the wait duration in a publish sleep.
+		sub.Unsubscribe()
+
+		if progress != nil {
+			progress.Increment(1)
+		}
+
 		if c.cnt > 1 && c.sleep > 0 {
 			st := c.sleep - time.Since(start)
 			if st > 0 {
@@ -295,10 +619,112 @@ func (c *pubCmd) doJetstream(nc *nats.Conn, progress *progress.Tracker) error {
 		}
 	}
 
+	if missing > 0 {
+		return &ExitCodeError{Code: 2, Err: fmt.Errorf("%d of %d messages did not receive a reply", missing, c.cnt)}
+	}
+
+	return nil
+}
+
+// doChunked publishes body without template processing, split into chunks of c.chunkSize when set
+func (c *pubCmd) doChunked(nc *nats.Conn, body []byte) error {
+	if c.cnt != 1 {
+		return fmt.Errorf("--count cannot be used when publishing from a file or in chunks")
+	}
+
+	size := int64(len(body))
+	if c.chunkSize > 0 && c.chunkSize < size {
+		size = c.chunkSize
+	}
+
+	chunks := 1
+	if size > 0 {
+		chunks = int(math.Ceil(float64(len(body)) / float64(size)))
+	}
+
+	var tracker *progress.Tracker
+	var progbar progress.Writer
+	var err error
+
+	if chunks > 20 {
+		progbar, tracker, err = iu.NewProgress(opts(), &progress.Tracker{
+			Total: int64(chunks),
+		})
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			progbar.Stop()
+			time.Sleep(300 * time.Millisecond)
+		}()
+	}
+
+	id := nuid.Next()
+
+	for i := 0; i < chunks; i++ {
+		start := int64(i) * size
+		end := min(start+size, int64(len(body)))
+
+		msg, err := c.prepareMsg(c.subject, body[start:end], i+1)
+		if err != nil {
+			return err
+		}
+
+		if chunks > 1 {
+			msg.Header.Set(chunkIdHeader, id)
+			msg.Header.Set(chunkSeqHeader, strconv.Itoa(i+1))
+			msg.Header.Set(chunkTotalHeader, strconv.Itoa(chunks))
+		}
+
+		if c.jetstream {
+			_, _, err = c.jsPublish(nc, msg)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = nc.PublishMsg(msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		if tracker != nil {
+			tracker.Increment(1)
+		}
+
+		if chunks > 1 && c.sleep > 0 {
+			time.Sleep(c.sleep)
+		}
+	}
+
+	err = nc.Flush()
+	if err != nil {
+		return err
+	}
+
+	err = nc.LastError()
+	if err != nil {
+		return err
+	}
+
+	if progbar == nil {
+		if chunks > 1 {
+			log.Printf("Published %d bytes in %d chunks to %q\n", len(body), chunks, c.subject)
+		} else {
+			log.Printf("Published %d bytes to %q\n", len(body), c.subject)
+		}
+	}
+
 	return nil
 }
 
 func (c *pubCmd) publish(_ *fisk.ParseContext) error {
+	err := checkReadOnlySubject(c.subject)
+	if err != nil {
+		return err
+	}
+
 	nc, err := newNatsConn("", natsOpts()...)
 	if err != nil {
 		return err
@@ -309,15 +735,86 @@ func (c *pubCmd) publish(_ *fisk.ParseContext) error {
 		c.cnt = math.MaxInt16
 	}
 
-	if c.body == "!nil!" && (terminal.IsTerminal(int(os.Stdout.Fd())) || c.forceStdin) {
+	if c.msgId != "" || c.expectStream != "" || c.expectSeqSet || c.expectSubjSeqSet {
+		c.jetstream = true
+	}
+
+	if c.ackWindow < 1 {
+		return fmt.Errorf("--ack-window must be at least 1")
+	}
+
+	if c.ackWindow > 1 && (c.expectSeqSet || c.expectSubjSeqSet) {
+		return fmt.Errorf("--ack-window can not be used with expected last sequences")
+	}
+
+	// the acknowledged sequence is carried to the next message, which is only valid when every message has the same subject
+	if c.expectSubjSeqSet && c.cnt > 1 && strings.Contains(c.subject, "{{") {
+		return fmt.Errorf("--expect-last-subject-seq can not be used with subject templates when publishing multiple messages")
+	}
+
+	if c.jetstream {
+		err = checkReadOnly("publish to JetStream")
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.expectReply {
+		switch {
+		case c.jetstream:
+			return fmt.Errorf("--expect-reply can not be used when publishing to JetStream")
+		case c.replyTo != "":
+			return fmt.Errorf("--expect-reply can not be used with a custom reply subject")
+		case c.file != "" || c.chunkSizeS != "":
+			return fmt.Errorf("--expect-reply can not be used when publishing from a file or in chunks")
+		}
+	}
+
+	if c.chunkSizeS != "" {
+		c.chunkSize, err = parseStringAsBytes(c.chunkSizeS)
+		if err != nil {
+			return err
+		}
+		if c.chunkSize <= 0 {
+			return fmt.Errorf("chunk size must be greater than 0")
+		}
+	}
+
+	if c.file != "" {
+		if c.bodyIsSet {
+			return fmt.Errorf("cannot specify both a message body and --file")
+		}
+
+		body, err := os.ReadFile(c.file)
+		if err != nil {
+			return err
+		}
+
+		return c.doChunked(nc, body)
+	}
+
+	if !c.bodyIsSet && (terminal.IsTerminal(int(os.Stdout.Fd())) || c.forceStdin) {
 		log.Println("Reading payload from STDIN")
 		body, err := io.ReadAll(os.Stdin)
 		if err != nil {
 			return err
 		}
+
+		if c.chunkSize > 0 {
+			return c.doChunked(nc, body)
+		}
+
 		c.body = string(body)
 	}
 
+	if c.chunkSize > 0 {
+		if !c.bodyIsSet {
+			return fmt.Errorf("a message body, --file or --force-stdin is required when publishing in chunks")
+		}
+
+		return c.doChunked(nc, []byte(c.body))
+	}
+
 	var tracker *progress.Tracker
 	var progbar progress.Writer
 
@@ -335,10 +832,18 @@ func (c *pubCmd) publish(_ *fisk.ParseContext) error {
 		}()
 	}
 
+	if c.jetstream && c.ackWindow > 1 && c.cnt > 1 {
+		return c.doJetstreamWindowed(nc, tracker)
+	}
+
 	if c.jetstream {
 		return c.doJetstream(nc, tracker)
 	}
 
+	if c.expectReply {
+		return c.doExpectReply(nc, tracker)
+	}
+
 	if c.req || c.replyCount >= 1 {
 		return c.doReq(nc, tracker)
 	}
@@ -0,0 +1,38 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPubRetryBackoff(t *testing.T) {
+	cases := []struct {
+		attempt int
+		expect  time.Duration
+	}{
+		{0, 250 * time.Millisecond},
+		{1, 500 * time.Millisecond},
+		{3, 2 * time.Second},
+		{20, time.Minute},
+	}
+
+	for _, tc := range cases {
+		backoff := pubRetryBackoff(250*time.Millisecond, tc.attempt)
+		if backoff != tc.expect {
+			t.Fatalf("expected %v for attempt %d got %v", tc.expect, tc.attempt, backoff)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// readOnlyDeniedCommands are the commands that modify JetStream assets, they are refused before connecting in read-only
+// mode to give a clear error while the connection guard refuses the underlying requests of any command
+var readOnlyDeniedCommands = []string{
+	"stream add",
+	"stream edit",
+	"stream rm",
+	"stream purge",
+	"stream copy",
+	"stream rmm",
+	"stream load",
+	"stream import",
+	"stream republish-range",
+	"stream migrate",
+	"stream restore",
+	"stream seal",
+	"stream cluster step-down",
+	"stream cluster balance",
+	"stream cluster peer-remove",
+	"consumer add",
+	"consumer add-partitioned",
+	"consumer edit",
+	"consumer retune",
+	"consumer ack",
+	"consumer sync-position",
+	"consumer gc",
+	"consumer prune",
+	"consumer rm",
+	"consumer copy",
+	"consumer pause",
+	"consumer unpin",
+	"consumer resume",
+	"consumer bookmark goto",
+	"consumer cluster step-down",
+	"consumer cluster balance",
+	"consumer cluster peer-remove",
+	"kv add",
+	"kv put",
+	"kv create",
+	"kv update",
+	"kv del",
+	"kv purge",
+	"kv revert",
+	"kv compact",
+	"object add",
+	"object put",
+	"object del",
+	"object seal",
+	"object link",
+	"object cp",
+	"account restore",
+	"server account purge",
+	"server cluster step-down",
+	"server cluster peer-remove",
+	"server decommission",
+}
+
+// readOnlyAPIRe matches JetStream API subjects including those using a domain
+var readOnlyAPIRe = regexp.MustCompile(`^\$JS\.(?:[^.]+\.)?API\.(.+)$`)
+
+// readOnlyDeniedAPIs are the JetStream API operations that modify assets
+var readOnlyDeniedAPIs = []string{
+	"STREAM.CREATE.",
+	"STREAM.UPDATE.",
+	"STREAM.DELETE.",
+	"STREAM.PURGE.",
+	"STREAM.MSG.DELETE.",
+	"STREAM.RESTORE.",
+	"STREAM.LEADER.STEPDOWN.",
+	"STREAM.PEER.REMOVE.",
+	"CONSUMER.CREATE.",
+	"CONSUMER.DURABLE.CREATE.",
+	"CONSUMER.DELETE.",
+	"CONSUMER.PAUSE.",
+	"CONSUMER.UNPIN.",
+	"CONSUMER.LEADER.STEPDOWN.",
+	"META.LEADER.STEPDOWN",
+	"SERVER.REMOVE",
+	"ACCOUNT.PURGE.",
+	"ACCOUNT.STREAM.MOVE.",
+	"ACCOUNT.STREAM.CANCEL_MOVE.",
+}
+
+// readOnlyMode determines if read-only mode is enabled using --read-only or the selected context
+func readOnlyMode() (bool, error) {
+	return readOnlyContext(selectedContextName())
+}
+
+// readOnlyContext determines if read-only mode is enabled using --read-only or the context name, an error is returned
+// with read-only mode enabled when the configuration can not be read to tell
+func readOnlyContext(name string) (bool, error) {
+	if opts().ReadOnly {
+		return true, nil
+	}
+
+	if name == "" {
+		return false, nil
+	}
+
+	cfg, err := iu.LoadConfig()
+	if err != nil {
+		return true, fmt.Errorf("could not determine if context %s is read-only: %w", name, err)
+	}
+
+	return cfg.ReadOnlyContexts[name], nil
+}
+
+// isReadOnlyDeniedCommand determines if the full command name modifies JetStream assets
+func isReadOnlyDeniedCommand(cmd string) bool {
+	for _, denied := range readOnlyDeniedCommands {
+		if cmd == denied {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readOnlyDeniedPrefixes are subjects outside the JetStream API that modify assets, acknowledgements and the
+// subjects Key-Value and Object stores write to
+var readOnlyDeniedPrefixes = []string{
+	"$JS.ACK.",
+	"$KV.",
+	"$O.",
+}
+
+// jsAPIOperation returns the operation part of a JetStream API subject, like STREAM.INFO.ORDERS, and false when subj
+// is not a JetStream API subject
+func jsAPIOperation(subj string) (string, bool) {
+	parts := readOnlyAPIRe.FindStringSubmatch(subj)
+	if parts != nil {
+		return parts[1], true
+	}
+
+	if opts().Config == nil {
+		return "", false
+	}
+
+	prefix := strings.TrimSuffix(opts().Config.JSAPIPrefix(), ".")
+	if prefix == "" || !strings.HasPrefix(subj, prefix+".") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(subj, prefix+"."), true
+}
+
+// isMutatingAPISubject determines if subj is a JetStream API subject that modifies assets
+func isMutatingAPISubject(subj string) bool {
+	op, ok := jsAPIOperation(subj)
+	if !ok {
+		return false
+	}
+
+	for _, denied := range readOnlyDeniedAPIs {
+		if strings.HasPrefix(op, denied) || op == strings.TrimSuffix(denied, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMutatingSubject determines if publishing to subj modifies assets, covering the JetStream API, acknowledgements
+// and Key-Value and Object store writes
+func isMutatingSubject(subj string) bool {
+	if isMutatingAPISubject(subj) {
+		return true
+	}
+
+	for _, denied := range readOnlyDeniedPrefixes {
+		if strings.HasPrefix(subj, denied) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkReadOnly fails when read-only mode is enabled, what describes the refused operation
+func checkReadOnly(what string) error {
+	readOnly, err := readOnlyMode()
+	if err != nil {
+		return err
+	}
+	if !readOnly {
+		return nil
+	}
+
+	return fmt.Errorf("refusing to %s in read-only mode", what)
+}
+
+// checkReadOnlyCommand fails when read-only mode is enabled and cmd modifies JetStream assets
+func checkReadOnlyCommand(cmd string) error {
+	if !isReadOnlyDeniedCommand(cmd) {
+		return nil
+	}
+
+	return checkReadOnly(fmt.Sprintf("run %q", cmd))
+}
+
+// checkReadOnlySubject fails when read-only mode is enabled and publishing to subj modifies assets
+func checkReadOnlySubject(subj string) error {
+	if !isMutatingSubject(subj) {
+		return nil
+	}
+
+	return checkReadOnly(fmt.Sprintf("publish to %s", subj))
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nats-io/natscli/options"
+)
+
+func TestReadOnlyChecks(t *testing.T) {
+	prev := options.DefaultOptions
+	defer func() { options.DefaultOptions = prev }()
+	options.DefaultOptions = &options.Options{}
+
+	for _, cmd := range []string{"stream add", "consumer rm", "kv put", "object del", "server cluster step-down"} {
+		if !isReadOnlyDeniedCommand(cmd) {
+			t.Fatalf("expected %q to be denied", cmd)
+		}
+	}
+
+	for _, cmd := range []string{"stream info", "stream ls", "kv get", "consumer report", "context add"} {
+		if isReadOnlyDeniedCommand(cmd) {
+			t.Fatalf("expected %q to be allowed", cmd)
+		}
+	}
+
+	for _, subj := range []string{"$JS.API.STREAM.DELETE.ORDERS", "$JS.hub.API.CONSUMER.CREATE.ORDERS.C1", "$JS.API.META.LEADER.STEPDOWN", "$JS.API.STREAM.MSG.DELETE.ORDERS"} {
+		if !isMutatingAPISubject(subj) {
+			t.Fatalf("expected %q to be mutating", subj)
+		}
+	}
+
+	for _, subj := range []string{"$JS.API.STREAM.INFO.ORDERS", "$JS.API.STREAM.MSG.GET.ORDERS", "$JS.API.INFO", "orders.new", "$JS.API.CONSUMER.LIST.ORDERS"} {
+		if isMutatingAPISubject(subj) {
+			t.Fatalf("expected %q to not be mutating", subj)
+		}
+	}
+
+	// benchmarks and other commands not in the list are refused by the requests they send
+	for _, subj := range []string{"$JS.ACK.ORDERS.C1.1.1.1.1.0", "$KV.CONFIG.key", "$O.FILES.C.abc", "$JS.API.STREAM.PURGE.ORDERS", "$JS.API.STREAM.CREATE.benchstream", "$KV.benchbucket.1"} {
+		if !isMutatingSubject(subj) {
+			t.Fatalf("expected %q to be mutating", subj)
+		}
+	}
+
+	for _, subj := range []string{"orders.new", "$JS.API.STREAM.INFO.ORDERS", "$JS.FC.ORDERS.x"} {
+		if isMutatingSubject(subj) {
+			t.Fatalf("expected %q to not be mutating", subj)
+		}
+	}
+}
+
+func TestReadOnlyContext(t *testing.T) {
+	prev := options.DefaultOptions
+	defer func() { options.DefaultOptions = prev }()
+	options.DefaultOptions = &options.Options{}
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfile := filepath.Join(dir, "nats", "cli", "config.json")
+	err := os.MkdirAll(filepath.Dir(cfile), 0700)
+	if err != nil {
+		t.Fatalf("could not create configuration directory: %v", err)
+	}
+
+	err = os.WriteFile(cfile, []byte(`{"read_only_contexts":{"prod":true}}`), 0600)
+	if err != nil {
+		t.Fatalf("could not write configuration: %v", err)
+	}
+
+	for name, expected := range map[string]bool{"prod": true, "dev": false, "": false} {
+		readOnly, err := readOnlyContext(name)
+		if err != nil || readOnly != expected {
+			t.Fatalf("expected context %q read-only to be %v got %v: %v", name, expected, readOnly, err)
+		}
+	}
+
+	err = os.WriteFile(cfile, []byte(`{`), 0600)
+	if err != nil {
+		t.Fatalf("could not write configuration: %v", err)
+	}
+
+	readOnly, err := readOnlyContext("dev")
+	if err == nil || !readOnly {
+		t.Fatalf("expected an unreadable configuration to enable read-only mode")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+// replicaStatus summarises how far a RAFT group is from having the desired replicas current
+type replicaStatus struct {
+	Peers   int
+	Current int
+	Lag     uint64
+	Leader  string
+	Cluster string
+}
+
+func newReplicaStatus(ci *api.ClusterInfo) replicaStatus {
+	var status replicaStatus
+	if ci == nil || ci.Leader == "" {
+		return status
+	}
+
+	status.Leader = ci.Leader
+	status.Cluster = ci.Name
+	status.Peers = len(ci.Replicas) + 1
+	status.Current = 1
+
+	for _, r := range ci.Replicas {
+		if !r.Offline && r.Current {
+			status.Current++
+		}
+		status.Lag += r.Lag
+	}
+
+	return status
+}
+
+// done determines if the group has exactly replicas peers all current, in cluster when set
+func (s replicaStatus) done(replicas int, cluster string) bool {
+	if cluster != "" && s.Cluster != cluster {
+		return false
+	}
+
+	return s.Leader != "" && s.Peers == replicas && s.Current == replicas
+}
+
+func (s replicaStatus) String() string {
+	if s.Leader == "" {
+		return "waiting for a leader"
+	}
+
+	return fmt.Sprintf("%d of %d peers current in cluster %s with %s lag", s.Current, s.Peers, s.Cluster, f(s.Lag))
+}
+
+// waitForReplicas polls info until the group has replicas current peers, placed in cluster when set, logging progress as it changes
+func waitForReplicas(what string, replicas int, cluster string, timeout time.Duration, info func() (*api.ClusterInfo, error)) error {
+	log.Printf("Waiting up to %v for %s to have %d current replicas", timeout, what, replicas)
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	last := ""
+	for {
+		select {
+		case <-ticker.C:
+			ci, err := info()
+			if err != nil {
+				continue
+			}
+
+			status := newReplicaStatus(ci)
+			if status.done(replicas, cluster) {
+				log.Printf("%s has %d current replicas in cluster %s", what, replicas, status.Cluster)
+				return nil
+			}
+
+			if msg := status.String(); msg != last {
+				log.Printf("%s: %s", what, msg)
+				last = msg
+			}
+
+		case <-deadline.C:
+			return fmt.Errorf("%s did not reach %d current replicas within %v", what, replicas, timeout)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestReplicaStatus(t *testing.T) {
+	ci := &api.ClusterInfo{
+		Name:   "east",
+		Leader: "n1",
+		Replicas: []*api.PeerInfo{
+			{Name: "n2", Current: true},
+			{Name: "n3", Current: false, Lag: 100},
+		},
+	}
+
+	status := newReplicaStatus(ci)
+	if status.Peers != 3 || status.Current != 2 || status.Lag != 100 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	if status.done(3, "") {
+		t.Fatalf("expected lagging replica to not be done")
+	}
+
+	ci.Replicas[1].Current = true
+	status = newReplicaStatus(ci)
+	if !status.done(3, "") {
+		t.Fatalf("expected current replicas to be done")
+	}
+	if status.done(3, "west") {
+		t.Fatalf("expected other cluster to not be done")
+	}
+	if status.done(5, "") {
+		t.Fatalf("expected missing peers to not be done")
+	}
+
+	if newReplicaStatus(nil).done(1, "") {
+		t.Fatalf("expected groups without a leader to not be done")
+	}
+}
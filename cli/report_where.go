@@ -0,0 +1,178 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats-server/v2/server"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// addReportWhereFlag adds the --where flag to cmd storing the expression in where, fields lists the names available to expressions
+func addReportWhereFlag(cmd *fisk.CmdClause, where *string, fields string) {
+	cmd.Flag("where", fmt.Sprintf("Only show rows matching an expression using %s or info", fields)).PlaceHolder("EXPRESSION").StringVar(where)
+}
+
+// reportFilter selects report rows using an expr expression, a nil filter matches all rows
+type reportFilter struct {
+	expression string
+	program    *vm.Program
+}
+
+func newReportFilter(expression string) (*reportFilter, error) {
+	if expression == "" {
+		return nil, nil
+	}
+
+	program, err := expr.Compile(expression, expr.Env(map[string]any{}), expr.AsBool(), expr.AllowUndefinedVariables())
+	if err != nil {
+		return nil, fmt.Errorf("invalid --where expression: %w", err)
+	}
+
+	return &reportFilter{expression: expression, program: program}, nil
+}
+
+// match determines if the row described by env matches the expression
+func (r *reportFilter) match(env map[string]any) (bool, error) {
+	if r == nil {
+		return true, nil
+	}
+
+	out, err := expr.Run(r.program, env)
+	if err != nil {
+		return false, fmt.Errorf("could not evaluate %q: %w", r.expression, err)
+	}
+
+	should, ok := out.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q did not return a boolean", r.expression)
+	}
+
+	return should, nil
+}
+
+const streamReportWhereFields = "name, subjects, storage, replicas, consumers, messages, bytes, deleted, lost_messages, lost_bytes, first_seq, last_seq, cluster, leader, mirror, sources, age"
+
+func streamReportRow(info *api.StreamInfo) map[string]any {
+	row := map[string]any{
+		"name":          info.Config.Name,
+		"subjects":      info.Config.Subjects,
+		"storage":       info.Config.Storage.String(),
+		"replicas":      info.Config.Replicas,
+		"consumers":     info.State.Consumers,
+		"messages":      info.State.Msgs,
+		"bytes":         info.State.Bytes,
+		"deleted":       info.State.NumDeleted,
+		"lost_messages": 0,
+		"lost_bytes":    uint64(0),
+		"first_seq":     info.State.FirstSeq,
+		"last_seq":      info.State.LastSeq,
+		"cluster":       "",
+		"leader":        "",
+		"mirror":        "",
+		"sources":       len(info.Config.Sources),
+		"age":           time.Since(info.Created),
+		"info":          iu.StructWithoutOmitEmpty(*info),
+	}
+
+	if info.State.Lost != nil {
+		row["lost_messages"] = len(info.State.Lost.Msgs)
+		row["lost_bytes"] = info.State.Lost.Bytes
+	}
+	if info.Cluster != nil {
+		row["cluster"] = info.Cluster.Name
+		row["leader"] = info.Cluster.Leader
+	}
+	if info.Config.Mirror != nil {
+		row["mirror"] = info.Config.Mirror.Name
+	}
+
+	return row
+}
+
+const consumerReportWhereFields = "name, stream, mode, filter, ack_policy, ack_wait, ack_pending, redelivered, pending, waiting, ack_floor, delivered, last_active, paused, cluster, leader"
+
+func consumerReportRow(info *api.ConsumerInfo) map[string]any {
+	mode := "Push"
+	if info.Config.DeliverSubject == "" {
+		mode = "Pull"
+	}
+
+	filter := info.Config.FilterSubject
+	if len(info.Config.FilterSubjects) > 0 {
+		filter = info.Config.FilterSubjects[0]
+	}
+
+	row := map[string]any{
+		"name":        info.Name,
+		"stream":      info.Stream,
+		"mode":        mode,
+		"filter":      filter,
+		"ack_policy":  info.Config.AckPolicy.String(),
+		"ack_wait":    info.Config.AckWait,
+		"ack_pending": info.NumAckPending,
+		"redelivered": info.NumRedelivered,
+		"pending":     info.NumPending,
+		"waiting":     info.NumWaiting,
+		"ack_floor":   info.AckFloor.Stream,
+		"delivered":   info.Delivered.Stream,
+		"last_active": time.Duration(0),
+		"paused":      info.Paused,
+		"cluster":     "",
+		"leader":      "",
+		"info":        iu.StructWithoutOmitEmpty(*info),
+	}
+
+	if info.Delivered.Last != nil {
+		row["last_active"] = time.Since(*info.Delivered.Last)
+	}
+	if info.Cluster != nil {
+		row["cluster"] = info.Cluster.Name
+		row["leader"] = info.Cluster.Leader
+	}
+
+	return row
+}
+
+const serverJetStreamWhereFields = "server, cluster, domain, streams, consumers, messages, bytes, memory, store, api_total, api_errors, pending"
+
+func serverJetStreamReportRow(jsz *server.ServerAPIJszResponse) map[string]any {
+	row := map[string]any{
+		"server":     jsz.Server.Name,
+		"cluster":    jsz.Server.Cluster,
+		"domain":     jsz.Data.Config.Domain,
+		"streams":    jsz.Data.Streams,
+		"consumers":  jsz.Data.Consumers,
+		"messages":   jsz.Data.Messages,
+		"bytes":      jsz.Data.Bytes,
+		"memory":     jsz.Data.JetStreamStats.Memory,
+		"store":      jsz.Data.JetStreamStats.Store,
+		"api_total":  jsz.Data.JetStreamStats.API.Total,
+		"api_errors": jsz.Data.JetStreamStats.API.Errors,
+		"pending":    0,
+		"info":       iu.StructWithoutOmitEmpty(*jsz),
+	}
+
+	if jsz.Data.Meta != nil {
+		row["pending"] = jsz.Data.Meta.Pending
+	}
+
+	return row
+}
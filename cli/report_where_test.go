@@ -0,0 +1,65 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestReportFilter(t *testing.T) {
+	where, err := newReportFilter("")
+	if err != nil || where != nil {
+		t.Fatalf("expected no filter for an empty expression")
+	}
+	matched, err := where.match(nil)
+	if err != nil || !matched {
+		t.Fatalf("expected a nil filter to match")
+	}
+
+	_, err = newReportFilter("pending >")
+	if err == nil {
+		t.Fatalf("expected invalid expression to fail")
+	}
+
+	where, err = newReportFilter("pending > 1000 && redelivered > 10 && ack_wait > duration('10s')")
+	if err != nil {
+		t.Fatalf("compile failed: %v", err)
+	}
+
+	info := &api.ConsumerInfo{Name: "C1", Stream: "ORDERS", NumPending: 2000, NumRedelivered: 11}
+	info.Config.AckWait = time.Minute
+
+	matched, err = where.match(consumerReportRow(info))
+	if err != nil || !matched {
+		t.Fatalf("expected match: %v", err)
+	}
+
+	info.NumRedelivered = 1
+	matched, err = where.match(consumerReportRow(info))
+	if err != nil || matched {
+		t.Fatalf("expected no match: %v", err)
+	}
+
+	where, err = newReportFilter("name matches '^ORD' && info.config.name == 'ORDERS' && lost_messages == 0")
+	if err != nil {
+		t.Fatalf("compile failed: %v", err)
+	}
+	matched, err = where.match(streamReportRow(&api.StreamInfo{Config: api.StreamConfig{Name: "ORDERS"}}))
+	if err != nil || !matched {
+		t.Fatalf("expected match: %v", err)
+	}
+}
@@ -46,7 +46,10 @@ func (c *schemaInfoCmd) info(_ *fisk.ParseContext) error {
 		}
 	}
 
-	fmt.Println(string(schema))
+	out := newPager()
+	defer out.Close()
+
+	fmt.Fprintln(out, string(schema))
 
 	return nil
 }
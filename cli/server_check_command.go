@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jsm.go/monitor"
 )
@@ -48,6 +49,8 @@ type SrvCheckCmd struct {
 	subjectsWarnIsSet        bool
 	subjectsCrit             int
 	subjectsCritIsSet        bool
+	streamAgeWarn            time.Duration
+	streamAgeCrit            time.Duration
 
 	consumerName                        string
 	consumerAckOutstandingCritical      int
@@ -63,6 +66,10 @@ type SrvCheckCmd struct {
 	consumerRedeliveryCritical          int
 	consumerRedeliveryCriticalIsSet     bool
 	consumerPinned                      bool
+	consumerAckOutstandingWarn          int
+	consumerWaitingWarn                 int
+	consumerUnprocessedWarn             int
+	consumerRedeliveryWarn              int
 
 	raftExpect            int
 	raftExpectIsSet       bool
@@ -117,6 +124,11 @@ type SrvCheckCmd struct {
 	exporterPort        int
 	exporterCertificate string
 	exporterKey         string
+	exporterStreams     []string
+	exporterInterval    time.Duration
+
+	readyWait     time.Duration
+	readyInterval time.Duration
 }
 
 func configureServerCheckCommand(srv *fisk.CmdClause) {
@@ -154,6 +166,8 @@ When set these settings will be used, but can be overridden using --lag-critical
 	stream.Flag("msgs-critical", "Critical if there are fewer than this many messages in the stream").PlaceHolder("MSGS").IsSetByUser(&c.streamMessagesCritIsSet).Uint64Var(&c.streamMessagesCrit)
 	stream.Flag("subjects-warn", "Critical threshold for subjects in the stream").PlaceHolder("SUBJECTS").Default("-1").IsSetByUser(&c.subjectsWarnIsSet).IntVar(&c.subjectsWarn)
 	stream.Flag("subjects-critical", "Warning threshold for subjects in the stream").PlaceHolder("SUBJECTS").Default("-1").IsSetByUser(&c.subjectsCritIsSet).IntVar(&c.subjectsCrit)
+	stream.Flag("age-warn", "Warning threshold for the age of the most recent message in the stream").PlaceHolder("DURATION").DurationVar(&c.streamAgeWarn)
+	stream.Flag("age-critical", "Critical threshold for the age of the most recent message in the stream").PlaceHolder("DURATION").DurationVar(&c.streamAgeCrit)
 
 	consumer := check.Command("consumer", "Checks the health of a consumer").Action(c.checkConsumer)
 	consumer.HelpLong(`These settings can be set using Consumer Metadata in the following form:
@@ -163,11 +177,15 @@ When set these settings will be used, but can be overridden using --lag-critical
 When set these settings will be used, but can be overridden using --waiting-critical.`)
 	consumer.Flag("stream", "The streams to check").Required().StringVar(&c.sourcesStream)
 	consumer.Flag("consumer", "The consumer to check").Required().StringVar(&c.consumerName)
+	consumer.Flag("outstanding-ack-warn", "Number of outstanding acks that will raise a warning").Default("-1").IntVar(&c.consumerAckOutstandingWarn)
 	consumer.Flag("outstanding-ack-critical", "Maximum number of outstanding acks to allow").Default("-1").IsSetByUser(&c.consumerAckOutstandingCriticalIsSet).IntVar(&c.consumerAckOutstandingCritical)
+	consumer.Flag("waiting-warn", "Number of waiting pulls that will raise a warning").Default("-1").IntVar(&c.consumerWaitingWarn)
 	consumer.Flag("waiting-critical", "Maximum number of waiting pulls to allow").Default("-1").IsSetByUser(&c.consumerWaitingCriticalIsSet).IntVar(&c.consumerWaitingCritical)
+	consumer.Flag("unprocessed-warn", "Number of unprocessed messages that will raise a warning").Default("-1").IntVar(&c.consumerUnprocessedWarn)
 	consumer.Flag("unprocessed-critical", "Maximum number of unprocessed messages to allow").Default("-1").IsSetByUser(&c.consumerUnprocessedCriticalIsSet).IntVar(&c.consumerUnprocessedCritical)
 	consumer.Flag("last-delivery-critical", "Time to allow since the last delivery").Default("0s").IsSetByUser(&c.consumerLastDeliveryCriticalIsSet).DurationVar(&c.consumerLastDeliveryCritical)
 	consumer.Flag("last-ack-critical", "Time to allow since the last ack").Default("0s").IsSetByUser(&c.consumerLastAckCriticalIsSet).DurationVar(&c.consumerLastAckCritical)
+	consumer.Flag("redelivery-warn", "Number of redeliveries that will raise a warning").Default("-1").IntVar(&c.consumerRedeliveryWarn)
 	consumer.Flag("redelivery-critical", "Maximum number of redeliveries to allow").Default("-1").IsSetByUser(&c.consumerRedeliveryCriticalIsSet).IntVar(&c.consumerRedeliveryCritical)
 	consumer.Flag("pinned", "Requires Pinned Client priority with all groups having a pinned client").UnNegatableBoolVar(&c.consumerPinned)
 
@@ -219,6 +237,8 @@ When set these settings will be used, but can be overridden using --waiting-crit
 	kv.Flag("values-warn", "Warning threshold for number of values in the bucket").Default("-1").Int64Var(&c.kvValuesWarn)
 	kv.Flag("key", "Requires a key to have any non-delete value set").StringVar(&c.kvKey)
 
+	configureServerCheckReadyCommand(check, c)
+
 	cred := check.Command("credential", "Checks the validity of a NATS credential file").Action(c.checkCredentialAction)
 	cred.Flag("credential", "The file holding the NATS credential").Required().StringVar(&c.credential)
 	cred.Flag("validity-warn", "Warning threshold for time before expiry").DurationVar(&c.credentialValidityWarn)
@@ -230,6 +250,13 @@ When set these settings will be used, but can be overridden using --waiting-crit
 	exporter.Flag("port", "Port to listen on").Default("8080").IntVar(&c.exporterPort)
 	exporter.Flag("https-key", "Key for HTTPS").ExistingFileVar(&c.exporterKey)
 	exporter.Flag("https-certificate", "Certificate for HTTPS").ExistingFileVar(&c.exporterCertificate)
+
+	stateExporter := check.Command("state-exporter", "Prometheus exporter for Stream and Consumer state").Action(c.stateExporterAction)
+	stateExporter.Flag("stream", "Streams to export, all Streams when not set").PlaceHolder("STREAM").StringsVar(&c.exporterStreams)
+	stateExporter.Flag("interval", "How often to gather Stream and Consumer state").Default("30s").PlaceHolder("DURATION").DurationVar(&c.exporterInterval)
+	stateExporter.Flag("port", "Port to listen on").Default("8080").IntVar(&c.exporterPort)
+	stateExporter.Flag("https-key", "Key for HTTPS").ExistingFileVar(&c.exporterKey)
+	stateExporter.Flag("https-certificate", "Certificate for HTTPS").ExistingFileVar(&c.exporterCertificate)
 }
 
 var (
@@ -280,6 +307,8 @@ func (c *SrvCheckCmd) checkConsumer(_ *fisk.ParseContext) error {
 		checkOpts.RedeliveryCritical = c.consumerRedeliveryCritical
 	}
 
+	checkOpts.HealthChecks = append(checkOpts.HealthChecks, c.checkConsumerWarnings)
+
 	logger := api.NewDiscardLogger()
 	if opts().Trace {
 		logger = api.NewDefaultLogger(api.TraceLevel)
@@ -397,6 +426,9 @@ func (c *SrvCheckCmd) checkStream(_ *fisk.ParseContext) error {
 	if c.subjectsCritIsSet {
 		checkOpts.SubjectsCrit = c.subjectsCrit
 	}
+	if c.streamAgeWarn > 0 || c.streamAgeCrit > 0 {
+		checkOpts.HealthChecks = append(checkOpts.HealthChecks, c.checkStreamMessageAge)
+	}
 
 	logger := api.NewDiscardLogger()
 	if opts().Trace {
@@ -408,6 +440,62 @@ func (c *SrvCheckCmd) checkStream(_ *fisk.ParseContext) error {
 	return nil
 }
 
+// checkStreamMessageAge checks how long ago the most recent message was stored in the stream
+func (c *SrvCheckCmd) checkStreamMessageAge(stream *jsm.Stream, check *monitor.Result, _ monitor.StreamHealthCheckOptions, _ api.Logger) {
+	state, err := stream.LatestState()
+	if check.CriticalIfErr(err, "could not load stream state: %v", err) {
+		return
+	}
+
+	// without a critical threshold an empty stream is only a warning
+	if state.Msgs == 0 || state.LastTime.IsZero() {
+		if c.streamAgeCrit > 0 {
+			check.Critical("no messages")
+		} else {
+			check.Warn("no messages")
+		}
+		return
+	}
+
+	age := time.Since(state.LastTime)
+	check.Pd(&monitor.PerfDataItem{Name: "last_message_age", Value: age.Seconds(), Unit: "s", Help: "Seconds since the most recent message was stored", Warn: c.streamAgeWarn.Seconds(), Crit: c.streamAgeCrit.Seconds()})
+
+	switch {
+	case c.streamAgeCrit > 0 && age >= c.streamAgeCrit:
+		check.Critical("last message %v old", age.Round(time.Millisecond))
+	case c.streamAgeWarn > 0 && age >= c.streamAgeWarn:
+		check.Warn("last message %v old", age.Round(time.Millisecond))
+	default:
+		check.Ok("last message %v old", age.Round(time.Millisecond))
+	}
+}
+
+// checkConsumerWarnings adds warning thresholds to the critical ones supported by the consumer health check
+func (c *SrvCheckCmd) checkConsumerWarnings(consumer *jsm.Consumer, check *monitor.Result, copts monitor.ConsumerHealthCheckOptions, _ api.Logger) {
+	if c.consumerAckOutstandingWarn <= 0 && c.consumerWaitingWarn <= 0 && c.consumerUnprocessedWarn <= 0 && c.consumerRedeliveryWarn <= 0 {
+		return
+	}
+
+	nfo, err := consumer.LatestState()
+	if check.CriticalIfErr(err, "could not load consumer state: %v", err) {
+		return
+	}
+
+	// values at or above critical are already reported by the consumer health check
+	warnIf := func(name string, value int, warn int, crit int) {
+		if warn <= 0 || value < warn || (crit > 0 && value >= crit) {
+			return
+		}
+
+		check.Warn("%s: %v", name, value)
+	}
+
+	warnIf("Ack Pending", nfo.NumAckPending, c.consumerAckOutstandingWarn, copts.AckOutstandingCritical)
+	warnIf("Waiting Pulls", nfo.NumWaiting, c.consumerWaitingWarn, copts.WaitingCritical)
+	warnIf("Unprocessed Messages", int(nfo.NumPending), c.consumerUnprocessedWarn, copts.UnprocessedCritical)
+	warnIf("Redelivered Messages", nfo.NumRedelivered, c.consumerRedeliveryWarn, copts.RedeliveryCritical)
+}
+
 func (c *SrvCheckCmd) checkMsg(_ *fisk.ParseContext) error {
 	check := &monitor.Result{Name: "Stream Message", Check: "message", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
 	defer check.GenericExit()
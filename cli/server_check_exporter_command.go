@@ -16,6 +16,7 @@ package cli
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/choria-io/fisk"
 	"github.com/nats-io/natscli/internal/exporter"
@@ -30,10 +31,33 @@ func (c *SrvCheckCmd) exporterAction(_ *fisk.ParseContext) error {
 	}
 
 	prometheus.MustRegister(exp)
+
+	return c.serveMetrics()
+}
+
+func (c *SrvCheckCmd) stateExporterAction(_ *fisk.ParseContext) error {
+	if c.exporterInterval < time.Second {
+		return fmt.Errorf("interval should be at least 1 second")
+	}
+
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	if err != nil {
+		return err
+	}
+
+	exp := exporter.NewStateExporter(opts().PrometheusNamespace, mgr, c.exporterStreams, c.exporterInterval)
+	prometheus.MustRegister(exp)
+
+	go exp.Run(ctx)
+
+	return c.serveMetrics()
+}
+
+func (c *SrvCheckCmd) serveMetrics() error {
 	http.Handle("/metrics", promhttp.Handler())
 
 	if c.exporterCertificate != "" && c.exporterKey != "" {
-		log.Printf("NATS CLI Prometheus Exporter listening on https://0.0.0.0:%da/metrics", c.exporterPort)
+		log.Printf("NATS CLI Prometheus Exporter listening on https://0.0.0.0:%d/metrics", c.exporterPort)
 		return http.ListenAndServeTLS(fmt.Sprintf(":%d", c.exporterPort), c.exporterCertificate, c.exporterKey, nil)
 	} else {
 		log.Printf("NATS CLI Prometheus Exporter listening on http://0.0.0.0:%d/metrics", c.exporterPort)
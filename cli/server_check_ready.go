@@ -0,0 +1,140 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/monitor"
+)
+
+func configureServerCheckReadyCommand(check *fisk.CmdClause, c *SrvCheckCmd) {
+	ready := check.Command("ready", "Waits until JetStream and optionally a Stream and Consumer are ready for use").Action(c.checkReady)
+	ready.HelpLong(`Blocks until the connected server has JetStream available with an elected meta
+leader and, when given, the Stream and Consumer exist and have elected leaders.
+
+The check is critical when not ready before --wait passes, making it suitable
+as a Kubernetes init container or deployment gate.
+
+   nats server check ready --wait 5m
+   nats server check ready --stream ORDERS --consumer NEW --format text`)
+	ready.Flag("stream", "Requires a Stream to exist and have a leader").PlaceHolder("STREAM").StringVar(&c.sourcesStream)
+	ready.Flag("consumer", "Requires a Consumer on --stream to exist and have a leader").PlaceHolder("CONSUMER").StringVar(&c.consumerName)
+	ready.Flag("wait", "How long to wait for readiness").Default("1m").PlaceHolder("DURATION").DurationVar(&c.readyWait)
+	ready.Flag("interval", "How often to check readiness").Default("1s").PlaceHolder("DURATION").DurationVar(&c.readyInterval)
+}
+
+// readyClusterProblem describes why a clustered asset is not ready, empty when it has a leader or is not clustered
+func readyClusterProblem(what string, ci *api.ClusterInfo) string {
+	if ci == nil || ci.Leader != "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s has no leader", what)
+}
+
+// readyProblem performs a single readiness check and describes the first problem found, empty when ready
+func (c *SrvCheckCmd) readyProblem() string {
+	_, mgr, err := prepareHelper("", natsOpts()...)
+	if err != nil {
+		return fmt.Sprintf("connection failed: %v", err)
+	}
+
+	// account information is only served by clustered JetStream once a meta leader is elected
+	_, err = mgr.JetStreamAccountInfo()
+	if err != nil {
+		return fmt.Sprintf("JetStream is not available: %v", err)
+	}
+
+	if c.sourcesStream == "" {
+		return ""
+	}
+
+	stream, err := mgr.LoadStream(c.sourcesStream)
+	if err != nil {
+		return fmt.Sprintf("Stream %s is not available: %v", c.sourcesStream, err)
+	}
+
+	nfo, err := stream.LatestInformation()
+	if err != nil {
+		return fmt.Sprintf("Stream %s is not available: %v", c.sourcesStream, err)
+	}
+
+	problem := readyClusterProblem(fmt.Sprintf("Stream %s", c.sourcesStream), nfo.Cluster)
+	if problem != "" || c.consumerName == "" {
+		return problem
+	}
+
+	consumer, err := mgr.LoadConsumer(c.sourcesStream, c.consumerName)
+	if err != nil {
+		return fmt.Sprintf("Consumer %s > %s is not available: %v", c.sourcesStream, c.consumerName, err)
+	}
+
+	state, err := consumer.LatestState()
+	if err != nil {
+		return fmt.Sprintf("Consumer %s > %s is not available: %v", c.sourcesStream, c.consumerName, err)
+	}
+
+	return readyClusterProblem(fmt.Sprintf("Consumer %s > %s", c.sourcesStream, c.consumerName), state.Cluster)
+}
+
+func (c *SrvCheckCmd) checkReady(_ *fisk.ParseContext) error {
+	check := &monitor.Result{Name: "Ready", Check: "ready", OutFile: checkRenderOutFile, NameSpace: opts().PrometheusNamespace, RenderFormat: checkRenderFormat}
+	defer check.GenericExit()
+
+	if c.consumerName != "" && c.sourcesStream == "" {
+		check.Critical("--consumer requires --stream")
+		return nil
+	}
+
+	if opts().Config == nil {
+		err := loadContext(false)
+		if check.CriticalIfErr(err, "loading context failed: %v", err) {
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.readyWait)
+	defer cancel()
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	start := time.Now()
+	ticker := time.NewTicker(c.readyInterval)
+	defer ticker.Stop()
+
+	for {
+		problem := c.readyProblem()
+		if problem == "" {
+			waited := time.Since(start)
+			check.Ok("Ready after %s", waited.Round(time.Millisecond))
+			check.Pd(&monitor.PerfDataItem{Name: "ready_time", Value: waited.Seconds(), Unit: "s", Help: "Seconds waited for readiness", Crit: c.readyWait.Seconds()})
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			check.Critical("Not ready after %s: %s", time.Since(start).Round(time.Millisecond), problem)
+			return nil
+		}
+	}
+}
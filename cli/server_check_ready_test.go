@@ -0,0 +1,34 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestReadyClusterProblem(t *testing.T) {
+	if p := readyClusterProblem("Stream ORDERS", nil); p != "" {
+		t.Fatalf("expected unclustered assets to be ready: %q", p)
+	}
+
+	if p := readyClusterProblem("Stream ORDERS", &api.ClusterInfo{Name: "east", Leader: "n1"}); p != "" {
+		t.Fatalf("expected assets with a leader to be ready: %q", p)
+	}
+
+	if p := readyClusterProblem("Stream ORDERS", &api.ClusterInfo{Name: "east"}); p != "Stream ORDERS has no leader" {
+		t.Fatalf("unexpected problem: %q", p)
+	}
+}
@@ -21,6 +21,7 @@ func configureServerCommand(app commandHost) {
 	configureServerCheckCommand(srv)
 	configureServerClusterCommand(srv)
 	configureServerConfigCommand(srv)
+	configureServerDecommissionCommand(srv)
 	configureServerGenerateCommand(srv)
 	configureServerGraphCommand(srv)
 	configureServerInfoCommand(srv)
@@ -31,6 +32,7 @@ func configureServerCommand(app commandHost) {
 	configureServerReportCommand(srv)
 	configureServerRequestCommand(srv)
 	configureServerRunCommand(srv)
+	configureServerSignalCommand(srv)
 	configureServerWatchCommand(srv)
 	configureStreamCheckCommand(srv)
 	configureConsumerCheckCommand(srv)
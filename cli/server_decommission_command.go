@@ -0,0 +1,386 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+type SrvDecommissionCmd struct {
+	peer       string
+	force      bool
+	removeMeta bool
+	timeout    time.Duration
+}
+
+type decommissionStream struct {
+	stream    *jsm.Stream
+	replicas  int
+	leader    bool
+	consumers []*decommissionConsumer
+	result    string
+	took      time.Duration
+}
+
+type decommissionConsumer struct {
+	name     string
+	replicas int
+}
+
+func configureServerDecommissionCommand(srv *fisk.CmdClause) {
+	c := &SrvDecommissionCmd{}
+
+	dc := srv.Command("decommission", "Moves all Stream and Consumer replicas off a JetStream peer").Action(c.decommissionAction)
+	dc.HelpLong(`Finds every Stream with a replica on the peer and removes the peer from it,
+waiting for each Stream and its Consumers to be placed on a replacement peer
+and become current before moving to the next.
+
+Only Streams visible to the current account are moved, run the command for
+every account with JetStream data on the peer. R1 Streams are not moved and
+should be scaled up or recreated elsewhere first.
+
+When --meta is given the peer is finally removed from the JetStream meta group.`)
+	dc.Arg("peer", "The Server Name to decommission").Required().StringVar(&c.peer)
+	dc.Flag("meta", "Remove the peer from the JetStream meta group once all Streams were moved").UnNegatableBoolVar(&c.removeMeta)
+	dc.Flag("timeout", "How long to wait for each Stream to be moved").Default("5m").DurationVar(&c.timeout)
+	dc.Flag("force", "Force decommission without prompting").Short('f').UnNegatableBoolVar(&c.force)
+}
+
+func (c *SrvDecommissionCmd) decommissionAction(_ *fisk.ParseContext) error {
+	nc, mgr, err := prepareHelper("", natsOpts()...)
+	if err != nil {
+		return err
+	}
+
+	affected, skipped, err := c.findStreams(mgr)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range skipped {
+		log.Printf("Skipping R1 Stream %s, it can not be moved without data loss", name)
+	}
+
+	if len(affected) == 0 && !c.removeMeta {
+		fmt.Printf("No Streams have replicas on peer %s\n", c.peer)
+		return nil
+	}
+
+	if len(affected) > 0 {
+		table := iu.NewTableWriter(opts(), "Streams with replicas on %s", c.peer)
+		table.AddHeaders("Stream", "Replicas", "Leader", "Consumers")
+		for _, s := range affected {
+			table.AddRow(s.stream.Name(), s.replicas, s.leader, len(s.consumers))
+		}
+		fmt.Println(table.Render())
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really decommission peer %s moving %d Streams", c.peer, len(affected)), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("decommission canceled")
+		}
+	}
+
+	failed := 0
+	for _, s := range affected {
+		start := time.Now()
+		err = c.moveStream(s)
+		s.took = time.Since(start).Round(time.Millisecond)
+		if err != nil {
+			s.result = err.Error()
+			failed++
+			log.Printf("Moving Stream %s failed: %v", s.stream.Name(), err)
+			continue
+		}
+
+		s.result = "OK"
+		log.Printf("Moved Stream %s and %d Consumers in %v", s.stream.Name(), len(s.consumers), s.took)
+	}
+
+	metaResult := "not requested"
+	switch {
+	case c.removeMeta && failed > 0:
+		metaResult = "skipped due to failed Stream moves"
+	case c.removeMeta:
+		err = c.removeMetaPeer(nc, mgr)
+		if err != nil {
+			metaResult = err.Error()
+			failed++
+		} else {
+			metaResult = "OK"
+		}
+	}
+
+	healthy, err := c.verifyMeta(nc)
+	if err != nil {
+		healthy = err.Error()
+		failed++
+	}
+
+	fmt.Println()
+	table := iu.NewTableWriter(opts(), "Decommission report for %s", c.peer)
+	table.AddHeaders("Stream", "Consumers", "Time", "Result")
+	for _, s := range affected {
+		table.AddRow(s.stream.Name(), len(s.consumers), s.took, s.result)
+	}
+	for _, name := range skipped {
+		table.AddRow(name, "", "", "skipped R1 Stream")
+	}
+	fmt.Println(table.Render())
+
+	fmt.Println()
+	fmt.Printf("Meta group peer removal: %s\n", metaResult)
+	fmt.Printf("   Meta group health: %s\n", healthy)
+
+	if failed > 0 || len(skipped) > 0 {
+		return fmt.Errorf("decommission of %s did not complete", c.peer)
+	}
+
+	return nil
+}
+
+func (c *SrvDecommissionCmd) findStreams(mgr *jsm.Manager) ([]*decommissionStream, []string, error) {
+	streams, missing, err := mgr.Streams(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("could not obtain stream information for %d streams", len(missing))
+	}
+
+	var affected []*decommissionStream
+	var skipped []string
+
+	for _, stream := range streams {
+		nfo, err := stream.LatestInformation()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !clusterHasPeer(nfo.Cluster, c.peer) {
+			continue
+		}
+
+		if nfo.Config.Replicas == 1 {
+			skipped = append(skipped, stream.Name())
+			continue
+		}
+
+		s := &decommissionStream{
+			stream:   stream,
+			replicas: nfo.Config.Replicas,
+			leader:   nfo.Cluster.Leader == c.peer,
+		}
+
+		_, err = stream.EachConsumer(func(consumer *jsm.Consumer) {
+			state, err := consumer.LatestState()
+			if err != nil {
+				return
+			}
+			if clusterHasPeer(state.Cluster, c.peer) {
+				// consumers without their own replica count inherit the stream replica count
+				replicas := state.Config.Replicas
+				if replicas == 0 {
+					replicas = nfo.Config.Replicas
+				}
+
+				s.consumers = append(s.consumers, &decommissionConsumer{name: consumer.Name(), replicas: replicas})
+			}
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		affected = append(affected, s)
+	}
+
+	return affected, skipped, nil
+}
+
+// moveStream removes the peer from the stream and waits for the stream and its consumers to be current on a replacement
+func (c *SrvDecommissionCmd) moveStream(s *decommissionStream) error {
+	log.Printf("Removing peer %s from Stream %s", c.peer, s.stream.Name())
+
+	err := s.stream.RemoveRAFTPeer(c.peer)
+	if err != nil {
+		return err
+	}
+
+	timeout := time.NewTimer(c.timeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			nfo, err := s.stream.LatestInformation()
+			if err != nil {
+				continue
+			}
+
+			if !clusterMoved(nfo.Cluster, c.peer, nfo.Config.Replicas) {
+				continue
+			}
+
+			done := true
+			for _, cons := range s.consumers {
+				consumer, err := s.stream.LoadConsumer(cons.name)
+				if err != nil {
+					done = false
+					break
+				}
+
+				state, err := consumer.LatestState()
+				if err != nil || !clusterMoved(state.Cluster, c.peer, cons.replicas) {
+					done = false
+					break
+				}
+			}
+
+			if done {
+				return nil
+			}
+
+		case <-timeout.C:
+			return fmt.Errorf("replacement peer did not become current within %v", c.timeout)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *SrvDecommissionCmd) removeMetaPeer(nc *nats.Conn, mgr *jsm.Manager) error {
+	meta, err := decommissionMetaInfo(nc)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range meta.Replicas {
+		if r.Name == c.peer {
+			log.Printf("Removing peer %s from the JetStream meta group", c.peer)
+			return mgr.MetaPeerRemove("", r.Peer)
+		}
+	}
+
+	if meta.Leader == c.peer {
+		return fmt.Errorf("peer %s is the meta leader, step it down first", c.peer)
+	}
+
+	return fmt.Errorf("peer %s is not part of the meta group", c.peer)
+}
+
+// verifyMeta confirms the meta group has a leader other than the peer and all other peers are current
+func (c *SrvDecommissionCmd) verifyMeta(nc *nats.Conn) (string, error) {
+	meta, err := decommissionMetaInfo(nc)
+	if err != nil {
+		return "", err
+	}
+
+	if meta.Leader == "" {
+		return "", fmt.Errorf("meta group has no leader")
+	}
+
+	lagging := 0
+	for _, r := range meta.Replicas {
+		if r.Name == c.peer {
+			continue
+		}
+		if r.Offline || !r.Current {
+			lagging++
+		}
+	}
+
+	if lagging > 0 {
+		return "", fmt.Errorf("%d meta group peers are not current", lagging)
+	}
+
+	return fmt.Sprintf("OK, leader %s with %d peers", meta.Leader, len(meta.Replicas)+1), nil
+}
+
+func decommissionMetaInfo(nc *nats.Conn) (*server.MetaClusterInfo, error) {
+	res, err := doReq(server.JSzOptions{LeaderOnly: true}, "$SYS.REQ.SERVER.PING.JSZ", 1, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 1 {
+		return nil, fmt.Errorf("did not receive a response from the meta leader, ensure the account used has system privileges and appropriate permissions")
+	}
+
+	var jsz struct {
+		Data server.JSInfo `json:"data"`
+	}
+	err = json.Unmarshal(res[0], &jsz)
+	if err != nil {
+		return nil, err
+	}
+
+	if jsz.Data.Meta == nil {
+		return nil, fmt.Errorf("no meta group information received, is JetStream clustered?")
+	}
+
+	return jsz.Data.Meta, nil
+}
+
+func clusterHasPeer(ci *api.ClusterInfo, peer string) bool {
+	if ci == nil {
+		return false
+	}
+
+	if ci.Leader == peer {
+		return true
+	}
+
+	for _, r := range ci.Replicas {
+		if r.Name == peer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clusterMoved determines if the group no longer includes peer and has a leader with all replicas current
+func clusterMoved(ci *api.ClusterInfo, peer string, replicas int) bool {
+	if ci == nil || ci.Leader == "" || clusterHasPeer(ci, peer) {
+		return false
+	}
+
+	if len(ci.Replicas)+1 < replicas {
+		return false
+	}
+
+	for _, r := range ci.Replicas {
+		if r.Offline || !r.Current {
+			return false
+		}
+	}
+
+	return true
+}
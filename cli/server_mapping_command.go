@@ -14,18 +14,22 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
-	"github.com/nats-io/natscli/internal/util"
+	"os"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/choria-io/fisk"
 	"github.com/nats-io/nats-server/v2/server"
+	iu "github.com/nats-io/natscli/internal/util"
 )
 
 type SrvMappingCmd struct {
-	src  string
-	dest string
-	subj string
+	src      string
+	dest     string
+	subj     string
+	subjects []string
 }
 
 func configureServerMappingCommand(srv *fisk.CmdClause) {
@@ -34,12 +38,19 @@ func configureServerMappingCommand(srv *fisk.CmdClause) {
 	m := srv.Command("mappings", "Test subject mapping patterns").Alias("mapping").Action(c.mappingAction)
 	m.Arg("source", "Source subject pattern").StringVar(&c.src)
 	m.Arg("dest", "Destination subject pattern").StringVar(&c.dest)
-	m.Arg("subject", "Subject to transform").StringVar(&c.subj)
+	m.HelpLong(`Evaluates subject mappings locally using the same transforms the server uses.
+
+Subjects to transform can be given as arguments, read one per line from STDIN
+or entered interactively, for example:
+
+   nats server mapping 'orders.*.*' 'orders.{{wildcard(2)}}.{{partition(3,1)}}' orders.1.eu
+   cat subjects.txt | nats server mapping 'orders.*.*' 'orders.{{wildcard(2)}}.{{partition(3,1)}}'`)
+	m.Arg("subject", "Subjects to transform").StringsVar(&c.subjects)
 }
 
 func (c *SrvMappingCmd) mappingAction(_ *fisk.ParseContext) error {
 	if c.src == "" {
-		err := util.AskOne(&survey.Input{
+		err := iu.AskOne(&survey.Input{
 			Message: "Source subject pattern",
 			Help:    "The pattern matching source subjects",
 		}, &c.src, survey.WithValidator(survey.Required))
@@ -49,7 +60,7 @@ func (c *SrvMappingCmd) mappingAction(_ *fisk.ParseContext) error {
 	}
 
 	if c.dest == "" {
-		err := util.AskOne(&survey.Input{
+		err := iu.AskOne(&survey.Input{
 			Message: "Destination subject pattern",
 			Help:    "The pattern matching describing the mapping to test",
 		}, &c.dest, survey.WithValidator(survey.Required))
@@ -63,27 +74,73 @@ func (c *SrvMappingCmd) mappingAction(_ *fisk.ParseContext) error {
 		return err
 	}
 
-	transAndShow := func(trans server.SubjectTransformer, subj string) {
+	transAndShow := func(trans server.SubjectTransformer, subj string) bool {
 		s, err := trans.Match(subj)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
+			fmt.Println()
+			return false
 		}
 
 		fmt.Println(s)
 		fmt.Println()
+
+		return true
 	}
 
-	if c.subj != "" {
-		transAndShow(trans, c.subj)
+	// batch mode showing source and destination for every subject
+	batch := func(subjects []string) error {
+		var failed int
+		for _, subj := range subjects {
+			s, err := trans.Match(subj)
+			if err != nil {
+				fmt.Printf("%s: error: %v\n", subj, err)
+				failed++
+				continue
+			}
+
+			fmt.Printf("%s: %s\n", subj, s)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d subjects could not be mapped", failed, len(subjects))
+		}
+
 		return nil
 	}
 
+	switch {
+	case len(c.subjects) == 1:
+		if !transAndShow(trans, c.subjects[0]) {
+			return fmt.Errorf("subject could not be mapped")
+		}
+		return nil
+
+	case len(c.subjects) > 1:
+		return batch(c.subjects)
+
+	case !iu.IsTerminal():
+		var subjects []string
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			subj := strings.TrimSpace(scanner.Text())
+			if subj != "" {
+				subjects = append(subjects, subj)
+			}
+		}
+		if scanner.Err() != nil {
+			return scanner.Err()
+		}
+
+		return batch(subjects)
+	}
+
 	fmt.Println("Enter subjects to test, empty subject terminates.")
 	fmt.Println()
 
 	for {
 		c.subj = ""
-		err = util.AskOne(&survey.Input{
+		err = iu.AskOne(&survey.Input{
 			Message: "Subject",
 			Help:    "Enter a subject that matching source and the mapping will be shown",
 		}, &c.subj)
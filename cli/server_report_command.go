@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"time"
@@ -36,6 +37,7 @@ type SrvReportCmd struct {
 	json bool
 
 	filterExpression        string
+	where                   string
 	account                 string
 	user                    string
 	waitFor                 int
@@ -55,6 +57,9 @@ type SrvReportCmd struct {
 	jsServerOnly            bool
 	stream                  string
 	consumer                string
+	csv                     bool
+	raftLag                 uint64
+	raftLastSeen            time.Duration
 }
 
 type srvReportAccountInfo struct {
@@ -88,6 +93,7 @@ func configureServerReportCommand(srv *fisk.CmdClause) {
 	acct.Flag("sort", "Sort by a specific property (in-bytes,out-bytes,in-msgs,out-msgs,conns,subs)").Default("subs").EnumVar(&c.sort, "in-bytes", "out-bytes", "in-msgs", "out-msgs", "conns", "subs")
 	acct.Flag("top", "Limit results to the top results").Default("1000").IntVar(&c.topk)
 	acct.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+	acct.Flag("csv", "Produce CSV output").UnNegatableBoolVar(&c.csv)
 
 	conns := report.Command("connections", "Report on connections").Alias("conn").Alias("connz").Alias("conns").Action(c.reportConnections)
 	conns.Arg("limit", "Limit the responses to a certain amount of servers").IntVar(&c.waitFor)
@@ -100,6 +106,7 @@ func configureServerReportCommand(srv *fisk.CmdClause) {
 	conns.Flag("state", "Limits responses only to those connections that are in a specific state (open, closed, all)").PlaceHolder("STATE").Default("open").EnumVar(&c.stateFilter, "open", "closed", "all")
 	conns.Flag("closed-reason", "Filter results based on a closed reason").PlaceHolder("REASON").StringVar(&c.filterReason)
 	conns.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+	conns.Flag("csv", "Produce CSV output").UnNegatableBoolVar(&c.csv)
 	conns.Flag("filter", "Expression based filter for connections").StringVar(&c.filterExpression)
 
 	cpu := report.Command("cpu", "Report on CPU usage").Action(c.reportCPU)
@@ -123,6 +130,19 @@ func configureServerReportCommand(srv *fisk.CmdClause) {
 	jsz.Flag("account", "Produce the report for a specific account").StringVar(&c.account)
 	jsz.Flag("sort", "Sort by a specific property (name,cluster,streams,consumers,msgs,mbytes,mem,file,api,err").Default("cluster").EnumVar(&c.sort, "name", "cluster", "streams", "consumers", "msgs", "mbytes", "bytes", "mem", "file", "store", "api", "err")
 	jsz.Flag("compact", "Compact server names").Default("true").BoolVar(&c.compact)
+	jsz.Flag("csv", "Produce CSV output").UnNegatableBoolVar(&c.csv)
+	addReportWhereFlag(jsz, &c.where, serverJetStreamWhereFields)
+
+	raft := report.Command("raft", "Report on RAFT health of the meta group, Streams and Consumers").Action(c.reportRaft)
+	raft.HelpLong(`Shows the JetStream meta group leader and peers and lists Streams and Consumers
+with replicas that are offline, not current, lagging or not seen recently.
+
+Requires a connection to the system account.`)
+	addFilterOpts(raft)
+	raft.Flag("account", "Only report on Streams and Consumers in a specific account").StringVar(&c.account)
+	raft.Flag("lag", "Number of operations a replica may lag before it is reported").Default("100").Uint64Var(&c.raftLag)
+	raft.Flag("last-seen", "Time since a replica was last seen before it is reported").Default("10s").DurationVar(&c.raftLastSeen)
+	raft.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
 
 	mem := report.Command("mem", "Report on Memory usage").Action(c.reportMem)
 	addFilterOpts(mem)
@@ -540,11 +560,19 @@ func (c *SrvReportCmd) reportCpuOrMem(mem bool) error {
 }
 
 func (c *SrvReportCmd) reportJetStream(_ *fisk.ParseContext) error {
+	where, err := newReportFilter(c.where)
+	if err != nil {
+		return err
+	}
+
 	nc, _, err := prepareHelper("", natsOpts()...)
 	if err != nil {
 		return err
 	}
 
+	out := newPager()
+	defer out.Close()
+
 	jszOpts := server.JSzOptions{}
 	if c.account != "" {
 		jszOpts.Account = c.account
@@ -586,6 +614,14 @@ func (c *SrvReportCmd) reportJetStream(_ *fisk.ParseContext) error {
 			return err
 		}
 
+		matched, err := where.match(serverJetStreamReportRow(response))
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
 		if response.Data.Config.Domain != "" {
 			renderDomain = true
 		}
@@ -629,6 +665,10 @@ func (c *SrvReportCmd) reportJetStream(_ *fisk.ParseContext) error {
 		}
 	})
 
+	if c.csv {
+		return c.renderJetStreamCSV(out, jszResponses)
+	}
+
 	if len(jszResponses) == 0 {
 		return fmt.Errorf("no results received, ensure the account used has system privileges and appropriate permissions")
 	}
@@ -755,16 +795,16 @@ func (c *SrvReportCmd) reportJetStream(_ *fisk.ParseContext) error {
 	}
 	table.AddFooter(row...)
 
-	fmt.Print(table.Render())
-	fmt.Println()
+	fmt.Fprint(out, table.Render())
+	fmt.Fprintln(out)
 
 	switch {
 	case c.isFiltered():
 	case expectedClusterSize == 0:
 	case len(jszResponses) > 0 && cluster == nil:
-		fmt.Println()
-		fmt.Printf("WARNING: No cluster meta leader found. The cluster expects %d nodes but only %d responded. JetStream operation requires at least %d up nodes.", expectedClusterSize, len(jszResponses), expectedClusterSize/2+1)
-		fmt.Println()
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "WARNING: No cluster meta leader found. The cluster expects %d nodes but only %d responded. JetStream operation requires at least %d up nodes.", expectedClusterSize, len(jszResponses), expectedClusterSize/2+1)
+		fmt.Fprintln(out)
 	default:
 		cluster.Replicas = append(cluster.Replicas, &server.PeerInfo{
 			Name:    cluster.Leader,
@@ -805,19 +845,53 @@ func (c *SrvReportCmd) reportJetStream(_ *fisk.ParseContext) error {
 
 			table.AddRow(cNames[i], peer, leader, replica.Current, online, f(replica.Active), f(replica.Lag))
 		}
-		fmt.Print(table.Render())
+		fmt.Fprint(out, table.Render())
 
 	}
 
 	return nil
 }
 
+func (c *SrvReportCmd) renderJetStreamCSV(w io.Writer, responses []*server.ServerAPIJszResponse) error {
+	var rows [][]any
+
+	for _, js := range responses {
+		jss := js.Data.JetStreamStats
+		streams := js.Data.Streams
+		consumers := js.Data.Consumers
+		msgs := js.Data.Messages
+		bytes := js.Data.Bytes
+
+		if c.account != "" && len(js.Data.AccountDetails) == 1 {
+			acc := js.Data.AccountDetails[0]
+			jss = acc.JetStreamStats
+			streams = len(acc.Streams)
+			bytes = acc.Memory + acc.Store
+			consumers = 0
+			msgs = 0
+			for _, sd := range acc.Streams {
+				consumers += sd.State.Consumers
+				msgs += sd.State.Msgs
+			}
+		}
+
+		leader := js.Data.Meta != nil && js.Data.Meta.Leader == js.Server.Name
+
+		rows = append(rows, []any{js.Server.Name, js.Server.Cluster, js.Data.Config.Domain, streams, consumers, msgs, bytes, jss.Memory, jss.Store, jss.API.Total, jss.API.Errors, leader})
+	}
+
+	return writeCSV(w, []string{"Server", "Cluster", "Domain", "Streams", "Consumers", "Messages", "Bytes", "Memory", "File", "API Req", "API Err", "Meta Leader"}, rows)
+}
+
 func (c *SrvReportCmd) reportAccount(_ *fisk.ParseContext) error {
 	nc, _, err := prepareHelper("", natsOpts()...)
 	if err != nil {
 		return err
 	}
 
+	out := newPager()
+	defer out.Close()
+
 	connz, err := c.getConnz(0, nc)
 	if err != nil {
 		return err
@@ -839,13 +913,17 @@ func (c *SrvReportCmd) reportAccount(_ *fisk.ParseContext) error {
 		}
 
 		if c.json {
-			iu.PrintJSON(account)
+			iu.FprintJSON(out, account)
 			return nil
 		}
 
+		if c.csv {
+			return c.renderConnectionsCSV(out, account.ConnInfo)
+		}
+
 		if len(account.ConnInfo) > 0 {
 			report := account.ConnInfo
-			c.renderConnections(report)
+			c.renderConnections(out, report)
 		}
 		return nil
 	}
@@ -882,10 +960,19 @@ func (c *SrvReportCmd) reportAccount(_ *fisk.ParseContext) error {
 	}
 
 	if c.json {
-		iu.PrintJSON(accounts)
+		iu.FprintJSON(out, accounts)
 		return nil
 	}
 
+	if c.csv {
+		var rows [][]any
+		for _, acct := range accounts {
+			rows = append(rows, []any{acct.Account, acct.Connections, acct.InMsgs, acct.OutMsgs, acct.InBytes, acct.OutBytes, acct.Subs})
+		}
+
+		return writeCSV(out, []string{"Account", "Connections", "In Msgs", "Out Msgs", "In Bytes", "Out Bytes", "Subs"}, rows)
+	}
+
 	table := iu.NewTableWriter(opts(), fmt.Sprintf("%d Accounts Overview", len(accounts)))
 	table.AddHeaders("Account", "Connections", "In Msgs", "Out Msgs", "In Bytes", "Out Bytes", "Subs")
 
@@ -893,7 +980,7 @@ func (c *SrvReportCmd) reportAccount(_ *fisk.ParseContext) error {
 		table.AddRow(acct.Account, f(acct.Connections), f(acct.InMsgs), f(acct.OutMsgs), humanize.IBytes(uint64(acct.InBytes)), humanize.IBytes(uint64(acct.OutBytes)), f(acct.Subs))
 	}
 
-	fmt.Print(table.Render())
+	fmt.Fprint(out, table.Render())
 
 	return nil
 }
@@ -945,6 +1032,9 @@ func (c *SrvReportCmd) reportConnections(_ *fisk.ParseContext) error {
 		return err
 	}
 
+	out := newPager()
+	defer out.Close()
+
 	connz, err := c.getConnz(0, nc)
 	if err != nil {
 		return err
@@ -957,15 +1047,34 @@ func (c *SrvReportCmd) reportConnections(_ *fisk.ParseContext) error {
 	conns := connz.flatConnInfo()
 
 	if c.json {
-		iu.PrintJSON(conns)
+		iu.FprintJSON(out, conns)
 		return nil
 	}
 
-	c.renderConnections(conns)
+	if c.csv {
+		return c.renderConnectionsCSV(out, conns)
+	}
+
+	c.renderConnections(out, conns)
 
 	return nil
 }
 
+func (c *SrvReportCmd) renderConnectionsCSV(w io.Writer, conns []connInfo) error {
+	c.sortConnections(conns)
+
+	if c.topk > 0 && c.topk < len(conns) {
+		conns = conns[:c.topk]
+	}
+
+	var rows [][]any
+	for _, info := range conns {
+		rows = append(rows, []any{info.Cid, info.Kind, info.Name, info.Info.Name, info.Info.Cluster, info.IP, info.Port, info.Account, info.Uptime, info.InMsgs, info.OutMsgs, info.InBytes, info.OutBytes, info.NumSubs, info.Reason})
+	}
+
+	return writeCSV(w, []string{"CID", "Kind", "Name", "Server", "Cluster", "IP", "Port", "Account", "Uptime", "In Msgs", "Out Msgs", "In Bytes", "Out Bytes", "Subs", "Reason"}, rows)
+}
+
 func (c *SrvReportCmd) boolReverse(v bool) bool {
 	if c.reverse {
 		return !v
@@ -995,7 +1104,7 @@ func (c *SrvReportCmd) sortConnections(conns []connInfo) {
 	})
 }
 
-func (c *SrvReportCmd) renderConnections(report []connInfo) {
+func (c *SrvReportCmd) renderConnections(w io.Writer, report []connInfo) {
 	c.sortConnections(report)
 
 	total := len(report)
@@ -1079,10 +1188,10 @@ func (c *SrvReportCmd) renderConnections(report []connInfo) {
 		table.AddFooter(values...)
 	}
 
-	fmt.Print(table.Render())
+	fmt.Fprint(w, table.Render())
 
 	if len(serverNames) > 0 {
-		fmt.Println()
+		fmt.Fprintln(w)
 
 		sort.Slice(serverNames, func(i, j int) bool {
 			return servers[serverNames[i]].conns < servers[serverNames[j]].conns
@@ -1097,7 +1206,7 @@ func (c *SrvReportCmd) renderConnections(report []connInfo) {
 		for _, n := range serverNames {
 			table.AddRow(n, servers[n].cluster, servers[n].conns)
 		}
-		fmt.Print(table.Render())
+		fmt.Fprint(w, table.Render())
 	}
 }
 
@@ -0,0 +1,210 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/fatih/color"
+	"github.com/nats-io/nats-server/v2/server"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// raftGroupProblem is a Stream or Consumer RAFT group with unhealthy replicas
+type raftGroupProblem struct {
+	Account  string   `json:"account"`
+	Stream   string   `json:"stream"`
+	Consumer string   `json:"consumer,omitempty"`
+	Leader   string   `json:"leader,omitempty"`
+	Replica  string   `json:"replica,omitempty"`
+	Problems []string `json:"problems"`
+}
+
+type raftReport struct {
+	MetaLeader string              `json:"meta_leader,omitempty"`
+	MetaPeers  []*server.PeerInfo  `json:"meta_peers,omitempty"`
+	Problems   []*raftGroupProblem `json:"problems"`
+}
+
+// raftPeerProblems lists the reasons a replica is considered unhealthy
+func raftPeerProblems(peer *server.PeerInfo, lag uint64, lastSeen time.Duration) []string {
+	var problems []string
+
+	if peer.Offline {
+		problems = append(problems, "offline")
+	}
+	if !peer.Current {
+		problems = append(problems, "not current")
+	}
+	if peer.Lag > lag {
+		problems = append(problems, fmt.Sprintf("lagging %s operations", f(peer.Lag)))
+	}
+	if lastSeen > 0 && peer.Active > lastSeen {
+		problems = append(problems, fmt.Sprintf("last seen %s ago", f(peer.Active)))
+	}
+
+	return problems
+}
+
+// raftGroupProblems finds unhealthy replicas in a group as reported by the server named reporter,
+// replica state is only known to the leader so reports from other servers are ignored unless there is no leader
+func raftGroupProblems(reporter string, ci *server.ClusterInfo, lag uint64, lastSeen time.Duration) (problems []*raftGroupProblem, authoritative bool) {
+	if ci == nil {
+		return nil, false
+	}
+
+	if ci.Leader == "" {
+		return []*raftGroupProblem{{Problems: []string{"no leader"}}}, true
+	}
+
+	if ci.Leader != reporter {
+		return nil, false
+	}
+
+	for _, peer := range ci.Replicas {
+		p := raftPeerProblems(peer, lag, lastSeen)
+		if len(p) > 0 {
+			problems = append(problems, &raftGroupProblem{Leader: ci.Leader, Replica: peer.Name, Problems: p})
+		}
+	}
+
+	return problems, true
+}
+
+func (c *SrvReportCmd) reportRaft(_ *fisk.ParseContext) error {
+	nc, _, err := prepareHelper("", natsOpts()...)
+	if err != nil {
+		return err
+	}
+
+	jszOpts := server.JSzOptions{
+		Account:  c.account,
+		Accounts: c.account == "",
+		Streams:  true,
+		Consumer: true,
+	}
+
+	res, err := doJszReq(jszOpts, c.reqFilter(), c.waitFor, nc)
+	if err != nil {
+		return err
+	}
+
+	if len(res) == 0 {
+		return fmt.Errorf("no results received, ensure the account used has system privileges and appropriate permissions")
+	}
+
+	report := &raftReport{}
+	seen := map[string]bool{}
+
+	add := func(key string, account string, stream string, consumer string, problems []*raftGroupProblem) {
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		for _, p := range problems {
+			p.Account = account
+			p.Stream = stream
+			p.Consumer = consumer
+			report.Problems = append(report.Problems, p)
+		}
+	}
+
+	for _, response := range res {
+		if response.Data == nil {
+			continue
+		}
+
+		if meta := response.Data.Meta; meta != nil && meta.Leader == response.Server.Name {
+			report.MetaLeader = meta.Leader
+			report.MetaPeers = append([]*server.PeerInfo{{Name: meta.Leader, Peer: meta.Peer, Current: true}}, meta.Replicas...)
+		}
+
+		for _, acct := range response.Data.AccountDetails {
+			for _, sd := range acct.Streams {
+				problems, ok := raftGroupProblems(response.Server.Name, sd.Cluster, c.raftLag, c.raftLastSeen)
+				if ok {
+					add(acct.Name+"/"+sd.Name, acct.Name, sd.Name, "", problems)
+				}
+
+				for _, cons := range sd.Consumer {
+					problems, ok := raftGroupProblems(response.Server.Name, cons.Cluster, c.raftLag, c.raftLastSeen)
+					if ok {
+						add(acct.Name+"/"+sd.Name+"/"+cons.Name, acct.Name, sd.Name, cons.Name, problems)
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(report.MetaPeers, func(i, j int) bool {
+		return report.MetaPeers[i].Name < report.MetaPeers[j].Name
+	})
+
+	sort.SliceStable(report.Problems, func(i, j int) bool {
+		a, b := report.Problems[i], report.Problems[j]
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		if a.Stream != b.Stream {
+			return a.Stream < b.Stream
+		}
+		return a.Consumer < b.Consumer
+	})
+
+	if c.json {
+		return iu.PrintJSON(report)
+	}
+
+	if report.MetaLeader == "" {
+		fmt.Println(color.RedString("No JetStream meta group leader found"))
+		fmt.Println()
+	} else {
+		table := iu.NewTableWriter(opts(), fmt.Sprintf("RAFT Meta Group led by %s", report.MetaLeader))
+		table.AddHeaders("Server", "ID", "Leader", "Current", "Online", "Last Seen", "Lag")
+		for _, peer := range report.MetaPeers {
+			leader := ""
+			if peer.Name == report.MetaLeader {
+				leader = "yes"
+			}
+
+			row := []any{peer.Name, peer.Peer, leader, peer.Current, !peer.Offline, f(peer.Active), f(peer.Lag)}
+			if len(raftPeerProblems(peer, c.raftLag, c.raftLastSeen)) > 0 {
+				for i, v := range row {
+					row[i] = color.RedString(fmt.Sprint(v))
+				}
+			}
+			table.AddRow(row...)
+		}
+		fmt.Println(table.Render())
+	}
+
+	if len(report.Problems) == 0 {
+		fmt.Println("All Stream and Consumer replicas are healthy")
+		return nil
+	}
+
+	table := iu.NewTableWriter(opts(), fmt.Sprintf("%s unhealthy Stream and Consumer replicas", f(len(report.Problems))))
+	table.AddHeaders("Account", "Stream", "Consumer", "Leader", "Replica", "Problems")
+	for _, p := range report.Problems {
+		table.AddRow(p.Account, p.Stream, p.Consumer, p.Leader, p.Replica, color.RedString(strings.Join(p.Problems, ", ")))
+	}
+	fmt.Println(table.Render())
+
+	return nil
+}
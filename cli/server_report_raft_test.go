@@ -0,0 +1,57 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+func TestRaftGroupProblems(t *testing.T) {
+	healthy := &server.PeerInfo{Name: "n2", Current: true, Active: time.Second}
+	lagging := &server.PeerInfo{Name: "n3", Current: true, Active: time.Minute, Lag: 1000}
+	offline := &server.PeerInfo{Name: "n4", Offline: true}
+
+	if p := raftPeerProblems(healthy, 100, 10*time.Second); len(p) != 0 {
+		t.Fatalf("expected no problems, got %v", p)
+	}
+	if p := raftPeerProblems(lagging, 100, 10*time.Second); len(p) != 2 {
+		t.Fatalf("expected lag and last seen problems, got %v", p)
+	}
+	if p := raftPeerProblems(offline, 100, 10*time.Second); len(p) != 2 || p[0] != "offline" || p[1] != "not current" {
+		t.Fatalf("expected offline problems, got %v", p)
+	}
+
+	ci := &server.ClusterInfo{Leader: "n1", Replicas: []*server.PeerInfo{healthy, lagging, offline}}
+
+	problems, ok := raftGroupProblems("n2", ci, 100, 10*time.Second)
+	if ok || len(problems) != 0 {
+		t.Fatalf("expected follower report to be ignored")
+	}
+
+	problems, ok = raftGroupProblems("n1", ci, 100, 10*time.Second)
+	if !ok || len(problems) != 2 {
+		t.Fatalf("expected 2 problems from leader, got %d", len(problems))
+	}
+	if problems[0].Replica != "n3" || problems[1].Replica != "n4" {
+		t.Fatalf("invalid problem replicas %s %s", problems[0].Replica, problems[1].Replica)
+	}
+
+	problems, ok = raftGroupProblems("n2", &server.ClusterInfo{}, 100, 10*time.Second)
+	if !ok || len(problems) != 1 || problems[0].Problems[0] != "no leader" {
+		t.Fatalf("expected leaderless group problem")
+	}
+}
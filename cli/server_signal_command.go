@@ -0,0 +1,155 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+type SrvSignalCmd struct {
+	server string
+	signal string
+	local  bool
+	force  bool
+}
+
+func configureServerSignalCommand(srv *fisk.CmdClause) {
+	c := &SrvSignalCmd{}
+
+	help := `Sends a control signal to a NATS Server
+
+The reload signal is sent over the system account to the server with the
+given ID.
+
+The NATS Server does not support lame duck mode or shutdown requests over
+the system account, these signals can only be sent to server processes
+on the local machine using --local, the server is then a PID or PID file.
+
+   nats server signal NDJWE4SOUJOJT2TY5Y2YQEOAHGAK5VIGXTGKWJSFHVCII4ITI3LBHBUV reload
+   nats server signal /var/run/nats-server.pid ldm --local
+`
+
+	sig := srv.Command("signal", "Sends control signals to servers").Action(c.signalAction)
+	sig.HelpLong(help)
+	sig.Arg("server", "The server ID, or PID when using --local, to signal").Required().StringVar(&c.server)
+	sig.Arg("signal", "The signal to send (reload, ldm, quit)").Required().EnumVar(&c.signal, "reload", "ldm", "quit")
+	sig.Flag("local", "Signal a server process running on this machine").UnNegatableBoolVar(&c.local)
+	sig.Flag("force", "Force signaling without prompting").Short('f').UnNegatableBoolVar(&c.force)
+}
+
+func (c *SrvSignalCmd) signalAction(pc *fisk.ParseContext) error {
+	if c.local {
+		return c.signalLocal()
+	}
+
+	if c.signal != "reload" {
+		return fmt.Errorf("the NATS Server does not support the %s signal over the system account, use --local on the server host", c.signal)
+	}
+
+	nc, err := newNatsConn("", natsOpts()...)
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	if !c.force {
+		resps, err := doReq(nil, fmt.Sprintf("$SYS.REQ.SERVER.%s.VARZ", c.server), 1, nc)
+		if err != nil {
+			return err
+		}
+
+		if len(resps) != 1 {
+			return fmt.Errorf("invalid response from %d servers", len(resps))
+		}
+
+		vz := server.ServerAPIResponse{}
+		err = json.Unmarshal(resps[0], &vz)
+		if err != nil {
+			return err
+		}
+
+		if vz.Error != nil {
+			return fmt.Errorf("%s", vz.Error.Description)
+		}
+
+		ok, err := askConfirmation(fmt.Sprintf("Really send the %s signal to %s (%s) on %s", c.signal, vz.Server.Name, vz.Server.ID, vz.Server.Host), false)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return nil
+		}
+	}
+
+	resps, err := doReq(nil, fmt.Sprintf("$SYS.REQ.SERVER.%s.RELOAD", c.server), 1, nc)
+	if err != nil {
+		return err
+	}
+
+	if len(resps) != 1 {
+		return fmt.Errorf("invalid response from %d servers", len(resps))
+	}
+
+	res := server.ServerAPIResponse{}
+	err = json.Unmarshal(resps[0], &res)
+	if err != nil {
+		return err
+	}
+
+	if res.Error != nil {
+		return fmt.Errorf("reload failed: %s", res.Error.Description)
+	}
+
+	fmt.Printf("Sent the %s signal to %s\n", c.signal, c.server)
+
+	return nil
+}
+
+func (c *SrvSignalCmd) signalLocal() error {
+	var cmd server.Command
+
+	switch c.signal {
+	case "reload":
+		cmd = server.CommandReload
+	case "ldm":
+		cmd = server.CommandLDMode
+	case "quit":
+		cmd = server.CommandQuit
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really send the %s signal to local server process %s", c.signal, c.server), false)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return nil
+		}
+	}
+
+	err := server.ProcessSignal(cmd, c.server)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Sent the %s signal to local server process %s\n", c.signal, c.server)
+
+	return nil
+}
@@ -38,6 +38,8 @@ type SrvWatchJSCmd struct {
 	servers   map[string]*server.ServerStatsMsg
 	sortNames map[string]string
 	lastMsg   time.Time
+	notify    notifier
+	pending   int
 	mu        sync.Mutex
 }
 
@@ -63,6 +65,8 @@ Since the updates are sent on a 30 second interval this is not a point in time v
 `)
 	js.Flag("sort", fmt.Sprintf("Sorts by a specific property (%s)", strings.Join(sortKeys, ", "))).Default("assets").EnumVar(&c.sort, sortKeys...)
 	js.Flag("number", "Amount of Accounts to show by the selected dimension").Default("0").Short('n').IntVar(&c.top)
+	js.Flag("pending-threshold", "Notify when the meta leader has more than this many pending API requests").PlaceHolder("REQUESTS").IntVar(&c.pending)
+	addNotifyFlags(js, &c.notify)
 }
 
 func (c *SrvWatchJSCmd) updateSizes() error {
@@ -172,6 +176,8 @@ func (c *SrvWatchJSCmd) redraw(drawPending bool) error {
 		apiPending int
 	)
 
+	var leader string
+
 	for _, srv := range c.servers {
 		if srv.Stats.JetStream == nil {
 			continue
@@ -179,6 +185,10 @@ func (c *SrvWatchJSCmd) redraw(drawPending bool) error {
 
 		servers = append(servers, srv)
 
+		if srv.Stats.JetStream.Meta != nil && srv.Stats.JetStream.Meta.Leader == srv.Server.Name {
+			leader = srv.Server.Name
+		}
+
 		assets += srv.Stats.JetStream.Stats.HAAssets
 		mem += srv.Stats.JetStream.Stats.Memory
 		store += srv.Stats.JetStream.Stats.Store
@@ -189,6 +199,8 @@ func (c *SrvWatchJSCmd) redraw(drawPending bool) error {
 		}
 	}
 
+	c.alerts(leader, apiPending)
+
 	sort.Slice(servers, func(i, j int) bool {
 		si := servers[i].Stats.JetStream.Stats
 		sj := servers[j].Stats.JetStream.Stats
@@ -264,3 +276,18 @@ func (c *SrvWatchJSCmd) redraw(drawPending bool) error {
 
 	return nil
 }
+
+// alerts notifies about meta leader changes and pending API request threshold breaches
+func (c *SrvWatchJSCmd) alerts(leader string, pending int) {
+	if !c.notify.enabled() {
+		return
+	}
+
+	if leader != "" && c.notify.changed("leader", leader) {
+		c.notify.notify("JetStream", "Meta leader changed to %s", leader)
+	}
+
+	if c.pending > 0 && c.notify.breached("pending", pending > c.pending) {
+		c.notify.notify("JetStream", "%s pending API requests exceeds %s", f(pending), f(c.pending))
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	iu "github.com/nats-io/natscli/internal/util"
+	"math/rand"
 	"os"
 	"sort"
 	"strconv"
@@ -33,10 +34,16 @@ import (
 )
 
 type serviceCmd struct {
-	name     string
-	id       string
-	showJSON bool
-	hdrs     map[string]string
+	name      string
+	id        string
+	showJSON  bool
+	hdrs      map[string]string
+	endpoint  string
+	version   string
+	reply     string
+	replyFile string
+	delay     time.Duration
+	errorRate float64
 
 	nc *nats.Conn
 }
@@ -45,6 +52,7 @@ func configureServiceCommand(app commandHost) {
 	c := &serviceCmd{hdrs: map[string]string{}}
 
 	mc := app.Command("service", "Services discovery and management").Alias("micro")
+	addCheat("service", mc)
 
 	ls := mc.Command("list", "List known Services").Alias("ls").Alias("l").Action(c.listAction)
 	ls.Arg("service", "List instances of a specific Service").PlaceHolder("NAME").StringVar(&c.name)
@@ -60,12 +68,27 @@ func configureServiceCommand(app commandHost) {
 	stats.Arg("id", "Show info for a specific ID").StringVar(&c.id)
 	stats.Flag("json", "Show JSON output").Short('j').UnNegatableBoolVar(&c.showJSON)
 
-	ping := mc.Command("ping", "Sends a ping to all Services").Action(c.pingAction)
-	ping.Arg("service", "Service to show").StringVar(&c.name)
+	ping := mc.Command("ping", "Sends a ping to all Services or a specific Service instance").Action(c.pingAction)
+	ping.Arg("service", "Service to ping").StringVar(&c.name)
+	ping.Arg("id", "Ping a specific instance ID").StringVar(&c.id)
 
 	echo := mc.Command("serve", "Runs a demo Service").Action(c.serveAction)
+	echo.HelpLong(`Runs a Service that echoes requests back or responds with a static or templated
+reply, optionally after a delay and failing a percentage of requests, so clients
+can be tested against a realistic responder.
+
+Replies are templates with the same functions as 'nats reply', for example
+{{ Request }} for the request body and {{ Count }} for the request number.
+
+   nats micro serve orders --endpoint create --reply-file order.json --delay 50ms --error-rate 5`)
 	echo.Arg("name", "A name for the service to run on").Required().StringVar(&c.name)
 	echo.Flag("header", "Headers to add to responses using K:V format").Short('H').StringMapVar(&c.hdrs)
+	echo.Flag("endpoint", "Name of the endpoint to listen on").Default("echo").StringVar(&c.endpoint)
+	echo.Flag("version", "Version of the Service to advertise").Default("1.0.0").StringVar(&c.version)
+	echo.Flag("reply", "Respond with a static or templated reply instead of echoing requests").PlaceHolder("BODY").StringVar(&c.reply)
+	echo.Flag("reply-file", "Respond with a static or templated reply read from a file").PlaceHolder("FILE").ExistingFileVar(&c.replyFile)
+	echo.Flag("delay", "Wait this period before responding").PlaceHolder("DURATION").DurationVar(&c.delay)
+	echo.Flag("error-rate", "Percentage of requests to fail with an error").PlaceHolder("PERCENT").Float64Var(&c.errorRate)
 }
 
 func init() {
@@ -97,11 +120,67 @@ func (c *serviceCmd) echoHandler(req micro.Request) {
 	req.Respond(req.Data(), micro.WithHeaders(micro.Headers(hdr)))
 }
 
+// replyHandler responds to requests using the configured reply body, delay and error rate
+func (c *serviceCmd) replyHandler(req micro.Request, body string, ctr int) {
+	if c.delay > 0 {
+		time.Sleep(c.delay)
+	}
+
+	if serviceShouldFail(c.errorRate, rand.Float64()) {
+		log.Printf("Failing request %d on subject %v", ctr, req.Subject())
+		req.Error("500", "simulated failure", nil)
+		return
+	}
+
+	if body == "" {
+		c.echoHandler(req)
+		return
+	}
+
+	log.Printf("Handling request %d on subject %v", ctr, req.Subject())
+
+	reply, err := pubReplyBodyTemplate(body, string(req.Data()), ctr)
+	if err != nil {
+		log.Printf("Could not render reply: %v", err)
+		req.Error("500", "could not render reply", nil)
+		return
+	}
+
+	hdr := nats.Header{}
+	for k, v := range c.hdrs {
+		hdr.Add(k, v)
+	}
+
+	req.Respond(reply, micro.WithHeaders(micro.Headers(hdr)))
+}
+
+// serviceShouldFail determines if a request should fail given the error rate as a percentage and a random number in [0,1)
+func serviceShouldFail(rate float64, r float64) bool {
+	return rate > 0 && r*100 < rate
+}
+
 func (c *serviceCmd) serveAction(_ *fisk.ParseContext) error {
 	var err error
 	var combinedPayload int
+	var requests int
 	var mu sync.Mutex
 
+	if c.reply != "" && c.replyFile != "" {
+		return fmt.Errorf("--reply and --reply-file are mutually exclusive")
+	}
+	if c.errorRate < 0 || c.errorRate > 100 {
+		return fmt.Errorf("--error-rate must be between 0 and 100")
+	}
+
+	body := c.reply
+	if c.replyFile != "" {
+		b, err := os.ReadFile(c.replyFile)
+		if err != nil {
+			return err
+		}
+		body = string(b)
+	}
+
 	c.nc, _, err = prepareHelper("", natsOpts()...)
 	if err != nil {
 		return fmt.Errorf("setup failed: %v", err)
@@ -109,7 +188,7 @@ func (c *serviceCmd) serveAction(_ *fisk.ParseContext) error {
 
 	srv, err := micro.AddService(c.nc, micro.Config{
 		Name:        c.name,
-		Version:     "1.0.0",
+		Version:     c.version,
 		Description: fmt.Sprintf("NATS CLI Demo Service (%s)", c.name),
 		Metadata: map[string]string{
 			"_nats.client.created.library": "natscli",
@@ -129,12 +208,20 @@ func (c *serviceCmd) serveAction(_ *fisk.ParseContext) error {
 	}
 
 	grp := srv.AddGroup(c.name)
-	err = grp.AddEndpoint("echo", micro.HandlerFunc(func(request micro.Request) {
+	err = grp.AddEndpoint(c.endpoint, micro.HandlerFunc(func(request micro.Request) {
 		mu.Lock()
 		combinedPayload += len(request.Data())
+		requests++
+		ctr := requests
 		mu.Unlock()
 
-		c.echoHandler(request)
+		if body == "" && c.delay == 0 && c.errorRate == 0 {
+			c.echoHandler(request)
+			return
+		}
+
+		// delayed responses are handled concurrently like a real service would
+		go c.replyHandler(request, body, ctr)
 	}))
 	if err != nil {
 		return err
@@ -142,7 +229,16 @@ func (c *serviceCmd) serveAction(_ *fisk.ParseContext) error {
 
 	cols := newColumns("NATS CLI Service %s handler %d waiting for requests on %s", c.name, os.Getpid(), c.nc.ConnectedUrlRedacted())
 	cols.AddSectionTitle("Listening Subjects")
-	cols.AddRow(fmt.Sprintf("%s.echo", c.name), "Echo Service")
+	if body == "" {
+		cols.AddRow(fmt.Sprintf("%s.%s", c.name, c.endpoint), "Echo Service")
+	} else {
+		cols.AddRow(fmt.Sprintf("%s.%s", c.name, c.endpoint), "Reply Service")
+	}
+	if c.delay > 0 || c.errorRate > 0 {
+		cols.AddSectionTitle("Simulated Behavior")
+		cols.AddRowIf("Delay", c.delay, c.delay > 0)
+		cols.AddRowIf("Error Rate", fmt.Sprintf("%.1f%%", c.errorRate), c.errorRate > 0)
+	}
 	if len(c.hdrs) > 0 {
 		cols.AddSectionTitle("Custom Response Headers")
 		for k, v := range c.hdrs {
@@ -176,7 +272,7 @@ func (c *serviceCmd) parseMessage(m []byte, expectedType string) (any, error) {
 		err    error
 	)
 
-	if os.Getenv("NOVALIDATE") == "" {
+	if validationLevel() != validationOff {
 		t, parsed, err = api.ParseAndValidateMessage(m, validator())
 	} else {
 		t, parsed, err = api.ParseMessage(m)
@@ -232,8 +328,8 @@ func (c *serviceCmd) getInfo(nc *nats.Conn, name string, id string, wait int) ([
 	}
 
 	sort.Slice(nfos, func(i, j int) bool {
-		if nfos[i].Name < nfos[j].Name {
-			return true
+		if nfos[i].Name != nfos[j].Name {
+			return nfos[i].Name < nfos[j].Name
 		}
 
 		return nfos[i].ID < nfos[j].ID
@@ -248,10 +344,16 @@ func (c *serviceCmd) pingAction(_ *fisk.ParseContext) error {
 		return fmt.Errorf("setup failed: %v", err)
 	}
 
+	if c.id != "" && c.name == "" {
+		return fmt.Errorf("a service name is required when pinging a specific instance")
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), opts().Timeout)
 	defer cancel()
 
 	start := time.Now()
+	var mu sync.Mutex
+	var responses int
 
 	sub, err := nc.Subscribe(nc.NewRespInbox(), func(m *nats.Msg) {
 		if opts().Trace {
@@ -262,13 +364,24 @@ func (c *serviceCmd) pingAction(_ *fisk.ParseContext) error {
 			return
 		}
 		r := resp.(*micro.Ping)
-		fmt.Printf("%-50s rtt=%s\n", fmt.Sprintf("%s %s", r.Name, r.ID), f(time.Since(start)))
+
+		mu.Lock()
+		responses++
+		mu.Unlock()
+
+		fmt.Printf("%-50s version=%s rtt=%s\n", fmt.Sprintf("%s %s", r.Name, r.ID), r.Version, f(time.Since(start)))
+
+		// instance ids are unique so no further responses are expected
+		if c.id != "" {
+			cancel()
+		}
 	})
 	if err != nil {
 		return err
 	}
+	defer sub.Unsubscribe()
 
-	msg := nats.NewMsg(c.makeSubj(micro.PingVerb, c.name, ""))
+	msg := nats.NewMsg(c.makeSubj(micro.PingVerb, c.name, c.id))
 	msg.Reply = sub.Subject
 	nc.PublishMsg(msg)
 	if opts().Trace {
@@ -276,6 +389,13 @@ func (c *serviceCmd) pingAction(_ *fisk.ParseContext) error {
 	}
 	<-ctx.Done()
 
+	mu.Lock()
+	defer mu.Unlock()
+
+	if responses == 0 {
+		return fmt.Errorf("no responses received")
+	}
+
 	return nil
 }
 
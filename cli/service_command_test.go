@@ -0,0 +1,39 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+)
+
+func TestServiceShouldFail(t *testing.T) {
+	cases := []struct {
+		rate   float64
+		r      float64
+		expect bool
+	}{
+		{0, 0, false},
+		{0, 0.5, false},
+		{5, 0.01, true},
+		{5, 0.05, false},
+		{5, 0.9, false},
+		{100, 0.999, true},
+	}
+
+	for _, tc := range cases {
+		if serviceShouldFail(tc.rate, tc.r) != tc.expect {
+			t.Fatalf("expected %v for rate %v and %v", tc.expect, tc.rate, tc.r)
+		}
+	}
+}
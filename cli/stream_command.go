@@ -17,12 +17,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -44,6 +46,7 @@ import (
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jsm.go/balancer"
+	"github.com/nats-io/nats-server/v2/server"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/natscli/columns"
 	"gopkg.in/yaml.v3"
@@ -52,10 +55,16 @@ import (
 type streamCmd struct {
 	stream           string
 	force            bool
+	allowLoop        bool
 	json             bool
+	outTemplate      string
+	csv              bool
+	replicaWait      time.Duration
 	msgID            int64
 	retentionPolicyS string
 	inputFile        string
+	configValues     []string
+	configValuesFile string
 	outFile          string
 	filterSubject    string
 	showAll          bool
@@ -81,6 +90,8 @@ type streamCmd struct {
 	reportSort             string
 	reportRaw              bool
 	reportLimitCluster     string
+	reportWhere            string
+	metaSelectors          map[string]string
 	reportLeaderDistrib    bool
 	discardPolicy          string
 	validateOnly           bool
@@ -94,13 +105,16 @@ type streamCmd struct {
 	placementTags          []string
 	placementClusterSet    bool
 	placementTagsSet       bool
+	showPlacement          bool
 	peerName               string
 	sources                []string
+	sourceTransforms       []string
 	mirror                 string
 	interactive            bool
 	purgeKeep              uint64
 	purgeSubject           string
 	purgeSequence          uint64
+	purgeOlderThan         time.Duration
 	description            string
 	subjectTransformSource string
 	subjectTransformDest   string
@@ -150,6 +164,15 @@ type streamCmd struct {
 	vwPageSize   int
 	vwRaw        bool
 	vwTranslate  string
+	vwDecoders   []string
+	getDirect    bool
+	kvCompatible bool
+	tailStreams  []string
+	tailMergeBy  string
+	tailWindow   time.Duration
+	vwDisplay    msgDisplay
+	vwUntilSeal  bool
+	vwGrace      time.Duration
 	vwSubject    string
 
 	dryRun             bool
@@ -160,6 +183,44 @@ type streamCmd struct {
 	placementPreferred string
 	allowMsgTTlSet     bool
 	allowMsgTTL        bool
+	copyData           bool
+	watchInterval      time.Duration
+	watchNotify        notifier
+	watchPending       uint64
+	watchLag           uint64
+	orphanStoreDir     string
+	orphanServer       string
+	seqQuery           string
+	dumpTarget         string
+	dumpJSONL          bool
+	dumpSince          time.Duration
+	exportResume       bool
+	exportCheckpoint   uint64
+	grepPattern        string
+	grepJSONPath       string
+	grepIgnoreCase     bool
+	grepMaxMatches     int
+	republishStartSeq  uint64
+	republishEndSeq    uint64
+	republishUntil     time.Duration
+	republishDest      string
+	republishPrefix    string
+	republishTarget    string
+	migrateContext     string
+	migrateMethod      string
+	publishersDuration time.Duration
+	publishersAccount  string
+	publishersHeader   string
+	publishersTop      int
+}
+
+type streamTokenStat struct {
+	Position    int    `json:"position"`
+	Distinct    int    `json:"distinct"`
+	Subjects    int    `json:"subjects"`
+	Messages    uint64 `json:"messages"`
+	Top         string `json:"top"`
+	TopMessages uint64 `json:"top_messages"`
 }
 
 type streamStat struct {
@@ -179,7 +240,7 @@ type streamStat struct {
 }
 
 func configureStreamCommand(app commandHost) {
-	c := &streamCmd{msgID: -1, metadata: map[string]string{}}
+	c := &streamCmd{msgID: -1, metadata: map[string]string{}, metaSelectors: map[string]string{}}
 
 	addCreateFlags := func(f *fisk.CmdClause, edit bool) {
 		f.Flag("subjects", "Subjects that are consumed by the Stream").Default().StringsVar(&c.subjects)
@@ -210,11 +271,13 @@ func configureStreamCommand(app commandHost) {
 		f.Flag("dupe-window", "Duration of the duplicate message tracking window").Default("").StringVar(&c.dupeWindow)
 		f.Flag("mirror", "Completely mirror another stream").StringVar(&c.mirror)
 		f.Flag("source", "Source data from other Streams, merging into this one").PlaceHolder("STREAM").StringsVar(&c.sources)
+		f.Flag("source-transform", "Filter and transform subjects of a source or mirror Stream").PlaceHolder("STREAM:SOURCE:DEST").StringsVar(&c.sourceTransforms)
 		f.Flag("allow-rollup", "Allows roll-ups to be done by publishing messages with special headers").IsSetByUser(&c.allowRollupSet).BoolVar(&c.allowRollup)
 		f.Flag("deny-delete", "Deny messages from being deleted via the API").IsSetByUser(&c.denyDeleteSet).BoolVar(&c.denyDelete)
 		f.Flag("deny-purge", "Deny entire stream or subject purges via the API").IsSetByUser(&c.denyPurgeSet).BoolVar(&c.denyPurge)
 		f.Flag("allow-direct", "Allows fast, direct, access to stream data via the direct get API").IsSetByUser(&c.allowDirectSet).Default("true").BoolVar(&c.allowDirect)
 		f.Flag("allow-mirror-direct", "Allows fast, direct, access to stream data via the direct get API on mirrors").IsSetByUser(&c.allowMirrorDirectSet).BoolVar(&c.allowMirrorDirect)
+		f.Flag("mirror-direct", "Allows fast, direct, access to stream data via the direct get API on mirrors").Hidden().IsSetByUser(&c.allowMirrorDirectSet).BoolVar(&c.allowMirrorDirect)
 		if !edit {
 			f.Flag("allow-msg-ttl", "Allows per-message TTL handling").IsSetByUser(&c.allowMsgTTlSet).UnNegatableBoolVar(&c.allowMsgTTL)
 		}
@@ -223,6 +286,7 @@ func configureStreamCommand(app commandHost) {
 		f.Flag("metadata", "Adds metadata to the stream").PlaceHolder("META").IsSetByUser(&c.metadataIsSet).StringMapVar(&c.metadata)
 		f.Flag("republish-source", "Republish messages to --republish-destination").PlaceHolder("SOURCE").StringVar(&c.repubSource)
 		f.Flag("republish-destination", "Republish destination for messages in --republish-source").PlaceHolder("DEST").StringVar(&c.repubDest)
+		f.Flag("allow-loop", "Allow republish destinations that overlap with the Stream subjects").UnNegatableBoolVar(&c.allowLoop)
 		f.Flag("republish-headers", "Republish only message headers, no bodies").UnNegatableBoolVar(&c.repubHeadersOnly)
 		if !edit {
 			f.Flag("limit-consumer-inactive", "The maximum Consumer inactive threshold the Stream allows").PlaceHolder("THRESHOLD").DurationVar(&c.limitInactiveThreshold)
@@ -245,20 +309,27 @@ func configureStreamCommand(app commandHost) {
 
 	strAdd := str.Command("add", "Create a new Stream").Alias("create").Alias("new").Action(c.addAction)
 	strAdd.Arg("stream", "Stream name").StringVar(&c.stream)
-	strAdd.Flag("config", "JSON file to read configuration from").ExistingFileVar(&c.inputFile)
-	strAdd.Flag("validate", "Only validates the configuration against the official Schema").UnNegatableBoolVar(&c.validateOnly)
+	strAdd.Flag("config", "JSON or YAML file to read configuration from, - for STDIN").PlaceHolder("FILE").StringVar(&c.inputFile)
+	strAdd.Flag("json-config", "JSON or YAML file to read configuration from, - for STDIN").Hidden().StringVar(&c.inputFile)
+	strAdd.Flag("set", "Sets a value used when rendering the configuration file as a template").PlaceHolder("KEY=VALUE").StringsVar(&c.configValues)
+	strAdd.Flag("values", "JSON or YAML file holding values used when rendering the configuration file as a template").PlaceHolder("FILE").ExistingFileVar(&c.configValuesFile)
+	strAdd.Flag("validate", "Only validates the configuration against the official Schema and compares it to the live asset when it exists").UnNegatableBoolVar(&c.validateOnly)
+	strAdd.Flag("kv-compatible", "Allows creating a Stream named like a KV or Object Store bucket when it is configured the way those clients require").UnNegatableBoolVar(&c.kvCompatible)
 	strAdd.Flag("output", "Save configuration instead of creating").PlaceHolder("FILE").StringVar(&c.outFile)
 	addCreateFlags(strAdd, false)
 	strAdd.Flag("defaults", "Accept default values for all prompts").UnNegatableBoolVar(&c.acceptDefaults)
 
 	strLs := str.Command("ls", "List all known Streams").Alias("list").Alias("l").Action(c.lsAction)
-	strLs.Flag("subject", "Limit the list to streams with matching subjects").StringVar(&c.filterSubject)
+	strLs.Flag("subject", "Limit the list to streams that capture messages published to a subject").StringVar(&c.filterSubject)
+	strLs.Flag("select", "Limit the list to streams with matching metadata").PlaceHolder("KEY=VALUE").StringMapVar(&c.metaSelectors)
 	strLs.Flag("names", "Show just the stream names").Short('n').UnNegatableBoolVar(&c.listNames)
 	strLs.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+	addOutputTemplateFlag(strLs, &c.outTemplate)
 
 	strReport := str.Command("report", "Reports on Stream statistics").Action(c.reportAction)
 	strReport.Flag("subject", "Limit the report to streams with matching subjects").StringVar(&c.filterSubject)
 	strReport.Flag("cluster", "Limit report to streams within a specific cluster").StringVar(&c.reportLimitCluster)
+	strReport.Flag("select", "Limit the report to streams with matching metadata").PlaceHolder("KEY=VALUE").StringMapVar(&c.metaSelectors)
 	strReport.Flag("consumers", "Sort by number of Consumers").Short('o').UnNegatableBoolVar(&c.reportSortConsumers)
 	strReport.Flag("messages", "Sort by number of Messages").Short('m').UnNegatableBoolVar(&c.reportSortMsgs)
 	strReport.Flag("name", "Sort by Stream name").Short('n').UnNegatableBoolVar(&c.reportSortName)
@@ -266,6 +337,9 @@ func configureStreamCommand(app commandHost) {
 	strReport.Flag("raw", "Show un-formatted numbers").Short('r').UnNegatableBoolVar(&c.reportRaw)
 	strReport.Flag("dot", "Produce a GraphViz graph of replication topology").StringVar(&c.outFile)
 	strReport.Flag("leaders", "Show details about cluster leaders").Short('l').UnNegatableBoolVar(&c.reportLeaderDistrib)
+	addOutputTemplateFlag(strReport, &c.outTemplate)
+	strReport.Flag("csv", "Produce CSV output").UnNegatableBoolVar(&c.csv)
+	addReportWhereFlag(strReport, &c.reportWhere, streamReportWhereFields)
 
 	findHelp := `Expression format:
 
@@ -314,72 +388,167 @@ Finding streams with certain subjects configured:
 	strFind.Flag("expression", "Match streams using an expression language").StringVar(&c.fExpression)
 
 	strInfo := str.Command("info", "Stream information").Alias("nfo").Alias("i").Action(c.infoAction)
-	strInfo.Arg("stream", "Stream to retrieve information for").StringVar(&c.stream)
+	strInfo.Arg("stream", "Stream to retrieve information for").HintAction(completeStreamNames).StringVar(&c.stream)
 	strInfo.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+	addOutputTemplateFlag(strInfo, &c.outTemplate)
 	strInfo.Flag("state", "Shows only the stream state").UnNegatableBoolVar(&c.showStateOnly)
+	strInfo.Flag("placement", "Shows the cluster and tags of the servers hosting the stream, requires system account access").UnNegatableBoolVar(&c.showPlacement)
 	strInfo.Flag("no-select", "Do not select streams from a list").Default("false").UnNegatableBoolVar(&c.force)
 
 	strState := str.Command("state", "Stream state").Action(c.stateAction)
-	strState.Arg("stream", "Stream to retrieve state information for").StringVar(&c.stream)
+	strState.Arg("stream", "Stream to retrieve state information for").HintAction(completeStreamNames).StringVar(&c.stream)
 	strState.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
 	strState.Flag("no-select", "Do not select streams from a list").Default("false").UnNegatableBoolVar(&c.force)
 
 	strSubs := str.Command("subjects", "Query subjects held in a stream").Alias("subj").Action(c.subjectsAction)
-	strSubs.Arg("stream", "Stream name").StringVar(&c.stream)
+	strSubs.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	strSubs.Arg("filter", "Limit the subjects to those matching a filter").Default(">").StringVar(&c.filterSubject)
 	strSubs.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
 	strSubs.Flag("sort", "Adjusts the sorting order (name, messages)").Default("messages").EnumVar(&c.reportSort, "name", "subjects", "messages", "count")
 	strSubs.Flag("reverse", "Reverse sort servers").Short('R').UnNegatableBoolVar(&c.reportSortReverse)
 	strSubs.Flag("names", "SList only subject names").BoolVar(&c.listNames)
 
+	strTokens := str.Command("tokens", "Reports the cardinality of each token position in subjects held in a stream").Action(c.tokensAction)
+	strTokens.HelpLong(`Analyzes the subjects held in a stream and reports how many distinct
+values appear in every token position.
+
+This helps in designing filter subjects, partitioning subject transforms
+and judging if limits like --max-msgs-per-subject are viable.`)
+	strTokens.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
+	strTokens.Arg("filter", "Limit the subjects to those matching a filter").Default(">").StringVar(&c.filterSubject)
+	strTokens.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+
 	strEdit := str.Command("edit", "Edits an existing stream").Alias("update").Action(c.editAction)
-	strEdit.Arg("stream", "Stream to retrieve edit").StringVar(&c.stream)
-	strEdit.Flag("config", "JSON file to read configuration from").ExistingFileVar(&c.inputFile)
+	strEdit.Arg("stream", "Stream to retrieve edit").HintAction(completeStreamNames).StringVar(&c.stream)
+	strEdit.Flag("config", "JSON or YAML file to read configuration from, - for STDIN").PlaceHolder("FILE").StringVar(&c.inputFile)
+	strEdit.Flag("set", "Sets a value used when rendering the configuration file as a template").PlaceHolder("KEY=VALUE").StringsVar(&c.configValues)
+	strEdit.Flag("values", "JSON or YAML file holding values used when rendering the configuration file as a template").PlaceHolder("FILE").ExistingFileVar(&c.configValuesFile)
 	strEdit.Flag("force", "Force edit without prompting").Short('f').UnNegatableBoolVar(&c.force)
 	strEdit.Flag("interactive", "Edit the configuring using your editor").Short('i').BoolVar(&c.interactive)
 	strEdit.Flag("dry-run", "Only shows differences, do not edit the stream").UnNegatableBoolVar(&c.dryRun)
+	strEdit.Flag("replica-wait", "How long to wait for new replicas to become current after changing replicas or placement, 0 to not wait").Default("10m").PlaceHolder("DURATION").DurationVar(&c.replicaWait)
 	addCreateFlags(strEdit, true)
 
+	strDiff := str.Command("diff", "Compares the configuration of a Stream with a configuration file").Action(c.diffAction)
+	strDiff.HelpLong(`Compares the live configuration with a JSON or YAML file and shows the differences.
+
+Exits with code 1 when the configuration differs from the file, files can
+be produced using 'nats stream info --json' or 'nats stream add --output'.`)
+	strDiff.Arg("stream", "Stream name").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	strDiff.Arg("file", "JSON or YAML file holding the desired configuration").Required().ExistingFileVar(&c.inputFile)
+	strDiff.Flag("set", "Sets a value used when rendering the configuration file as a template").PlaceHolder("KEY=VALUE").StringsVar(&c.configValues)
+	strDiff.Flag("values", "JSON or YAML file holding values used when rendering the configuration file as a template").PlaceHolder("FILE").ExistingFileVar(&c.configValuesFile)
+
 	strRm := str.Command("rm", "Removes a Stream").Alias("delete").Alias("del").Action(c.rmAction)
-	strRm.Arg("stream", "Stream name").StringVar(&c.stream)
+	strRm.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	strRm.Flag("force", "Force removal without prompting").Short('f').UnNegatableBoolVar(&c.force)
 
 	strPurge := str.Command("purge", "Purge a Stream without deleting it").Action(c.purgeAction)
-	strPurge.Arg("stream", "Stream name").StringVar(&c.stream)
+	strPurge.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	strPurge.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
 	strPurge.Flag("force", "Force removal without prompting").Short('f').UnNegatableBoolVar(&c.force)
 	strPurge.Flag("subject", "Limits the purge to a specific subject").PlaceHolder("SUBJECT").StringVar(&c.purgeSubject)
 	strPurge.Flag("seq", "Purge up to but not including a specific message sequence").PlaceHolder("SEQUENCE").Uint64Var(&c.purgeSequence)
 	strPurge.Flag("keep", "Keeps a certain number of messages after the purge").PlaceHolder("MESSAGES").Uint64Var(&c.purgeKeep)
+	strPurge.Flag("older-than", "Purge messages stored longer ago than a duration like 7d").PlaceHolder("DURATION").DurationVar(&c.purgeOlderThan)
 
-	strCopy := str.Command("copy", "Creates a new Stream based on the configuration of another, does not copy data").Alias("cp").Action(c.cpAction)
+	strCopy := str.Command("copy", "Creates a new Stream based on the configuration of another, optionally sourcing its data").Alias("cp").Action(c.cpAction)
 	strCopy.Arg("source", "Source Stream to copy").Required().StringVar(&c.stream)
 	strCopy.Arg("destination", "New Stream to create").Required().StringVar(&c.destination)
+	strCopy.Flag("data", "Source the messages from the original Stream into the new Stream").UnNegatableBoolVar(&c.copyData)
 	addCreateFlags(strCopy, false)
 
 	strRmMsg := str.Command("rmm", "Securely removes an individual message from a Stream").Action(c.rmMsgAction)
-	strRmMsg.Arg("stream", "Stream name").StringVar(&c.stream)
+	strRmMsg.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	strRmMsg.Arg("id", "Message Sequence to remove").Int64Var(&c.msgID)
 	strRmMsg.Flag("force", "Force removal without prompting").Short('f').UnNegatableBoolVar(&c.force)
 
 	strView := str.Command("view", "View messages in a stream").Action(c.viewAction)
-	strView.Arg("stream", "Stream name").StringVar(&c.stream)
+	strView.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	strView.Arg("size", "Page size").Default("10").IntVar(&c.vwPageSize)
 	strView.Flag("id", "Start at a specific message Sequence").IntVar(&c.vwStartId)
 	strView.Flag("since", "Delivers messages received since a duration like 1d3h5m2s").DurationVar(&c.vwStartDelta)
 	strView.Flag("raw", "Show the raw data received").UnNegatableBoolVar(&c.vwRaw)
 	strView.Flag("translate", "Translate the message data by running it through the given command before output").StringVar(&c.vwTranslate)
+	strView.Flag("decode", fmt.Sprintf("Decodes the message data before output, can be repeated to decode in order (%s)", strings.Join(payloadDecoders, ", "))).PlaceHolder("DECODER").EnumsVar(&c.vwDecoders, payloadDecoders...)
 	strView.Flag("subject", "Filter the stream using a subject").StringVar(&c.vwSubject)
+	addMsgDisplayFlags(strView, &c.vwDisplay)
+
+	configureStreamTailCommand(str.Command("tail", "Follows new messages in one or more Streams using ephemeral ordered consumers"), c)
 
 	strGet := str.Command("get", "Retrieves a specific message from a Stream").Action(c.getAction)
-	strGet.Arg("stream", "Stream name").StringVar(&c.stream)
+	strGet.Arg("stream", "Stream name").HintAction(completeStreamNames).StringVar(&c.stream)
 	strGet.Arg("id", "Message Sequence to retrieve").Int64Var(&c.msgID)
 	strGet.Flag("last-for", "Retrieves the message for a specific subject").Short('S').PlaceHolder("SUBJECT").StringVar(&c.filterSubject)
 	strGet.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
 	strGet.Flag("translate", "Translate the message data by running it through the given command before output").StringVar(&c.vwTranslate)
+	strGet.Flag("decode", fmt.Sprintf("Decodes the message data before output, can be repeated to decode in order (%s)", strings.Join(payloadDecoders, ", "))).PlaceHolder("DECODER").EnumsVar(&c.vwDecoders, payloadDecoders...)
+	strGet.Flag("direct", "Uses the direct get API when the Stream allows it").Default("true").BoolVar(&c.getDirect)
+
+	strDump := str.Command("dump", "Writes Stream messages to files for offline analysis and archiving").Action(c.dumpAction)
+	strDump.HelpLong(`Writes every message, including subject, headers, time and sequence, to a
+directory holding one JSON file per message or, with --jsonl, a single
+STREAM.jsonl archive. Use - as target with --jsonl to write to STDOUT.
+
+Dumps can be published to a Stream again using 'nats stream load'.`)
+	strDump.Arg("stream", "Stream to dump").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	strDump.Arg("target", "Directory to write the messages to").Required().StringVar(&c.dumpTarget)
+	strDump.Flag("subject", "Only dump messages matching a subject").PlaceHolder("SUBJECT").StringVar(&c.filterSubject)
+	strDump.Flag("since", "Only dump messages received since a duration like 1d3h5m2s").PlaceHolder("DURATION").DurationVar(&c.dumpSince)
+	strDump.Flag("jsonl", "Write a single JSON Lines archive rather than a file per message").UnNegatableBoolVar(&c.dumpJSONL)
+
+	strLoad := str.Command("load", "Publishes messages previously written by 'nats stream dump' into a Stream").Action(c.loadAction)
+	strLoad.Arg("stream", "Stream to publish the messages into").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	strLoad.Arg("source", "Directory or JSONL archive holding the messages").Required().ExistingFileOrDirVar(&c.dumpTarget)
+	strLoad.Flag("force", "Load without prompting").Short('f').UnNegatableBoolVar(&c.force)
+
+	configureStreamExportCommand(str, c)
+	configureStreamGrepCommand(str, c)
+	configureStreamRepublishCommand(str, c)
+
+	strPublishers := str.Command("publishers", "Estimates which connections are publishing into a Stream").Action(c.publishersAction)
+	strPublishers.HelpLong(`Samples connection statistics and the Stream state for a period and reports
+which connections published messages during that time.
+
+Connections are found using server connection reports and so requires system
+account access, use --account to limit the report to the account holding the
+Stream. As connections may publish to other subjects too these figures are an
+estimate, the Stream share of a connection is the number of messages it
+published divided by the number of messages the Stream stored while sampling.
+
+When publishers set a header identifying themselves --header groups the
+messages stored during the sample period by that header for exact figures.`)
+	strPublishers.Arg("stream", "Stream to report on").HintAction(completeStreamNames).StringVar(&c.stream)
+	strPublishers.Flag("duration", "How long to sample for").Default("10s").DurationVar(&c.publishersDuration)
+	strPublishers.Flag("account", "Only consider connections in this account").StringVar(&c.publishersAccount)
+	strPublishers.Flag("header", "Group stored messages by the value of this header").PlaceHolder("HEADER").StringVar(&c.publishersHeader)
+	strPublishers.Flag("top", "Limit the report to the top connections").Default("10").IntVar(&c.publishersTop)
+	strPublishers.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+
+	strMigrate := str.Command("migrate", "Copies a Stream from the selected context to another saved context").Action(c.migrateAction)
+	strMigrate.HelpLong(`Copies a Stream and its messages into the account described by another
+saved context, typically in a different cluster.
+
+The snapshot method performs a backup and restore and preserves sequences and
+Consumers exactly. The republish method creates the Stream and republishes
+every message, adding Nats-Migrated-Stream, Nats-Migrated-Sequence and
+Nats-Migrated-Time headers holding the original details. Nats-Msg-Id headers
+are moved to Nats-Migrated-Msg-Id so messages are not discarded as
+duplicates. Sequences are kept
+by filling gaps left by deleted messages with placeholders that are deleted
+again. Limits are only applied once all messages are copied and sources are
+not added as they would store the copied messages again.
+
+Message counts and last sequences in the source and destination are compared
+once done.`)
+	strMigrate.Arg("stream", "Stream to migrate").HintAction(completeStreamNames).StringVar(&c.stream)
+	strMigrate.Flag("to-context", "The saved context to migrate the Stream to").Required().StringVar(&c.migrateContext)
+	strMigrate.Flag("method", "The migration method to use (snapshot, republish)").Default("snapshot").EnumVar(&c.migrateMethod, "snapshot", "republish")
+	strMigrate.Flag("progress", "Enables or disables progress reporting using a progress bar").Default("true").BoolVar(&c.showProgress)
+	strMigrate.Flag("force", "Migrate without prompting").Short('f').UnNegatableBoolVar(&c.force)
 
 	strBackup := str.Command("backup", "Creates a backup of a Stream over the NATS network").Alias("snapshot").Action(c.backupAction)
-	strBackup.Arg("stream", "Stream to backup").Required().StringVar(&c.stream)
+	strBackup.Arg("stream", "Stream to backup").HintAction(completeStreamNames).Required().StringVar(&c.stream)
 	strBackup.Arg("target", "Directory to create the backup in").Required().StringVar(&c.backupDirectory)
 	strBackup.Flag("progress", "Enables or disables progress reporting using a progress bar").Default("true").BoolVar(&c.showProgress)
 	strBackup.Flag("check", "Checks the Stream for health prior to backup").UnNegatableBoolVar(&c.healthCheck)
@@ -395,21 +564,48 @@ Finding streams with certain subjects configured:
 	strRestore.Flag("replicas", "Override how many replicas of the data to create").Int64Var(&c.replicas)
 
 	strSeal := str.Command("seal", "Seals a stream preventing further updates").Action(c.sealAction)
-	strSeal.Arg("stream", "The name of the Stream to seal").Required().StringVar(&c.stream)
+	strSeal.Arg("stream", "The name of the Stream to seal").HintAction(completeStreamNames).Required().StringVar(&c.stream)
 	strSeal.Flag("force", "Force sealing without prompting").Short('f').UnNegatableBoolVar(&c.force)
 
 	gapDetect := str.Command("gaps", "Detect gaps in the Stream content that would be reported as deleted messages").Action(c.detectGaps)
-	gapDetect.Arg("stream", "Stream to act on").StringVar(&c.stream)
+	gapDetect.Arg("stream", "Stream to act on").HintAction(completeStreamNames).StringVar(&c.stream)
 	gapDetect.Flag("force", "Act without prompting").Short('f').UnNegatableBoolVar(&c.force)
 	gapDetect.Flag("progress", "Enable progress bar").Default("true").BoolVar(&c.showProgress)
 	gapDetect.Flag("json", "Show detected gaps in JSON format").UnNegatableBoolVar(&c.json)
 
 	graph := str.Command("graph", "View a graph of Stream activity").Action(c.graphAction)
-	graph.Arg("stream", "The name of the Stream to graph").StringVar(&c.stream)
+	graph.Arg("stream", "The name of the Stream to graph").HintAction(completeStreamNames).StringVar(&c.stream)
+
+	watch := str.Command("watch", "Watch Stream growth, ingest rates and Consumer progress").Action(c.watchAction)
+	watch.Arg("stream", "The name of the Stream to watch").HintAction(completeStreamNames).StringVar(&c.stream)
+	watch.Flag("interval", "How often to refresh the information").Default("2s").DurationVar(&c.watchInterval)
+	watch.Flag("pending-threshold", "Notify when Consumers have more than this many unprocessed messages").PlaceHolder("MESSAGES").Uint64Var(&c.watchPending)
+	watch.Flag("lag-threshold", "Notify when the mirror and source lag exceeds this many messages").PlaceHolder("MESSAGES").Uint64Var(&c.watchLag)
+	addNotifyFlags(watch, &c.watchNotify)
+
+	strSeq := str.Command("seq", "Translates between Stream sequences and the time messages were stored").Action(c.seqAction)
+	strSeq.HelpLong(`Given a timestamp finds the first message stored at or after that time, given a
+sequence shows when the message was stored.
+
+Timestamps can be absolute like "2025-01-02 15:04:05" or RFC3339, or durations
+like 2h or -2h meaning that long ago. The result can be used with --deliver
+when adding Consumers or --seq when purging Streams.`)
+	strSeq.Arg("stream", "The name of the Stream to query").HintAction(completeStreamNames).StringVar(&c.stream)
+	strSeq.Arg("value", "The sequence or timestamp to translate").Required().StringVar(&c.seqQuery)
+	strSeq.Flag("json", "Produce JSON output").Short('j').UnNegatableBoolVar(&c.json)
+
+	strCheck := str.Command("check", "Checks Streams for problems")
+	orphans := strCheck.Command("orphan-data", "Finds data in a JetStream store directory not known to the server").Action(c.orphanDataAction)
+	orphans.HelpLong(`Compares the Streams, Consumers and RAFT groups a server reports hosting with the
+directories found in its JetStream store directory, reporting any left behind by
+failed deletes or moves. Must be run with access to the server file system and
+the system account.`)
+	orphans.Arg("server", "The name of the server owning the store directory").Required().StringVar(&c.orphanServer)
+	orphans.Arg("directory", "The JetStream store directory").Required().ExistingDirVar(&c.orphanStoreDir)
 
 	strCluster := str.Command("cluster", "Manages a clustered Stream").Alias("c")
 	strClusterDown := strCluster.Command("step-down", "Force a new leader election by standing down the current leader").Alias("stepdown").Alias("sd").Alias("elect").Alias("down").Alias("d").Action(c.leaderStandDown)
-	strClusterDown.Arg("stream", "Stream to act on").StringVar(&c.stream)
+	strClusterDown.Arg("stream", "Stream to act on").HintAction(completeStreamNames).StringVar(&c.stream)
 	strClusterDown.Flag("preferred", "Prefer placing the leader on a specific host").StringVar(&c.placementPreferred)
 	strClusterDown.Flag("force", "Force leader step down ignoring current leader").Short('f').UnNegatableBoolVar(&c.force)
 
@@ -429,7 +625,7 @@ Finding streams with certain subjects configured:
 	strClusterBalance.Flag("expression", "Balance matching streams using an expression language").StringVar(&c.fExpression)
 
 	strClusterRemovePeer := strCluster.Command("peer-remove", "Removes a peer from the Stream cluster").Alias("pr").Action(c.removePeer)
-	strClusterRemovePeer.Arg("stream", "The stream to act on").StringVar(&c.stream)
+	strClusterRemovePeer.Arg("stream", "The stream to act on").HintAction(completeStreamNames).StringVar(&c.stream)
 	strClusterRemovePeer.Arg("peer", "The name of the peer to remove").StringVar(&c.peerName)
 	strClusterRemovePeer.Flag("force", "Force sealing without prompting").Short('f').UnNegatableBoolVar(&c.force)
 }
@@ -569,6 +765,160 @@ func (c *streamCmd) graphAction(_ *fisk.ParseContext) error {
 	}
 }
 
+func (c *streamCmd) watchAction(_ *fisk.ParseContext) error {
+	if c.watchInterval < time.Second {
+		return fmt.Errorf("interval should be at least 1 second")
+	}
+
+	c.connectAndAskStream()
+
+	stream, err := c.loadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+
+	var last *api.StreamInfo
+	var lastTs time.Time
+
+	show := func() error {
+		nfo, err := stream.LatestInformation()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+
+		var consumers, pending, ackPending, redelivered uint64
+		var behindName string
+		var behind uint64
+
+		_, err = stream.EachConsumer(func(consumer *jsm.Consumer) {
+			state, err := consumer.LatestState()
+			if err != nil {
+				return
+			}
+
+			consumers++
+			pending += state.NumPending
+			ackPending += uint64(state.NumAckPending)
+			redelivered += uint64(state.NumRedelivered)
+
+			if state.NumPending > behind || behindName == "" {
+				behind = state.NumPending
+				behindName = state.Name
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		var sourceLag uint64
+		if nfo.Mirror != nil {
+			sourceLag += nfo.Mirror.Lag
+		}
+		for _, source := range nfo.Sources {
+			sourceLag += source.Lag
+		}
+
+		c.watchAlerts(nfo, pending, sourceLag)
+
+		cols := newColumns("Stream %s at %s", nfo.Config.Name, f(now))
+		cols.AddSectionTitle("State")
+		cols.AddRow("Messages", nfo.State.Msgs)
+		cols.AddRow("Bytes", humanize.IBytes(nfo.State.Bytes))
+		cols.AddRow("First Sequence", nfo.State.FirstSeq)
+		cols.AddRowIf("First Message Age", now.Sub(nfo.State.FirstTime), !nfo.State.FirstTime.IsZero() && nfo.State.Msgs > 0)
+		cols.AddRow("Last Sequence", nfo.State.LastSeq)
+		cols.AddRowIf("Last Message Age", now.Sub(nfo.State.LastTime), !nfo.State.LastTime.IsZero() && nfo.State.LastSeq > 0)
+
+		cols.AddSectionTitle("Rates")
+		if last == nil {
+			cols.AddRow("Ingest Rate", "waiting for data")
+		} else {
+			since := now.Sub(lastTs)
+			cols.AddRowf("Ingest Rate", "%s msg/s", f(calculateRate(float64(nfo.State.LastSeq), float64(last.State.LastSeq), since)))
+			cols.AddRowf("Removal Rate", "%s msg/s", f(calculateRate(float64(nfo.State.FirstSeq), float64(last.State.FirstSeq), since)))
+
+			growth := (float64(nfo.State.Bytes) - float64(last.State.Bytes)) / since.Seconds()
+			if growth < 0 {
+				cols.AddRowf("Growth", "-%s/s", humanize.IBytes(uint64(-growth)))
+			} else {
+				cols.AddRowf("Growth", "%s/s", humanize.IBytes(uint64(growth)))
+			}
+		}
+		cols.AddRowIf("Mirror and Source Lag", sourceLag, nfo.Mirror != nil || len(nfo.Sources) > 0)
+
+		cols.AddSectionTitle("Consumers")
+		cols.AddRow("Consumers", consumers)
+		if consumers > 0 {
+			cols.AddRow("Unprocessed Messages", pending)
+			cols.AddRow("Ack Pending", ackPending)
+			cols.AddRow("Redelivered", redelivered)
+			cols.AddRowf("Furthest Behind", "%s with %s unprocessed", behindName, f(behind))
+		}
+
+		out, err := cols.Render()
+		if err != nil {
+			return err
+		}
+
+		if iu.IsTerminal() {
+			iu.ClearScreen()
+		}
+		fmt.Println(out)
+
+		last = nfo
+		lastTs = now
+
+		return nil
+	}
+
+	err = show()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(c.watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err = show()
+			if err != nil {
+				log.Printf("Could not load Stream information: %v", err)
+			}
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// watchAlerts notifies about leader changes and threshold breaches seen while watching a stream
+func (c *streamCmd) watchAlerts(nfo *api.StreamInfo, pending uint64, lag uint64) {
+	n := &c.watchNotify
+	if !n.enabled() {
+		return
+	}
+
+	name := nfo.Config.Name
+
+	if nfo.Cluster != nil && nfo.Cluster.Leader != "" && n.changed("leader", nfo.Cluster.Leader) {
+		n.notify(fmt.Sprintf("Stream %s", name), "Leader changed to %s", nfo.Cluster.Leader)
+	}
+
+	if c.watchPending > 0 && n.breached("pending", pending > c.watchPending) {
+		n.notify(fmt.Sprintf("Stream %s", name), "%s unprocessed messages exceeds %s", f(pending), f(c.watchPending))
+	}
+
+	if c.watchLag > 0 && n.breached("lag", lag > c.watchLag) {
+		n.notify(fmt.Sprintf("Stream %s", name), "Mirror and source lag of %s exceeds %s", f(lag), f(c.watchLag))
+	}
+}
+
 func (c *streamCmd) detectGaps(_ *fisk.ParseContext) error {
 	c.connectAndAskStream()
 
@@ -759,6 +1109,82 @@ func (c *streamCmd) subjectsAction(_ *fisk.ParseContext) (err error) {
 	return nil
 }
 
+func (c *streamCmd) tokensAction(_ *fisk.ParseContext) error {
+	asked := c.connectAndAskStream()
+
+	subs, err := c.mgr.StreamContainedSubjects(c.stream, c.filterSubject)
+	if err != nil {
+		return err
+	}
+
+	stats := subjectTokenStats(subs)
+
+	if c.json {
+		return iu.PrintJSON(stats)
+	}
+
+	if asked {
+		fmt.Println()
+	}
+
+	if len(subs) == 0 {
+		fmt.Printf("No subjects found matching %s\n", c.filterSubject)
+		return nil
+	}
+
+	var msgs uint64
+	for _, v := range subs {
+		msgs += v
+	}
+
+	table := iu.NewTableWriter(opts(), fmt.Sprintf("Subject token cardinality for %s subjects in stream %s", f(len(subs)), c.stream))
+	table.AddHeaders("Token", "Distinct Values", "Subjects", "Messages", "Most Common", "Most Common Messages")
+	for _, stat := range stats {
+		var pct float64
+		if stat.Messages > 0 {
+			pct = float64(stat.TopMessages) / float64(stat.Messages) * 100
+		}
+
+		table.AddRow(stat.Position, f(stat.Distinct), f(stat.Subjects), f(stat.Messages), stat.Top, fmt.Sprintf("%s (%s%%)", f(stat.TopMessages), f(pct)))
+	}
+	fmt.Println(table.Render())
+
+	fmt.Printf("Average messages per subject: %s\n", f(float64(msgs)/float64(len(subs))))
+
+	return nil
+}
+
+// subjectTokenStats calculates the cardinality of every token position in subjects, subs maps subjects to message counts
+func subjectTokenStats(subs map[string]uint64) []streamTokenStat {
+	var stats []streamTokenStat
+	var values []map[string]uint64
+
+	for subj, cnt := range subs {
+		for i, token := range strings.Split(subj, ".") {
+			if i == len(values) {
+				values = append(values, map[string]uint64{})
+				stats = append(stats, streamTokenStat{Position: i + 1})
+			}
+
+			values[i][token] += cnt
+			stats[i].Subjects++
+			stats[i].Messages += cnt
+		}
+	}
+
+	for i, vals := range values {
+		stats[i].Distinct = len(vals)
+		for token, cnt := range vals {
+			if cnt > stats[i].TopMessages || (cnt == stats[i].TopMessages && token < stats[i].Top) {
+				stats[i].Top = token
+				stats[i].TopMessages = cnt
+			}
+		}
+	}
+
+	return stats
+}
+
 func (c *streamCmd) parseLimitStrings(_ *fisk.ParseContext) (err error) {
 	if c.maxBytesLimitString != "" {
 		c.maxBytesLimit, err = parseStringAsBytes(c.maxBytesLimitString)
@@ -994,7 +1420,7 @@ func (c *streamCmd) leaderStandDown(_ *fisk.ParseContext) error {
 	}
 
 	fmt.Println()
-	return c.showStream(stream)
+	return c.showStream(os.Stdout, stream)
 }
 
 func (c *streamCmd) removePeer(_ *fisk.ParseContext) error {
@@ -1054,6 +1480,11 @@ func (c *streamCmd) viewAction(_ *fisk.ParseContext) error {
 		c.vwPageSize = 25
 	}
 
+	err := c.vwDisplay.validate()
+	if err != nil {
+		return err
+	}
+
 	c.connectAndAskStream()
 
 	str, err := c.loadStream(c.stream)
@@ -1111,7 +1542,7 @@ func (c *streamCmd) viewAction(_ *fisk.ParseContext) error {
 		case msg == nil:
 			shouldTerminate = true
 		case c.vwRaw:
-			fmt.Println(string(msg.Data))
+			c.vwDisplay.printRaw(msg.Header, func() { fmt.Println(string(msg.Data)) })
 		default:
 			meta, err := jsm.ParseJSMsgMetadata(msg)
 			if err == nil {
@@ -1120,24 +1551,10 @@ func (c *streamCmd) viewAction(_ *fisk.ParseContext) error {
 				fmt.Printf("Subject: %s Reply: %s\n", msg.Subject, msg.Reply)
 			}
 
-			if len(msg.Header) > 0 {
-				fmt.Println()
-				for k, vs := range msg.Header {
-					for _, v := range vs {
-						if k == "Nats-Stream-Source" {
-							v = strings.ReplaceAll(v, "\f", "\u240A")
-						}
-
-						if k == "Nats-Subject" || k == "Nats-Stream" || k == "Nats-Sequence" || k == "Nats-Time-Stamp" || k == "Nats-Num-Pending" || k == "Nats-Last-Sequence" || k == "Nats-UpTo-Sequnce" {
-							continue
-						}
-
-						fmt.Printf("  %s: %s\n", k, v)
-					}
-				}
-			}
-
-			outPutMSGBody(msg.Data, c.vwTranslate, msg.Subject, meta.Stream())
+			fmt.Println()
+			c.vwDisplay.printMsg(msg.Header, func() {
+				outPutMSGBody(decodedMsg(msg, c.vwDecoders).Data, c.vwTranslate, msg.Subject, meta.Stream())
+			}, "Nats-Subject", "Nats-Stream", "Nats-Sequence", "Nats-Time-Stamp", "Nats-Num-Pending", "Nats-Last-Sequence", "Nats-UpTo-Sequence")
 		}
 
 		if shouldTerminate {
@@ -1170,10 +1587,10 @@ func (c *streamCmd) sealAction(_ *fisk.ParseContext) error {
 	stream, err := c.loadStream(c.stream)
 	fisk.FatalIfError(err, "could not seal Stream")
 
-	stream.Seal()
+	err = stream.Seal()
 	fisk.FatalIfError(err, "could not seal Stream")
 
-	return c.showStream(stream)
+	return c.showStream(os.Stdout, stream)
 }
 
 func (c *streamCmd) restoreAction(_ *fisk.ParseContext) error {
@@ -1225,7 +1642,7 @@ func (c *streamCmd) restoreAction(_ *fisk.ParseContext) error {
 	}
 
 	if c.inputFile != "" {
-		cfg, err := c.loadConfigFile(c.inputFile)
+		cfg, err := c.loadConfigFile(c.inputFile, false)
 		if err != nil {
 			return err
 		}
@@ -1271,7 +1688,7 @@ func (c *streamCmd) restoreAction(_ *fisk.ParseContext) error {
 
 	stream, err := mgr.LoadStream(bm.Config.Name)
 	fisk.FatalIfError(err, "could not request Stream info")
-	err = c.showStream(stream)
+	err = c.showStream(os.Stdout, stream)
 	fisk.FatalIfError(err, "could not show stream")
 
 	return nil
@@ -1416,10 +1833,18 @@ func (c *streamCmd) backupAction(_ *fisk.ParseContext) error {
 }
 
 func (c *streamCmd) reportAction(_ *fisk.ParseContext) error {
+	where, err := newReportFilter(c.reportWhere)
+	if err != nil {
+		return err
+	}
+
 	_, mgr, err := prepareHelper("", natsOpts()...)
 	fisk.FatalIfError(err, "setup failed")
 
-	if !c.json {
+	out := newPager()
+	defer out.Close()
+
+	if !c.json && !c.csv && c.outTemplate == "" {
 		fmt.Print("Obtaining Stream stats\n\n")
 	}
 
@@ -1439,6 +1864,16 @@ func (c *streamCmd) reportAction(_ *fisk.ParseContext) error {
 		info, err := stream.LatestInformation()
 		fisk.FatalIfError(err, "could not get stream info for %s", stream.Name())
 
+		if !metadataMatches(info.Config.Metadata, c.metaSelectors) {
+			return
+		}
+
+		matched, err := where.match(streamReportRow(info))
+		fisk.FatalIfError(err, "could not filter stream %s", stream.Name())
+		if !matched {
+			return
+		}
+
 		if info.Cluster != nil {
 			if c.reportLimitCluster != "" && info.Cluster.Name != c.reportLimitCluster {
 				return
@@ -1523,8 +1958,12 @@ func (c *streamCmd) reportAction(_ *fisk.ParseContext) error {
 	}
 
 	if len(stats) == 0 {
-		if !c.json {
-			fmt.Println("No Streams defined")
+		if c.csv {
+			return c.renderStreamsCSV(out, stats)
+		}
+
+		if !c.json && c.outTemplate == "" {
+			fmt.Fprintln(out, "No Streams defined")
 		}
 		return nil
 	}
@@ -1541,10 +1980,18 @@ func (c *streamCmd) reportAction(_ *fisk.ParseContext) error {
 		sort.Slice(stats, func(i, j int) bool { return stats[i].Bytes < stats[j].Bytes })
 	}
 
-	c.renderStreams(stats)
+	if c.outTemplate != "" {
+		return renderOutputTemplateTo(out, c.outTemplate, stats)
+	}
+
+	if c.csv {
+		return c.renderStreamsCSV(out, stats)
+	}
+
+	c.renderStreams(out, stats)
 
 	if showReplication {
-		c.renderReplication(stats)
+		c.renderReplication(out, stats)
 
 		if c.outFile != "" {
 			os.WriteFile(c.outFile, []byte(dg.String()), 0600)
@@ -1552,15 +1999,15 @@ func (c *streamCmd) reportAction(_ *fisk.ParseContext) error {
 	}
 
 	if c.reportLeaderDistrib && len(leaders) > 0 {
-		renderRaftLeaders(leaders, "Streams")
+		renderRaftLeaders(out, leaders, "Streams")
 	}
 
-	c.renderMissing(os.Stdout, missing)
+	c.renderMissing(out, missing)
 
 	return nil
 }
 
-func (c *streamCmd) renderReplication(stats []streamStat) {
+func (c *streamCmd) renderReplication(w io.Writer, stats []streamStat) {
 	table := iu.NewTableWriter(opts(), "Replication Report")
 	table.AddHeaders("Stream", "Kind", "API Prefix", "Source Stream", "Filters and Transforms", "Active", "Lag", "Error")
 
@@ -1616,10 +2063,31 @@ func (c *streamCmd) renderReplication(stats []streamStat) {
 
 		}
 	}
-	fmt.Println(table.Render())
+	fmt.Fprintln(w, table.Render())
+}
+
+func (c *streamCmd) renderStreamsCSV(w io.Writer, stats []streamStat) error {
+	var rows [][]any
+
+	for _, s := range stats {
+		var cluster, tags, leader string
+		replicas := 1
+		if s.Placement != nil {
+			cluster = s.Placement.Cluster
+			tags = strings.Join(s.Placement.Tags, " ")
+		}
+		if s.Cluster != nil {
+			leader = s.Cluster.Leader
+			replicas = len(s.Cluster.Replicas) + 1
+		}
+
+		rows = append(rows, []any{s.Name, s.Storage, cluster, tags, s.Consumers, s.Msgs, s.Bytes, s.LostMsgs, s.LostBytes, s.Deleted, replicas, leader})
+	}
+
+	return writeCSV(w, []string{"Stream", "Storage", "Placement Cluster", "Placement Tags", "Consumers", "Messages", "Bytes", "Lost Messages", "Lost Bytes", "Deleted", "Replicas", "Leader"}, rows)
 }
 
-func (c *streamCmd) renderStreams(stats []streamStat) {
+func (c *streamCmd) renderStreams(w io.Writer, stats []streamStat) {
 	table := iu.NewTableWriter(opts(), "Stream Report")
 	table.AddHeaders("Stream", "Storage", "Placement", "Consumers", "Messages", "Bytes", "Lost", "Deleted", "Replicas")
 
@@ -1648,11 +2116,11 @@ func (c *streamCmd) renderStreams(stats []streamStat) {
 		}
 	}
 
-	fmt.Println(table.Render())
+	fmt.Fprintln(w, table.Render())
 }
 
-func (c *streamCmd) loadConfigFile(file string) (*api.StreamConfig, error) {
-	f, err := os.ReadFile(file)
+func (c *streamCmd) loadConfigFile(file string, expandEnv bool) (*api.StreamConfig, error) {
+	f, err := readConfigFile(file, c.configValuesFile, c.configValues, expandEnv)
 	if err != nil {
 		return nil, err
 	}
@@ -1718,7 +2186,7 @@ func (c *streamCmd) copyAndEditStream(cfg api.StreamConfig, pc *fisk.ParseContex
 	var err error
 
 	if c.inputFile != "" {
-		cfg, err := c.loadConfigFile(c.inputFile)
+		cfg, err := c.loadConfigFile(c.inputFile, true)
 		if err != nil {
 			return api.StreamConfig{}, err
 		}
@@ -1815,7 +2283,7 @@ func (c *streamCmd) copyAndEditStream(cfg api.StreamConfig, pc *fisk.ParseContex
 		cfg.Placement = nil
 	}
 
-	if len(c.sources) > 0 || c.mirror != "" {
+	if len(c.sources) > 0 || c.mirror != "" || len(c.sourceTransforms) > 0 {
 		return cfg, fmt.Errorf("cannot edit mirrors, or sources using the CLI, use --config instead")
 	}
 
@@ -1961,6 +2429,11 @@ func (c *streamCmd) editAction(pc *fisk.ParseContext) error {
 		fisk.FatalIfError(err, "could not create new configuration for Stream %s", c.stream)
 	}
 
+	err = checkStreamLimits(cfg)
+	if err != nil {
+		return err
+	}
+
 	// sorts strings to subject lists that only differ in ordering is considered equal
 	sorter := cmp.Transformer("Sort", func(in []string) []string {
 		out := append([]string(nil), in...)
@@ -1979,9 +2452,16 @@ func (c *streamCmd) editAction(pc *fisk.ParseContext) error {
 
 	fmt.Printf("Differences (-old +new):\n%s", diff)
 	if c.dryRun {
-		os.Exit(1)
+		return ErrDifferencesFound
+	}
+
+	err = c.checkRepublishLoop(cfg)
+	if err != nil {
+		return err
 	}
 
+	c.warnStreamFeatures(cfg)
+
 	if !c.force {
 		ok, err := askConfirmation(fmt.Sprintf("Really edit Stream %s", c.stream), false)
 		fisk.FatalIfError(err, "could not obtain confirmation")
@@ -1991,6 +2471,14 @@ func (c *streamCmd) editAction(pc *fisk.ParseContext) error {
 		}
 	}
 
+	err = validateRequest(cfg)
+	if err != nil {
+		return err
+	}
+
+	nfo, err := sourceStream.Information()
+	clustered := err == nil && nfo.Cluster != nil
+
 	err = sourceStream.UpdateConfiguration(cfg)
 	fisk.FatalIfError(err, "could not edit Stream %s", c.stream)
 
@@ -1998,7 +2486,66 @@ func (c *streamCmd) editAction(pc *fisk.ParseContext) error {
 		fmt.Printf("Stream %s was updated\n\n", c.stream)
 	}
 
-	return c.showStream(sourceStream)
+	if clustered && c.replicaWait > 0 && streamPlacementChanged(input, cfg) {
+		cluster := ""
+		if cfg.Placement != nil {
+			cluster = cfg.Placement.Cluster
+		}
+
+		err = waitForReplicas(fmt.Sprintf("Stream %s", c.stream), cfg.Replicas, cluster, c.replicaWait, func() (*api.ClusterInfo, error) {
+			nfo, err := sourceStream.LatestInformation()
+			if err != nil {
+				return nil, err
+			}
+			return nfo.Cluster, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if !c.json {
+			fmt.Println()
+		}
+	}
+
+	return c.showStream(os.Stdout, sourceStream)
+}
+
+// streamPlacementChanged determines if an update changes the replicas or placement causing peers to be added or moved
+func streamPlacementChanged(live api.StreamConfig, desired api.StreamConfig) bool {
+	if live.Replicas != desired.Replicas {
+		return true
+	}
+
+	return !cmp.Equal(live.Placement, desired.Placement)
+}
+
+func (c *streamCmd) diffAction(_ *fisk.ParseContext) error {
+	c.connectAndAskStream()
+
+	stream, err := c.loadStream(c.stream)
+	if err != nil {
+		return fmt.Errorf("could not load Stream %s: %w", c.stream, err)
+	}
+
+	desired, err := c.loadConfigFile(c.inputFile, false)
+	if err != nil {
+		return fmt.Errorf("could not load configuration file %s: %w", c.inputFile, err)
+	}
+
+	live := stream.Configuration()
+	live.Metadata = iu.RemoveReservedMetadata(live.Metadata)
+	desired.Metadata = iu.RemoveReservedMetadata(desired.Metadata)
+
+	diff := configDiff(live, desired)
+	if diff == "" {
+		fmt.Printf("Stream %s matches %s\n", c.stream, c.inputFile)
+		return nil
+	}
+
+	fmt.Printf("Differences (-live +%s):\n%s", c.inputFile, colorDiff(diff))
+
+	return ErrDifferencesFound
 }
 
 func (c *streamCmd) cpAction(pc *fisk.ParseContext) error {
@@ -2028,14 +2575,27 @@ func (c *streamCmd) cpAction(pc *fisk.ParseContext) error {
 
 	cfg.Name = c.destination
 
+	if c.copyData {
+		// the original stream keeps receiving the subjects unless new ones were given
+		if len(c.subjects) == 0 {
+			cfg.Subjects = nil
+		}
+		cfg.Mirror = nil
+		cfg.Sources = []*api.StreamSource{{Name: c.stream}}
+	}
+
 	newStream, err := c.mgr.NewStreamFromDefault(cfg.Name, cfg)
 	fisk.FatalIfError(err, "could not create Stream")
 
 	if !c.json {
-		fmt.Printf("Stream %s was created\n\n", c.stream)
+		if c.copyData {
+			fmt.Printf("Stream %s was created sourcing data from %s\n\n", c.destination, c.stream)
+		} else {
+			fmt.Printf("Stream %s was created\n\n", c.destination)
+		}
 	}
 
-	c.showStream(newStream)
+	c.showStream(os.Stdout, newStream)
 
 	return nil
 }
@@ -2170,6 +2730,18 @@ func (c *streamCmd) renderSource(s *api.StreamSource) string {
 		parts = append(parts, fmt.Sprintf("Start Time: %v", s.OptStartTime))
 	}
 
+	if s.FilterSubject != "" {
+		parts = append(parts, fmt.Sprintf("Filter: %s", s.FilterSubject))
+	}
+
+	for _, t := range s.SubjectTransforms {
+		if t.Destination == "" {
+			parts = append(parts, fmt.Sprintf("Filter: %s", t.Source))
+		} else {
+			parts = append(parts, fmt.Sprintf("Transform: %s to %s", t.Source, t.Destination))
+		}
+	}
+
 	if s.External != nil {
 		if s.External.ApiPrefix != "" {
 			parts = append(parts, fmt.Sprintf("API Prefix: %s", s.External.ApiPrefix))
@@ -2183,20 +2755,26 @@ func (c *streamCmd) renderSource(s *api.StreamSource) string {
 	return f(parts)
 }
 
-func (c *streamCmd) showStream(stream *jsm.Stream) error {
+func (c *streamCmd) showStream(w io.Writer, stream *jsm.Stream) error {
 	info, err := stream.LatestInformation()
 	if err != nil {
 		return err
 	}
 
-	c.showStreamInfo(info)
+	c.showStreamInfo(w, info)
 
 	return nil
 }
 
-func (c *streamCmd) showStreamInfo(info *api.StreamInfo) {
+func (c *streamCmd) showStreamInfo(w io.Writer, info *api.StreamInfo) {
+	if c.outTemplate != "" {
+		err := renderOutputTemplateTo(w, c.outTemplate, info)
+		fisk.FatalIfError(err, "could not display info")
+		return
+	}
+
 	if c.json {
-		err := iu.PrintJSON(info)
+		err := iu.FprintJSON(w, info)
 		fisk.FatalIfError(err, "could not display info")
 		return
 	}
@@ -2370,7 +2948,7 @@ func (c *streamCmd) showStreamInfo(info *api.StreamInfo) {
 		}
 	}
 
-	cols.Frender(os.Stdout)
+	cols.Frender(w)
 }
 
 func (c *streamCmd) stateAction(pc *fisk.ParseContext) error {
@@ -2381,12 +2959,23 @@ func (c *streamCmd) stateAction(pc *fisk.ParseContext) error {
 func (c *streamCmd) infoAction(_ *fisk.ParseContext) error {
 	c.connectAndAskStream()
 
+	out := newPager()
+	defer out.Close()
+
 	stream, err := c.loadStream(c.stream)
 	fisk.FatalIfError(err, "could not request Stream info")
-	err = c.showStream(stream)
+	err = c.showStream(out, stream)
 	fisk.FatalIfError(err, "could not show stream")
 
-	fmt.Println()
+	fmt.Fprintln(out)
+
+	if c.showPlacement && !c.json && c.outTemplate == "" {
+		info, err := stream.LatestInformation()
+		fisk.FatalIfError(err, "could not request Stream info")
+
+		err = c.showEffectivePlacement(out, info)
+		fisk.FatalIfError(err, "could not show effective placement")
+	}
 
 	return nil
 }
@@ -2433,7 +3022,7 @@ func (c *streamCmd) prepareConfig(_ *fisk.ParseContext, requireSize bool) api.St
 	var err error
 
 	if c.inputFile != "" {
-		cfg, err := c.loadConfigFile(c.inputFile)
+		cfg, err := c.loadConfigFile(c.inputFile, true)
 		fisk.FatalIfError(err, "invalid input")
 
 		cfg.Metadata = iu.RemoveReservedMetadata(cfg.Metadata)
@@ -2708,7 +3297,7 @@ func (c *streamCmd) prepareConfig(_ *fisk.ParseContext, requireSize bool) api.St
 		DenyDelete:    c.denyDelete,
 		AllowDirect:   c.allowDirect,
 		AllowMsgTTL:   c.allowMsgTTL,
-		MirrorDirect:  c.allowMirrorDirectSet,
+		MirrorDirect:  c.allowMirrorDirect,
 		DiscardNewPer: c.discardPerSubj,
 	}
 
@@ -2731,13 +3320,18 @@ func (c *streamCmd) prepareConfig(_ *fisk.ParseContext, requireSize bool) api.St
 		}
 	}
 
+	transforms, err := parseSourceTransforms(c.sourceTransforms)
+	fisk.FatalIfError(err, "invalid source transform")
+
 	if c.mirror != "" {
 		if isJsonString(c.mirror) {
 			cfg.Mirror, err = c.parseStreamSource(c.mirror)
 			fisk.FatalIfError(err, "invalid mirror")
 		} else {
 			if !c.acceptDefaults {
-				cfg.Mirror = c.askMirror()
+				cfg.Mirror = c.askMirror(len(transforms[c.mirror]) > 0)
+			} else {
+				cfg.Mirror = &api.StreamSource{Name: c.mirror}
 			}
 		}
 	}
@@ -2748,11 +3342,28 @@ func (c *streamCmd) prepareConfig(_ *fisk.ParseContext, requireSize bool) api.St
 			fisk.FatalIfError(err, "invalid source")
 			cfg.Sources = append(cfg.Sources, ss)
 		} else {
-			ss := c.askSource(source, fmt.Sprintf("%s Source", source))
+			ss := c.askSource(source, fmt.Sprintf("%s Source", source), len(transforms[source]) > 0)
 			cfg.Sources = append(cfg.Sources, ss)
 		}
 	}
 
+	for name, st := range transforms {
+		found := false
+		if cfg.Mirror != nil && cfg.Mirror.Name == name {
+			cfg.Mirror.SubjectTransforms = append(cfg.Mirror.SubjectTransforms, st...)
+			found = true
+		}
+		for _, ss := range cfg.Sources {
+			if ss.Name == name {
+				ss.SubjectTransforms = append(ss.SubjectTransforms, st...)
+				found = true
+			}
+		}
+		if !found {
+			fisk.Fatalf("--source-transform for %q does not match a --source or --mirror", name)
+		}
+	}
+
 	c.checkRepubTransform()
 
 	if c.repubSource != "" && c.repubDest != "" {
@@ -2775,7 +3386,7 @@ func (c *streamCmd) prepareConfig(_ *fisk.ParseContext, requireSize bool) api.St
 	return cfg
 }
 
-func (c *streamCmd) askMirror() *api.StreamSource {
+func (c *streamCmd) askMirror(transformsSet bool) *api.StreamSource {
 	mirror := &api.StreamSource{Name: c.mirror}
 	ok, err := askConfirmation("Adjust mirror start", false)
 	fisk.FatalIfError(err, "Could not request mirror details")
@@ -2799,8 +3410,11 @@ func (c *streamCmd) askMirror() *api.StreamSource {
 		}
 	}
 
-	ok, err = askConfirmation("Adjust mirror filter and transform", false)
-	fisk.FatalIfError(err, "Could not request mirror details")
+	ok = false
+	if !transformsSet {
+		ok, err = askConfirmation("Adjust mirror filter and transform", false)
+		fisk.FatalIfError(err, "Could not request mirror details")
+	}
 
 	if ok {
 		var sources []string
@@ -2880,7 +3494,30 @@ func (c *streamCmd) askMirror() *api.StreamSource {
 	return mirror
 }
 
-func (c *streamCmd) askSource(name string, prefix string) *api.StreamSource {
+// parseSourceTransforms parses STREAM:SOURCE:DEST values into subject transforms per source stream, DEST can be
+// empty to only filter the source. Unlike the paired --transform-source and --transform-destination flags of the
+// Stream transform a single value names the source so that several sources can each be given transforms
+func parseSourceTransforms(vals []string) (map[string][]api.SubjectTransformConfig, error) {
+	res := make(map[string][]api.SubjectTransformConfig)
+
+	for _, val := range vals {
+		parts := strings.SplitN(val, ":", 3)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("expected STREAM:SOURCE:DEST got %q", val)
+		}
+
+		transform := api.SubjectTransformConfig{Source: parts[1]}
+		if len(parts) == 3 {
+			transform.Destination = parts[2]
+		}
+
+		res[parts[0]] = append(res[parts[0]], transform)
+	}
+
+	return res, nil
+}
+
+func (c *streamCmd) askSource(name string, prefix string, transformsSet bool) *api.StreamSource {
 	cfg := &api.StreamSource{Name: name}
 
 	ok, err := askConfirmation(fmt.Sprintf("Adjust source %q start", name), false)
@@ -2903,8 +3540,11 @@ func (c *streamCmd) askSource(name string, prefix string) *api.StreamSource {
 		}
 	}
 
-	ok, err = askConfirmation(fmt.Sprintf("Adjust source %q filter and transform", name), false)
-	fisk.FatalIfError(err, "Could not request source details")
+	ok = false
+	if !transformsSet {
+		ok, err = askConfirmation(fmt.Sprintf("Adjust source %q filter and transform", name), false)
+		fisk.FatalIfError(err, "Could not request source details")
+	}
 
 	if ok {
 		var sources []string
@@ -3003,7 +3643,7 @@ func (c *streamCmd) parseStreamSource(source string) (*api.StreamSource, error)
 }
 
 func (c *streamCmd) validateCfg(cfg *api.StreamConfig) (bool, []byte, []string, error) {
-	if os.Getenv("NOVALIDATE") != "" {
+	if validationLevel() == validationOff {
 		return true, nil, nil, nil
 	}
 
@@ -3025,14 +3665,249 @@ func (c *streamCmd) validateCfg(cfg *api.StreamConfig) (bool, []byte, []string,
 	return valid, j, errs, nil
 }
 
+// warnStreamFeatures warns about configuration the connected server is too old to support
+func (c *streamCmd) warnStreamFeatures(cfg api.StreamConfig) {
+	if cfg.AllowDirect || cfg.MirrorDirect {
+		warnServerFeature(c.nc, "Direct Get", 2, 9, 0)
+	}
+	if cfg.RePublish != nil {
+		warnServerFeature(c.nc, "Republishing", 2, 9, 0)
+	}
+	if cfg.Compression != api.NoCompression {
+		warnServerFeature(c.nc, "Stream compression", 2, 10, 0)
+	}
+	if cfg.SubjectTransform != nil {
+		warnServerFeature(c.nc, "Subject transforms", 2, 10, 0)
+	}
+	if cfg.FirstSeq > 0 {
+		warnServerFeature(c.nc, "Setting the first sequence", 2, 10, 0)
+	}
+	if len(iu.RemoveReservedMetadata(cfg.Metadata)) > 0 {
+		warnServerFeature(c.nc, "Stream metadata", 2, 10, 0)
+	}
+}
+
+// bucketStreamProblems detects streams named like KV or Object Store bucket backing streams, returning the kind of bucket
+// and the settings that differ from what the KV and Object Store clients create
+func bucketStreamProblems(cfg api.StreamConfig) (string, []string) {
+	var kind, bucket string
+	var subjects []string
+
+	switch {
+	case jsm.IsKVBucketStream(cfg.Name):
+		kind, bucket = "kv", strings.TrimPrefix(cfg.Name, "KV_")
+	case jsm.IsObjectBucketStream(cfg.Name):
+		kind, bucket = "object", strings.TrimPrefix(cfg.Name, "OBJ_")
+	default:
+		for _, subj := range cfg.Subjects {
+			tokens := strings.Split(subj, ".")
+			if len(tokens) < 2 {
+				continue
+			}
+
+			switch tokens[0] {
+			case "$KV":
+				kind, bucket = "kv", tokens[1]
+			case "$O":
+				kind, bucket = "object", tokens[1]
+			}
+		}
+		if kind == "" {
+			return "", nil
+		}
+	}
+
+	var problems []string
+	if kind == "kv" {
+		subjects = []string{fmt.Sprintf("$KV.%s.>", bucket)}
+		if cfg.Name != "KV_"+bucket {
+			problems = append(problems, fmt.Sprintf("the Stream must be named KV_%s", bucket))
+		}
+	} else {
+		subjects = []string{fmt.Sprintf("$O.%s.C.>", bucket), fmt.Sprintf("$O.%s.M.>", bucket)}
+		if cfg.Name != "OBJ_"+bucket {
+			problems = append(problems, fmt.Sprintf("the Stream must be named OBJ_%s", bucket))
+		}
+	}
+
+	have := append([]string{}, cfg.Subjects...)
+	sort.Strings(have)
+	if !slices.Equal(have, subjects) {
+		problems = append(problems, fmt.Sprintf("subjects must be %s", strings.Join(subjects, ", ")))
+	}
+	if !cfg.AllowRollup {
+		problems = append(problems, "rollups must be allowed")
+	}
+	if !cfg.AllowDirect {
+		problems = append(problems, "direct get must be allowed")
+	}
+	if cfg.Discard != api.DiscardNew {
+		problems = append(problems, "the new discard policy is required")
+	}
+	if kind == "kv" {
+		if !cfg.DenyDelete {
+			problems = append(problems, "message deletes must be denied")
+		}
+		if cfg.MaxMsgsPer < 1 {
+			problems = append(problems, "the maximum messages per subject sets the history and must be at least 1")
+		}
+	}
+
+	return kind, problems
+}
+
+// checkBucketLookalike warns when creating a stream that looks like a KV or Object Store bucket, with --kv-compatible
+// the stream has to be configured like those clients require, otherwise creation has to be confirmed
+func (c *streamCmd) checkBucketLookalike(cfg api.StreamConfig) (bool, error) {
+	kind, problems := bucketStreamProblems(cfg)
+	if kind == "" {
+		return true, nil
+	}
+
+	if c.kvCompatible {
+		if len(problems) > 0 {
+			return false, fmt.Errorf("stream %s is not compatible with %s buckets:\n\t%s", cfg.Name, kind, strings.Join(problems, "\n\t"))
+		}
+
+		return true, nil
+	}
+
+	fmt.Printf("WARNING: Stream %s looks like the backing Stream of a %s bucket, buckets should be created using 'nats %s add'\n", cfg.Name, kind, kind)
+	for _, p := range problems {
+		fmt.Printf("         %s\n", p)
+	}
+	fmt.Println()
+
+	if c.force {
+		return true, nil
+	}
+
+	ok, err := askConfirmation(fmt.Sprintf("Really create Stream %s, clients might fail to open it as a bucket", cfg.Name), false)
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+// streamLimitProblems finds combinations of limits the server rejects and those that have no effect
+func streamLimitProblems(cfg api.StreamConfig) (errs []string, warnings []string) {
+	for name, v := range map[string]int64{"maximum messages": cfg.MaxMsgs, "maximum bytes": cfg.MaxBytes, "maximum messages per subject": cfg.MaxMsgsPer, "maximum message size": int64(cfg.MaxMsgSize)} {
+		if v < -1 {
+			errs = append(errs, fmt.Sprintf("%s may not be less than -1", name))
+		}
+	}
+	if cfg.MaxAge < 0 {
+		errs = append(errs, "maximum age may not be negative")
+	}
+	if cfg.Duplicates < 0 {
+		errs = append(errs, "duplicate window may not be negative")
+	}
+	if cfg.MaxAge > 0 && cfg.Duplicates > cfg.MaxAge {
+		errs = append(errs, fmt.Sprintf("duplicate window %v may not exceed the maximum age %v", cfg.Duplicates, cfg.MaxAge))
+	}
+	if cfg.MaxMsgSize > 0 && cfg.MaxBytes > 0 && int64(cfg.MaxMsgSize) > cfg.MaxBytes {
+		errs = append(errs, fmt.Sprintf("maximum message size %s exceeds the maximum bytes %s", humanize.IBytes(uint64(cfg.MaxMsgSize)), humanize.IBytes(uint64(cfg.MaxBytes))))
+	}
+	if cfg.DiscardNewPer && cfg.Discard != api.DiscardNew {
+		errs = append(errs, "discard per subject requires the new discard policy")
+	}
+	if cfg.DiscardNewPer && cfg.MaxMsgsPer <= 0 {
+		errs = append(errs, "discard per subject requires a maximum messages per subject limit")
+	}
+	sort.Strings(errs)
+
+	if cfg.MaxMsgsPer > 0 && cfg.MaxMsgs > 0 && cfg.MaxMsgsPer > cfg.MaxMsgs {
+		warnings = append(warnings, fmt.Sprintf("maximum messages per subject %s exceeds the maximum messages %s", f(cfg.MaxMsgsPer), f(cfg.MaxMsgs)))
+	}
+	if cfg.Discard == api.DiscardNew && cfg.MaxMsgs <= 0 && cfg.MaxBytes <= 0 && cfg.MaxMsgsPer <= 0 {
+		warnings = append(warnings, "the new discard policy has no effect without message, byte or per subject limits")
+	}
+
+	return errs, warnings
+}
+
+// checkStreamLimits validates the limits in cfg before submitting it, warning about limits that have no effect
+func checkStreamLimits(cfg api.StreamConfig) error {
+	errs, warnings := streamLimitProblems(cfg)
+	for _, w := range warnings {
+		log.Printf("WARNING: %s", w)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid Stream limits:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+
+	return nil
+}
+
+// checkRepublishLoop refuses configurations that republish messages back into the same stream unless allowed
+func (c *streamCmd) checkRepublishLoop(cfg api.StreamConfig) error {
+	if c.allowLoop || cfg.RePublish == nil {
+		return nil
+	}
+
+	subject, loop := subjectLoop(cfg.Subjects, cfg.RePublish.Destination)
+	if loop {
+		return fmt.Errorf("republish destination %q overlaps with stream subject %q and would create a loop, use --allow-loop to override", cfg.RePublish.Destination, subject)
+	}
+
+	return nil
+}
+
+// immutableStreamChanges lists changes from live to desired that the server would reject in an update
+func immutableStreamChanges(live api.StreamConfig, desired api.StreamConfig) []string {
+	var changes []string
+
+	if live.Storage != desired.Storage {
+		changes = append(changes, fmt.Sprintf("Storage can not be changed from %v to %v", live.Storage, desired.Storage))
+	}
+
+	if live.Retention != desired.Retention {
+		// limits and interest retention can be switched between, work queue is fixed
+		if live.Retention == api.WorkQueuePolicy || desired.Retention == api.WorkQueuePolicy {
+			changes = append(changes, fmt.Sprintf("Retention can not be changed from %v to %v", live.Retention, desired.Retention))
+		}
+	}
+
+	if live.MaxConsumers != desired.MaxConsumers {
+		changes = append(changes, fmt.Sprintf("Maximum Consumers can not be changed from %d to %d", live.MaxConsumers, desired.MaxConsumers))
+	}
+
+	lm, _ := json.Marshal(live.Mirror)
+	dm, _ := json.Marshal(desired.Mirror)
+	if !bytes.Equal(lm, dm) {
+		changes = append(changes, "Mirror configuration can not be changed")
+	}
+
+	if live.Sealed && !desired.Sealed {
+		changes = append(changes, "Sealed Streams can not be unsealed")
+	}
+
+	if live.DenyDelete && !desired.DenyDelete {
+		changes = append(changes, "Deny Delete can not be disabled")
+	}
+
+	if live.DenyPurge && !desired.DenyPurge {
+		changes = append(changes, "Deny Purge can not be disabled")
+	}
+
+	return changes
+}
+
 func (c *streamCmd) addAction(pc *fisk.ParseContext) (err error) {
-	_, mgr, err := prepareHelper("", natsOpts()...)
+	nc, mgr, err := prepareHelper("", natsOpts()...)
 	fisk.FatalIfError(err, "could not create Stream")
 
 	requireSize, _ := mgr.IsStreamMaxBytesRequired()
 
 	cfg := c.prepareConfig(pc, requireSize)
 
+	err = checkStreamLimits(cfg)
+	if err != nil {
+		return err
+	}
+
 	switch {
 	case c.validateOnly:
 		valid, j, errs, err := c.validateCfg(&cfg)
@@ -3047,7 +3922,27 @@ func (c *streamCmd) addAction(pc *fisk.ParseContext) (err error) {
 		}
 
 		fmt.Printf("Configuration is a valid Stream matching %s\n", cfg.SchemaType())
-		return nil
+
+		known, err := mgr.IsKnownStream(c.stream)
+		if err != nil {
+			return err
+		}
+		if !known {
+			return nil
+		}
+
+		stream, err := mgr.LoadStream(c.stream)
+		if err != nil {
+			return err
+		}
+
+		live := stream.Configuration()
+		live.Metadata = iu.RemoveReservedMetadata(live.Metadata)
+		cfg.Metadata = iu.RemoveReservedMetadata(cfg.Metadata)
+		sort.Strings(live.Subjects)
+		sort.Strings(cfg.Subjects)
+
+		return renderValidatePlan("Stream", c.stream, live, cfg, immutableStreamChanges(live, cfg))
 
 	case c.outFile != "":
 		valid, j, errs, err := c.validateCfg(&cfg)
@@ -3060,12 +3955,30 @@ func (c *streamCmd) addAction(pc *fisk.ParseContext) (err error) {
 		return os.WriteFile(c.outFile, j, 0600)
 	}
 
+	err = c.checkRepublishLoop(cfg)
+	if err != nil {
+		return err
+	}
+
+	ok, err := c.checkBucketLookalike(cfg)
+	if err != nil || !ok {
+		return err
+	}
+
+	c.nc = nc
+	c.warnStreamFeatures(cfg)
+
+	err = validateRequest(cfg)
+	if err != nil {
+		return err
+	}
+
 	str, err := mgr.NewStreamFromDefault(c.stream, cfg)
 	fisk.FatalIfError(err, "could not create Stream")
 
 	fmt.Printf("Stream %s was created\n\n", c.stream)
 
-	c.showStream(str)
+	c.showStream(os.Stdout, str)
 
 	return nil
 }
@@ -3110,6 +4023,10 @@ func (c *streamCmd) rmAction(_ *fisk.ParseContext) (err error) {
 func (c *streamCmd) purgeAction(_ *fisk.ParseContext) (err error) {
 	c.connectAndAskStream()
 
+	if c.purgeOlderThan > 0 {
+		return c.purgeOlderThanAction()
+	}
+
 	if !c.force {
 		ok, err := askConfirmation(fmt.Sprintf("Really purge Stream %s", c.stream), false)
 		fisk.FatalIfError(err, "could not obtain confirmation")
@@ -3133,6 +4050,11 @@ func (c *streamCmd) purgeAction(_ *fisk.ParseContext) (err error) {
 			Subject:  c.purgeSubject,
 			Keep:     c.purgeKeep,
 		}
+
+		err = validateRequest(req)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = stream.Purge(req)
@@ -3140,25 +4062,93 @@ func (c *streamCmd) purgeAction(_ *fisk.ParseContext) (err error) {
 
 	stream.Reset()
 
-	c.showStream(stream)
+	c.showStream(os.Stdout, stream)
 
 	return nil
 }
 
-func (c *streamCmd) lsNames(mgr *jsm.Manager, filter *jsm.StreamNamesFilter) error {
+// purgeOlderThanAction purges messages stored before the --older-than cutoff by resolving it to a sequence
+func (c *streamCmd) purgeOlderThanAction() error {
+	if c.purgeSequence > 0 || c.purgeKeep > 0 {
+		return fmt.Errorf("--older-than cannot be combined with --seq or --keep")
+	}
+
+	stream, err := c.loadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	before, err := stream.State()
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-c.purgeOlderThan)
+	res, err := c.seqForTime(stream, cutoff)
+	if err != nil {
+		return err
+	}
+
+	if before.Msgs == 0 || res.Sequence <= before.FirstSeq {
+		fmt.Printf("No messages in Stream %s are older than %s\n", c.stream, f(c.purgeOlderThan))
+		return nil
+	}
+
+	what := "messages"
+	if c.purgeSubject != "" {
+		what = fmt.Sprintf("messages on subject %s", c.purgeSubject)
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really purge %s stored before %s (sequences before %s) from Stream %s", what, cutoff.Format(time.RFC3339), f(res.Sequence), c.stream), false)
+		fisk.FatalIfError(err, "could not obtain confirmation")
+
+		if !ok {
+			return nil
+		}
+	}
+
+	req := &api.JSApiStreamPurgeRequest{Sequence: res.Sequence, Subject: c.purgeSubject}
+	err = validateRequest(req)
+	if err != nil {
+		return err
+	}
+
+	err = stream.Purge(req)
+	if err != nil {
+		return fmt.Errorf("could not purge Stream: %w", err)
+	}
+
+	stream.Reset()
+
+	after, err := stream.State()
+	if err != nil {
+		return err
+	}
+
+	if !c.json {
+		fmt.Printf("Purged %s %s older than %s reclaiming %s\n\n", f(before.Msgs-min(before.Msgs, after.Msgs)), what, f(c.purgeOlderThan), humanize.IBytes(before.Bytes-min(before.Bytes, after.Bytes)))
+	}
+
+	c.showStream(os.Stdout, stream)
+
+	return nil
+}
+
+func (c *streamCmd) lsNames(w io.Writer, mgr *jsm.Manager, filter *jsm.StreamNamesFilter) error {
 	names, err := mgr.StreamNames(filter)
 	if err != nil {
 		return err
 	}
 
 	if c.json {
-		err = iu.PrintJSON(names)
+		err = iu.FprintJSON(w, names)
 		fisk.FatalIfError(err, "could not display Streams")
 		return nil
 	}
 
 	for _, n := range names {
-		fmt.Println(n)
+		fmt.Fprintln(w, n)
 	}
 
 	return nil
@@ -3168,13 +4158,16 @@ func (c *streamCmd) lsAction(_ *fisk.ParseContext) error {
 	_, mgr, err := prepareHelper("", natsOpts()...)
 	fisk.FatalIfError(err, "setup failed")
 
+	out := newPager()
+	defer out.Close()
+
 	var filter *jsm.StreamNamesFilter
 	if c.filterSubject != "" {
 		filter = &jsm.StreamNamesFilter{Subject: c.filterSubject}
 	}
 
-	if c.listNames {
-		return c.lsNames(mgr, filter)
+	if c.listNames && len(c.metaSelectors) == 0 {
+		return c.lsNames(out, mgr, filter)
 	}
 
 	var streams []*jsm.Stream
@@ -3188,6 +4181,10 @@ func (c *streamCmd) lsAction(_ *fisk.ParseContext) error {
 			return
 		}
 
+		if !metadataMatches(s.Metadata(), c.metaSelectors) {
+			return
+		}
+
 		streams = append(streams, s)
 		names = append(names, s.Name())
 	})
@@ -3195,26 +4192,44 @@ func (c *streamCmd) lsAction(_ *fisk.ParseContext) error {
 		return fmt.Errorf("could not list streams: %s", err)
 	}
 
+	if c.outTemplate != "" {
+		var infos []*api.StreamInfo
+		for _, s := range streams {
+			info, err := s.LatestInformation()
+			if err != nil {
+				return err
+			}
+			infos = append(infos, info)
+		}
+
+		return renderOutputTemplateTo(out, c.outTemplate, infos)
+	}
+
 	if c.json {
-		err = iu.PrintJSON(names)
+		err = iu.FprintJSON(out, names)
 		fisk.FatalIfError(err, "could not display Streams")
 		return nil
 	}
 
+	if c.listNames {
+		fmt.Fprintln(out, c.renderStreamsAsList(streams, nil))
+		return nil
+	}
+
 	if len(streams) == 0 && skipped {
-		fmt.Println("No Streams defined, pass -a to include system streams")
+		fmt.Fprintln(out, "No Streams defined, pass -a to include system streams")
 		return nil
 	} else if len(streams) == 0 {
-		fmt.Println("No Streams defined")
+		fmt.Fprintln(out, "No Streams defined")
 		return nil
 	}
 
-	out, err := c.renderStreamsAsTable(streams, missing)
+	table, err := c.renderStreamsAsTable(streams, missing)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(out)
+	fmt.Fprintln(out, table)
 
 	return nil
 }
@@ -3247,10 +4262,19 @@ func (c *streamCmd) renderStreamsAsTable(streams []*jsm.Stream, missing []string
 		table = iu.NewTableWriter(opts(), fmt.Sprintf("Streams matching %s", c.filterSubject))
 	}
 
-	table.AddHeaders("Name", "Description", "Created", "Messages", "Size", "Last Message")
+	if c.filterSubject == "" {
+		table.AddHeaders("Name", "Description", "Created", "Messages", "Size", "Last Message")
+	} else {
+		table.AddHeaders("Name", "Description", "Matching Subjects", "Created", "Messages", "Size", "Last Message")
+	}
+
 	for _, s := range streams {
 		nfo, _ := s.LatestInformation()
-		table.AddRow(s.Name(), s.Description(), f(nfo.Created.Local()), f(nfo.State.Msgs), humanize.IBytes(nfo.State.Bytes), f(sinceRefOrNow(nfo.TimeStamp, nfo.State.LastTime)))
+		if c.filterSubject == "" {
+			table.AddRow(s.Name(), s.Description(), f(nfo.Created.Local()), f(nfo.State.Msgs), humanize.IBytes(nfo.State.Bytes), f(sinceRefOrNow(nfo.TimeStamp, nfo.State.LastTime)))
+		} else {
+			table.AddRow(s.Name(), s.Description(), f(matchingStreamSubjects(s.Subjects(), c.filterSubject)), f(nfo.Created.Local()), f(nfo.State.Msgs), humanize.IBytes(nfo.State.Bytes), f(sinceRefOrNow(nfo.TimeStamp, nfo.State.LastTime)))
+		}
 	}
 
 	fmt.Fprintln(&out, table.Render())
@@ -3260,6 +4284,18 @@ func (c *streamCmd) renderStreamsAsTable(streams []*jsm.Stream, missing []string
 	return out.String(), nil
 }
 
+// matchingStreamSubjects finds the stream subjects that capture messages published to subjects matching filter
+func matchingStreamSubjects(subjects []string, filter string) []string {
+	var res []string
+	for _, subject := range subjects {
+		if server.SubjectsCollide(subject, filter) {
+			res = append(res, subject)
+		}
+	}
+
+	return res
+}
+
 func (c *streamCmd) renderMissing(out io.Writer, missing []string) {
 	toany := func(items []string) (res []any) {
 		for _, i := range items {
@@ -3340,15 +4376,31 @@ func (c *streamCmd) getAction(_ *fisk.ParseContext) (err error) {
 	stream, err := c.loadStream(c.stream)
 	fisk.FatalIfError(err, "could not load Stream %s", c.stream)
 
-	var item *api.StoredMsg
-	if c.msgID > -1 {
-		item, err = stream.ReadMessage(uint64(c.msgID))
-	} else if c.filterSubject != "" {
-		item, err = stream.ReadLastMessageForSubject(c.filterSubject)
-	} else {
+	if c.msgID < 0 && c.filterSubject == "" {
 		return fmt.Errorf("no ID or subject specified")
 	}
-	fisk.FatalIfError(err, "could not retrieve %s#%d", c.stream, c.msgID)
+
+	var item *api.StoredMsg
+	if c.getDirect && stream.DirectAllowed() {
+		item, err = c.directReadMessage(stream)
+		if errors.Is(err, nats.ErrNoResponders) || errors.Is(err, nats.ErrTimeout) {
+			if opts().Trace {
+				log.Printf("Direct get failed, falling back to the JetStream API: %v", err)
+			}
+			item = nil
+		} else {
+			fisk.FatalIfError(err, "could not retrieve %s#%d", c.stream, c.msgID)
+		}
+	}
+
+	if item == nil {
+		if c.msgID > -1 {
+			item, err = stream.ReadMessage(uint64(c.msgID))
+		} else {
+			item, err = stream.ReadLastMessageForSubject(c.filterSubject)
+		}
+		fisk.FatalIfError(err, "could not retrieve %s#%d", c.stream, c.msgID)
+	}
 
 	if c.json {
 		iu.PrintJSON(item)
@@ -3369,10 +4421,83 @@ func (c *streamCmd) getAction(_ *fisk.ParseContext) (err error) {
 		}
 		fmt.Println()
 	}
-	outPutMSGBody(item.Data, c.vwTranslate, item.Subject, c.stream)
+	data, err := decodePayload(item.Data, c.vwDecoders)
+	if err != nil {
+		log.Printf("Could not decode message: %v", err)
+		data = item.Data
+	}
+
+	outPutMSGBody(data, c.vwTranslate, item.Subject, c.stream)
 	return nil
 }
 
+// directReadMessage retrieves the message by sequence or the last message for the filter subject using the direct get API
+func (c *streamCmd) directReadMessage(stream *jsm.Stream) (*api.StoredMsg, error) {
+	req := api.JSApiMsgGetRequest{LastFor: c.filterSubject}
+	if c.msgID > -1 {
+		req = api.JSApiMsgGetRequest{Seq: uint64(c.msgID)}
+	}
+
+	err := validateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rj, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.nc.Request(stream.DirectSubject(), rj, opts().Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Header.Get("Status") {
+	case "":
+	case "404":
+		return nil, fmt.Errorf("no message found")
+	default:
+		return nil, fmt.Errorf("direct get failed: %s %s", resp.Header.Get("Status"), resp.Header.Get("Description"))
+	}
+
+	return directGetStoredMsg(resp)
+}
+
+// directGetStoredMsg converts a direct get response into the form returned by the JetStream API
+func directGetStoredMsg(msg *nats.Msg) (*api.StoredMsg, error) {
+	seq, err := strconv.ParseUint(msg.Header.Get("Nats-Sequence"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sequence in direct get response: %w", err)
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, msg.Header.Get("Nats-Time-Stamp"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid time stamp in direct get response: %w", err)
+	}
+
+	item := &api.StoredMsg{
+		Subject:  msg.Header.Get("Nats-Subject"),
+		Sequence: seq,
+		Data:     msg.Data,
+		Time:     ts,
+	}
+
+	hdr := nats.Header{}
+	for k, v := range msg.Header {
+		switch k {
+		case "Nats-Stream", "Nats-Subject", "Nats-Sequence", "Nats-Time-Stamp", "Nats-Num-Pending", "Nats-Last-Sequence":
+			continue
+		}
+		hdr[k] = v
+	}
+	if len(hdr) > 0 {
+		item.Header = encodeHeadersMsg(hdr)
+	}
+
+	return item, nil
+}
+
 func (c *streamCmd) connectAndAskStream() bool {
 	var err error
 
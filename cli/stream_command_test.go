@@ -0,0 +1,201 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+)
+
+func TestSubjectTokenStats(t *testing.T) {
+	stats := subjectTokenStats(map[string]uint64{
+		"orders.eu.1":  10,
+		"orders.eu.2":  5,
+		"orders.us.3":  1,
+		"orders.us":    2,
+		"payments.eu":  1,
+		"payments.eu2": 1,
+	})
+
+	expect := []streamTokenStat{
+		{Position: 1, Distinct: 2, Subjects: 6, Messages: 20, Top: "orders", TopMessages: 18},
+		{Position: 2, Distinct: 3, Subjects: 6, Messages: 20, Top: "eu", TopMessages: 16},
+		{Position: 3, Distinct: 3, Subjects: 3, Messages: 16, Top: "1", TopMessages: 10},
+	}
+
+	if !cmp.Equal(stats, expect) {
+		t.Fatalf("invalid stats: %s", cmp.Diff(expect, stats))
+	}
+}
+
+func TestImmutableStreamChanges(t *testing.T) {
+	live := api.StreamConfig{Retention: api.LimitsPolicy, Storage: api.FileStorage, MaxConsumers: -1, DenyDelete: true}
+
+	desired := live
+	desired.Retention = api.InterestPolicy
+	desired.MaxAge = time.Hour
+	if changes := immutableStreamChanges(live, desired); len(changes) != 0 {
+		t.Fatalf("expected no immutable changes got %v", changes)
+	}
+
+	desired.Storage = api.MemoryStorage
+	desired.Retention = api.WorkQueuePolicy
+	desired.DenyDelete = false
+	if changes := immutableStreamChanges(live, desired); len(changes) != 3 {
+		t.Fatalf("expected 3 immutable changes got %v", changes)
+	}
+}
+
+func TestStreamLimitProblems(t *testing.T) {
+	cases := []struct {
+		cfg      api.StreamConfig
+		errs     int
+		warnings int
+	}{
+		{cfg: api.StreamConfig{MaxMsgs: -1, MaxBytes: -1, MaxMsgsPer: -1, MaxMsgSize: -1}},
+		{cfg: api.StreamConfig{MaxAge: time.Hour, Duplicates: 2 * time.Hour}, errs: 1},
+		{cfg: api.StreamConfig{MaxAge: time.Hour, Duplicates: time.Minute}},
+		{cfg: api.StreamConfig{MaxBytes: 1024, MaxMsgSize: 2048}, errs: 1},
+		{cfg: api.StreamConfig{MaxMsgs: -2, MaxAge: -1}, errs: 2},
+		{cfg: api.StreamConfig{DiscardNewPer: true, Discard: api.DiscardOld}, errs: 2},
+		{cfg: api.StreamConfig{DiscardNewPer: true, Discard: api.DiscardNew, MaxMsgsPer: 1}},
+		{cfg: api.StreamConfig{MaxMsgs: 10, MaxMsgsPer: 100}, warnings: 1},
+		{cfg: api.StreamConfig{MaxMsgs: -1, MaxBytes: -1, Discard: api.DiscardNew}, warnings: 1},
+	}
+
+	for i, tc := range cases {
+		errs, warnings := streamLimitProblems(tc.cfg)
+		if len(errs) != tc.errs || len(warnings) != tc.warnings {
+			t.Fatalf("case %d: expected %d errors and %d warnings got %v and %v", i, tc.errs, tc.warnings, errs, warnings)
+		}
+	}
+}
+
+func TestDirectGetStoredMsg(t *testing.T) {
+	msg := nats.NewMsg("_INBOX.x")
+	msg.Data = []byte("hello")
+	msg.Header.Set("Nats-Stream", "ORDERS")
+	msg.Header.Set("Nats-Subject", "ORDERS.new")
+	msg.Header.Set("Nats-Sequence", "10")
+	msg.Header.Set("Nats-Time-Stamp", "2025-01-02T15:04:05.000000001Z")
+	msg.Header.Set("Order-Id", "123")
+
+	item, err := directGetStoredMsg(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if item.Subject != "ORDERS.new" || item.Sequence != 10 || string(item.Data) != "hello" || item.Time.Nanosecond() != 1 {
+		t.Fatalf("invalid message: %+v", item)
+	}
+
+	hdrs, err := decodeHeadersMsg(item.Header)
+	if err != nil {
+		t.Fatalf("could not decode headers: %v", err)
+	}
+	if len(hdrs) != 1 || hdrs.Get("Order-Id") != "123" {
+		t.Fatalf("invalid headers: %v", hdrs)
+	}
+
+	msg.Header.Del("Order-Id")
+	item, err = directGetStoredMsg(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Header != nil {
+		t.Fatalf("expected no headers: %q", item.Header)
+	}
+}
+
+func TestBucketStreamProblems(t *testing.T) {
+	kvCfg := api.StreamConfig{
+		Name:        "KV_CONFIG",
+		Subjects:    []string{"$KV.CONFIG.>"},
+		AllowRollup: true,
+		AllowDirect: true,
+		DenyDelete:  true,
+		Discard:     api.DiscardNew,
+		MaxMsgsPer:  5,
+	}
+
+	kind, problems := bucketStreamProblems(kvCfg)
+	if kind != "kv" || len(problems) != 0 {
+		t.Fatalf("expected a compatible kv stream: %s %v", kind, problems)
+	}
+
+	kvCfg.Name = "CONFIG"
+	kvCfg.AllowRollup = false
+	kind, problems = bucketStreamProblems(kvCfg)
+	if kind != "kv" || len(problems) != 2 {
+		t.Fatalf("expected 2 problems: %s %v", kind, problems)
+	}
+
+	kind, problems = bucketStreamProblems(api.StreamConfig{Name: "OBJ_FILES", Subjects: []string{"$O.FILES.M.>", "$O.FILES.C.>"}, AllowRollup: true, AllowDirect: true, Discard: api.DiscardNew})
+	if kind != "object" || len(problems) != 0 {
+		t.Fatalf("expected a compatible object stream: %s %v", kind, problems)
+	}
+
+	kind, _ = bucketStreamProblems(api.StreamConfig{Name: "ORDERS", Subjects: []string{"ORDERS.>"}})
+	if kind != "" {
+		t.Fatalf("expected no bucket detection got %s", kind)
+	}
+}
+
+func TestParseSourceTransforms(t *testing.T) {
+	res, err := parseSourceTransforms([]string{"ORDERS:orders.0.*:orders.{{wildcard(1)}}", "ORDERS:orders.new", "OTHER:a.>:b.>"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect := map[string][]api.SubjectTransformConfig{
+		"ORDERS": {
+			{Source: "orders.0.*", Destination: "orders.{{wildcard(1)}}"},
+			{Source: "orders.new"},
+		},
+		"OTHER": {{Source: "a.>", Destination: "b.>"}},
+	}
+	if !cmp.Equal(res, expect) {
+		t.Fatalf("invalid transforms: %s", cmp.Diff(expect, res))
+	}
+
+	for _, invalid := range []string{"ORDERS", ":orders.>", "ORDERS::x"} {
+		_, err = parseSourceTransforms([]string{invalid})
+		if err == nil {
+			t.Fatalf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestMatchingStreamSubjects(t *testing.T) {
+	subjects := []string{"orders.*", "orders.new.>", "invoices.>", "orders"}
+
+	res := matchingStreamSubjects(subjects, "orders.new")
+	if !cmp.Equal(res, []string{"orders.*"}) {
+		t.Fatalf("invalid subjects: %v", res)
+	}
+
+	res = matchingStreamSubjects(subjects, "orders.>")
+	if !cmp.Equal(res, []string{"orders.*", "orders.new.>"}) {
+		t.Fatalf("invalid subjects: %v", res)
+	}
+
+	res = matchingStreamSubjects(subjects, "shipping.new")
+	if len(res) != 0 {
+		t.Fatalf("expected no subjects: %v", res)
+	}
+}
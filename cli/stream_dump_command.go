@@ -0,0 +1,329 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/choria-io/fisk"
+	"github.com/dustin/go-humanize"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// streamDumpMsg is a single message written by stream dump and read by stream load
+type streamDumpMsg struct {
+	Stream   string              `json:"stream"`
+	Sequence uint64              `json:"seq"`
+	Subject  string              `json:"subject"`
+	Time     time.Time           `json:"time"`
+	Headers  map[string][]string `json:"headers,omitempty"`
+	Encoding string              `json:"encoding"`
+	Data     string              `json:"data"`
+}
+
+func newStreamDumpMsg(msg jetstream.Msg) (*streamDumpMsg, error) {
+	meta, err := msg.Metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	dm := &streamDumpMsg{
+		Stream:   meta.Stream,
+		Sequence: meta.Sequence.Stream,
+		Subject:  msg.Subject(),
+		Time:     meta.Timestamp.UTC(),
+	}
+
+	if len(msg.Headers()) > 0 {
+		dm.Headers = msg.Headers()
+	}
+
+	if utf8.Valid(msg.Data()) {
+		dm.Encoding = "string"
+		dm.Data = string(msg.Data())
+	} else {
+		dm.Encoding = "base64"
+		dm.Data = base64.StdEncoding.EncodeToString(msg.Data())
+	}
+
+	return dm, nil
+}
+
+func (m *streamDumpMsg) data() ([]byte, error) {
+	switch m.Encoding {
+	case "base64":
+		return base64.StdEncoding.DecodeString(m.Data)
+	case "string", "":
+		return []byte(m.Data), nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %q for message %d", m.Encoding, m.Sequence)
+	}
+}
+
+func (c *streamCmd) dumpAction(_ *fisk.ParseContext) error {
+	toStdout := c.dumpTarget == "-"
+	if toStdout && !c.dumpJSONL {
+		return fmt.Errorf("writing to STDOUT requires --jsonl")
+	}
+
+	if !toStdout {
+		err := os.MkdirAll(c.dumpTarget, 0700)
+		if err != nil {
+			return err
+		}
+	}
+
+	nc, js, err := prepareJSHelper()
+	if err != nil {
+		return err
+	}
+	c.nc = nc
+
+	cfg := jetstream.OrderedConsumerConfig{}
+	if c.filterSubject != "" {
+		cfg.FilterSubjects = []string{c.filterSubject}
+	}
+	if c.dumpSince > 0 {
+		start := time.Now().Add(-c.dumpSince)
+		cfg.DeliverPolicy = jetstream.DeliverByStartTimePolicy
+		cfg.OptStartTime = &start
+	}
+
+	var out *bufio.Writer
+	if c.dumpJSONL {
+		var w io.Writer = os.Stdout
+		if !toStdout {
+			fh, err := os.OpenFile(filepath.Join(c.dumpTarget, c.stream+".jsonl"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			defer fh.Close()
+			w = fh
+		}
+
+		out = bufio.NewWriter(w)
+		defer out.Flush()
+	}
+
+	var count, size uint64
+
+	err = walkStream(ctx, js, c.stream, cfg, 0, func(msg jetstream.Msg, _ *jetstream.MsgMetadata) error {
+		dm, err := newStreamDumpMsg(msg)
+		if err != nil {
+			return err
+		}
+
+		j, err := json.Marshal(dm)
+		if err != nil {
+			return err
+		}
+
+		if c.dumpJSONL {
+			_, err = fmt.Fprintln(out, string(j))
+		} else {
+			err = os.WriteFile(filepath.Join(c.dumpTarget, fmt.Sprintf("%020d.json", dm.Sequence)), j, 0600)
+		}
+		if err != nil {
+			return err
+		}
+
+		count++
+		size += uint64(len(msg.Data()))
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("dump failed after %s messages: %w", f(count), err)
+	}
+
+	if !toStdout {
+		fmt.Printf("Dumped %s messages with %s of data from %s to %s\n", f(count), humanize.IBytes(size), c.stream, c.dumpTarget)
+	}
+
+	return nil
+}
+
+// readStreamDump reads messages from a JSONL archive or a directory of message files one at a time calling cb for each,
+// they are read in the order stream dump wrote them which is by sequence
+func readStreamDump(source string, cb func(msg *streamDumpMsg) error) error {
+	if iu.IsDirectory(source) {
+		files, err := filepath.Glob(filepath.Join(source, "*.json"))
+		if err != nil {
+			return err
+		}
+
+		jsonl, err := filepath.Glob(filepath.Join(source, "*.jsonl"))
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case len(files) > 0 && len(jsonl) > 0:
+			return fmt.Errorf("%s holds both message files and JSONL archives", source)
+		case len(jsonl) > 1:
+			return fmt.Errorf("%s holds multiple JSONL archives, specify the file to load", source)
+		case len(jsonl) == 1:
+			return readStreamDump(jsonl[0], cb)
+		}
+
+		// message files are named using the zero padded sequence and glob sorts them
+		for _, file := range files {
+			j, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+
+			var msg streamDumpMsg
+			err = json.Unmarshal(j, &msg)
+			if err != nil {
+				return fmt.Errorf("could not parse %s: %w", file, err)
+			}
+
+			err = cb(&msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	fh, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	scanner := bufio.NewScanner(fh)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var msg streamDumpMsg
+		err = json.Unmarshal(scanner.Bytes(), &msg)
+		if err != nil {
+			return fmt.Errorf("could not parse line %d: %w", line, err)
+		}
+
+		err = cb(&msg)
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// publishArchivedMsg publishes a message read from an archive into stream, true when the Stream discarded it as a
+// duplicate. Expectation headers are removed as they were checked when the message was first published and would fail now
+func publishArchivedMsg(js jetstream.JetStream, stream string, subject string, headers map[string][]string, data []byte) (bool, error) {
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	for k, vals := range headers {
+		if strings.HasPrefix(k, "Nats-Expected-") {
+			continue
+		}
+		for _, v := range vals {
+			msg.Header.Add(k, v)
+		}
+	}
+
+	ack, err := js.PublishMsg(ctx, msg, jetstream.WithExpectStream(stream))
+	if err != nil {
+		return false, err
+	}
+
+	return ack.Duplicate, nil
+}
+
+func (c *streamCmd) loadAction(_ *fisk.ParseContext) error {
+	// the dump is read twice, first to validate it and count the messages
+	var total int
+	err := readStreamDump(c.dumpTarget, func(_ *streamDumpMsg) error {
+		total++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if total == 0 {
+		fmt.Printf("No messages found in %s\n", c.dumpTarget)
+		return nil
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really publish %s messages into Stream %s", f(total), c.stream), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	_, js, err := prepareJSHelper()
+	if err != nil {
+		return err
+	}
+
+	var count, duplicates, size uint64
+	err = readStreamDump(c.dumpTarget, func(dm *streamDumpMsg) error {
+		data, err := dm.data()
+		if err != nil {
+			return err
+		}
+
+		duplicate, err := publishArchivedMsg(js, c.stream, dm.Subject, dm.Headers, data)
+		if err != nil {
+			return fmt.Errorf("publishing message %d failed after loading %s messages: %w", dm.Sequence, f(count), err)
+		}
+
+		if duplicate {
+			duplicates++
+			return nil
+		}
+
+		count++
+		size += uint64(len(data))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Published %s messages with %s of data into %s\n", f(count), humanize.IBytes(size), c.stream)
+	if duplicates > 0 {
+		log.Printf("WARNING: %s messages were discarded by %s as duplicates", f(duplicates), c.stream)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadStreamDump(t *testing.T) {
+	dir := t.TempDir()
+
+	archive := `{"stream":"ORDERS","seq":1,"subject":"orders.1","time":"2025-01-02T09:00:00Z","encoding":"string","data":"world"}
+
+{"stream":"ORDERS","seq":2,"subject":"orders.2","time":"2025-01-02T10:00:00Z","encoding":"base64","data":"aGVsbG8="}
+`
+	err := os.WriteFile(filepath.Join(dir, "ORDERS.jsonl"), []byte(archive), 0600)
+	if err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	var msgs []*streamDumpMsg
+	err = readStreamDump(dir, func(msg *streamDumpMsg) error {
+		msgs = append(msgs, msg)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	if len(msgs) != 2 || msgs[0].Sequence != 1 || msgs[1].Sequence != 2 {
+		t.Fatalf("expected 2 messages in the order written: %#v", msgs)
+	}
+
+	data, err := msgs[1].data()
+	if err != nil {
+		t.Fatalf("data failed: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("expected hello got %q", data)
+	}
+}
@@ -0,0 +1,446 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/dustin/go-humanize"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// streamExportVersion is the version of the archive format written by stream export
+	streamExportVersion = 1
+
+	streamExportHeader     = "header"
+	streamExportMsg        = "msg"
+	streamExportCheckpoint = "checkpoint"
+	streamExportEnd        = "end"
+)
+
+// streamExportRecord is a single line in a stream export archive.
+//
+// Archives start with a header record holding the format version and Stream name followed by msg records
+// holding the subject, headers, time and base64 encoded payload of every message. Checkpoint records are
+// written periodically recording the last sequence that was fully written, an end record is written once
+// all messages up to the last sequence the Stream held when the export started were exported. Interrupted
+// exports are resumed from the last checkpoint or end record.
+type streamExportRecord struct {
+	Type     string              `json:"type"`
+	Version  int                 `json:"version,omitempty"`
+	Stream   string              `json:"stream,omitempty"`
+	Filter   string              `json:"filter,omitempty"`
+	Sequence uint64              `json:"seq,omitempty"`
+	Subject  string              `json:"subject,omitempty"`
+	Time     time.Time           `json:"time"`
+	Headers  map[string][]string `json:"headers,omitempty"`
+	Data     string              `json:"data,omitempty"`
+	Count    uint64              `json:"count,omitempty"`
+	Bytes    uint64              `json:"bytes,omitempty"`
+}
+
+// streamExportState is the position of a partially written archive
+type streamExportState struct {
+	header *streamExportRecord
+	// offset is the size of the archive up to and including the last checkpoint or end record
+	offset int64
+	seq    uint64
+	count  uint64
+	bytes  uint64
+}
+
+func configureStreamExportCommand(str *fisk.CmdClause, c *streamCmd) {
+	export := str.Command("export", "Exports Stream messages to a portable line based archive").Action(c.exportAction)
+	export.HelpLong(`Writes messages to a versioned archive holding one JSON document per line,
+a header identifying the Stream followed by the subject, headers, time and
+base64 encoded payload of every message. Checkpoints are written periodically
+so that interrupted exports can be continued using --resume, resuming a
+complete archive appends messages added to the Stream since.
+
+   nats stream export ORDERS orders.ndjson
+   nats stream export ORDERS orders.ndjson --resume
+
+Archives are imported into a Stream using 'nats stream import'.`)
+	export.Arg("stream", "Stream to export").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	export.Arg("file", "File to write the archive to").Required().StringVar(&c.dumpTarget)
+	export.Flag("subject", "Only export messages matching a subject").PlaceHolder("SUBJECT").StringVar(&c.filterSubject)
+	export.Flag("resume", "Continue an existing archive from its last checkpoint").UnNegatableBoolVar(&c.exportResume)
+	export.Flag("checkpoint", "Number of messages between checkpoints").Default("1000").Uint64Var(&c.exportCheckpoint)
+
+	imp := str.Command("import", "Publishes messages from an archive written by 'nats stream export' into a Stream").Action(c.importAction)
+	imp.Arg("stream", "Stream to publish the messages into").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	imp.Arg("file", "Archive to import").Required().ExistingFileVar(&c.dumpTarget)
+	imp.Flag("force", "Import without prompting").Short('f').UnNegatableBoolVar(&c.force)
+}
+
+// newStreamExportMsg creates the archive record for msg
+func newStreamExportMsg(msg jetstream.Msg) (*streamExportRecord, error) {
+	meta, err := msg.Metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &streamExportRecord{
+		Type:     streamExportMsg,
+		Sequence: meta.Sequence.Stream,
+		Subject:  msg.Subject(),
+		Time:     meta.Timestamp.UTC(),
+		Data:     base64.StdEncoding.EncodeToString(msg.Data()),
+	}
+
+	if len(msg.Headers()) > 0 {
+		rec.Headers = msg.Headers()
+	}
+
+	return rec, nil
+}
+
+// readStreamExport reads an archive calling cb for every record after the header, it returns the position of the last checkpoint or end record
+func readStreamExport(r io.Reader, cb func(rec *streamExportRecord) error) (*streamExportState, error) {
+	reader := bufio.NewReaderSize(r, 1024*1024)
+	state := &streamExportState{}
+
+	var pos int64
+	line := 0
+	for {
+		b, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(b) == 0 {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		line++
+		pos += int64(len(b))
+
+		if err == io.EOF {
+			// an unterminated line is the result of an interrupted write
+			break
+		}
+
+		if strings.TrimSpace(string(b)) == "" {
+			continue
+		}
+
+		var rec streamExportRecord
+		err = json.Unmarshal(b, &rec)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse line %d: %w", line, err)
+		}
+
+		if state.header == nil {
+			if rec.Type != streamExportHeader {
+				return nil, fmt.Errorf("not a stream export archive, line 1 is not a header")
+			}
+			if rec.Version != streamExportVersion {
+				return nil, fmt.Errorf("unsupported archive version %d", rec.Version)
+			}
+
+			state.header = &rec
+			state.offset = pos
+			continue
+		}
+
+		switch rec.Type {
+		case streamExportCheckpoint, streamExportEnd:
+			state.offset = pos
+			state.seq = rec.Sequence
+			state.count = rec.Count
+			state.bytes = rec.Bytes
+		case streamExportMsg:
+		default:
+			return nil, fmt.Errorf("unknown record type %q on line %d", rec.Type, line)
+		}
+
+		if cb != nil {
+			err = cb(&rec)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if state.header == nil {
+		return nil, fmt.Errorf("not a stream export archive, no header found")
+	}
+
+	return state, nil
+}
+
+// prepareExportFile opens the archive for writing, when resuming the existing archive is truncated after its last checkpoint
+func (c *streamCmd) prepareExportFile() (*os.File, *streamExportState, error) {
+	if !c.exportResume {
+		fh, err := os.OpenFile(c.dumpTarget, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			return nil, nil, fmt.Errorf("%s already exists, use --resume to continue it", c.dumpTarget)
+		}
+
+		return fh, nil, err
+	}
+
+	fh, err := os.OpenFile(c.dumpTarget, os.O_RDWR, 0600)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state, err := readStreamExport(fh, nil)
+	if err != nil {
+		fh.Close()
+		return nil, nil, err
+	}
+
+	if state.header.Stream != c.stream {
+		fh.Close()
+		return nil, nil, fmt.Errorf("%s is an export of Stream %s", c.dumpTarget, state.header.Stream)
+	}
+	if state.header.Filter != c.filterSubject {
+		fh.Close()
+		return nil, nil, fmt.Errorf("%s was exported using subject filter %q", c.dumpTarget, state.header.Filter)
+	}
+
+	err = fh.Truncate(state.offset)
+	if err == nil {
+		_, err = fh.Seek(state.offset, io.SeekStart)
+	}
+	if err != nil {
+		fh.Close()
+		return nil, nil, err
+	}
+
+	return fh, state, nil
+}
+
+func (c *streamCmd) exportAction(_ *fisk.ParseContext) error {
+	if c.exportCheckpoint == 0 {
+		return fmt.Errorf("--checkpoint must be greater than 0")
+	}
+
+	nc, js, err := prepareJSHelper()
+	if err != nil {
+		return err
+	}
+	c.nc = nc
+
+	fh, state, err := c.prepareExportFile()
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	out := bufio.NewWriter(fh)
+	defer out.Flush()
+
+	write := func(rec *streamExportRecord) error {
+		j, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(j))
+		return err
+	}
+
+	if state == nil {
+		state = &streamExportState{}
+		err = write(&streamExportRecord{Type: streamExportHeader, Version: streamExportVersion, Stream: c.stream, Filter: c.filterSubject, Time: time.Now().UTC()})
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("Resuming export of %s after sequence %d with %s messages already exported\n", c.stream, state.seq, f(state.count))
+	}
+
+	checkpoint := func(kind string) error {
+		err := write(&streamExportRecord{Type: kind, Sequence: state.seq, Count: state.count, Bytes: state.bytes, Time: time.Now().UTC()})
+		if err != nil {
+			return err
+		}
+
+		return out.Flush()
+	}
+
+	cfg := jetstream.OrderedConsumerConfig{}
+	if c.filterSubject != "" {
+		cfg.FilterSubjects = []string{c.filterSubject}
+	}
+	if state.seq > 0 {
+		cfg.DeliverPolicy = jetstream.DeliverByStartSequencePolicy
+		cfg.OptStartSeq = state.seq + 1
+	}
+
+	str, err := js.Stream(ctx, c.stream)
+	if err != nil {
+		return err
+	}
+
+	// the archive is only complete once it reached the last message the stream held when the export started
+	last := str.CachedInfo().State.LastSeq
+
+	var exported, sinceCheckpoint uint64
+
+	if last > state.seq {
+		err = walkStream(ctx, js, c.stream, cfg, last, func(msg jetstream.Msg, _ *jetstream.MsgMetadata) error {
+			rec, err := newStreamExportMsg(msg)
+			if err != nil {
+				return err
+			}
+
+			err = write(rec)
+			if err != nil {
+				return err
+			}
+
+			state.seq = rec.Sequence
+			state.count++
+			state.bytes += uint64(len(msg.Data()))
+			exported++
+			sinceCheckpoint++
+
+			if sinceCheckpoint >= c.exportCheckpoint {
+				err = checkpoint(streamExportCheckpoint)
+				if err != nil {
+					return err
+				}
+				sinceCheckpoint = 0
+			}
+
+			return nil
+		})
+		if err != nil {
+			// a checkpoint after the last written message lets --resume continue from there
+			cerr := checkpoint(streamExportCheckpoint)
+			if cerr != nil {
+				log.Printf("Could not write a checkpoint: %v", cerr)
+			}
+
+			return err
+		}
+	}
+
+	// messages after the last one matching the filter were considered so resuming continues from last
+	state.seq = max(state.seq, last)
+
+	err = checkpoint(streamExportEnd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %s messages from %s to %s, the archive holds %s messages with %s of data\n", f(exported), c.stream, c.dumpTarget, f(state.count), humanize.IBytes(state.bytes))
+
+	return nil
+}
+
+func (c *streamCmd) importAction(_ *fisk.ParseContext) error {
+	fh, err := os.Open(c.dumpTarget)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	// the archive is read twice, first to validate it and count the messages
+	var total uint64
+	state, err := readStreamExport(fh, func(rec *streamExportRecord) error {
+		if rec.Type == streamExportMsg {
+			total++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if total == 0 {
+		fmt.Printf("No messages found in %s\n", c.dumpTarget)
+		return nil
+	}
+
+	if state.offset < fileSize(fh) {
+		log.Printf("WARNING: %s is incomplete, messages after sequence %d were not fully written", c.dumpTarget, state.seq)
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really publish %s messages exported from %s into Stream %s", f(total), state.header.Stream, c.stream), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	_, js, err := prepareJSHelper()
+	if err != nil {
+		return err
+	}
+
+	_, err = fh.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	var count, duplicates, size uint64
+	_, err = readStreamExport(fh, func(rec *streamExportRecord) error {
+		if rec.Type != streamExportMsg {
+			return nil
+		}
+
+		data, err := base64.StdEncoding.DecodeString(rec.Data)
+		if err != nil {
+			return fmt.Errorf("invalid payload for message %d: %w", rec.Sequence, err)
+		}
+
+		duplicate, err := publishArchivedMsg(js, c.stream, rec.Subject, rec.Headers, data)
+		if err != nil {
+			return fmt.Errorf("publishing message %d failed after importing %s messages: %w", rec.Sequence, f(count), err)
+		}
+
+		if duplicate {
+			duplicates++
+			return nil
+		}
+
+		count++
+		size += uint64(len(data))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Published %s messages with %s of data into %s\n", f(count), humanize.IBytes(size), c.stream)
+	if duplicates > 0 {
+		log.Printf("WARNING: %s messages were discarded by %s as duplicates", f(duplicates), c.stream)
+	}
+
+	return nil
+}
+
+func fileSize(fh *os.File) int64 {
+	stat, err := fh.Stat()
+	if err != nil {
+		return 0
+	}
+
+	return stat.Size()
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadStreamExport(t *testing.T) {
+	archive := `{"type":"header","version":1,"stream":"ORDERS","time":"2025-01-01T00:00:00Z"}
+{"type":"msg","seq":1,"subject":"o.1","time":"2025-01-01T00:00:00Z","data":"MQ=="}
+{"type":"checkpoint","seq":1,"count":1,"bytes":1,"time":"2025-01-01T00:00:00Z"}
+{"type":"msg","seq":2,"subject":"o.2","time":"2025-01-01T00:00:00Z","data":"Mg=="}
+{"type":"msg","seq":3,"subj`
+
+	var msgs int
+	state, err := readStreamExport(bytes.NewBufferString(archive), func(rec *streamExportRecord) error {
+		if rec.Type == streamExportMsg {
+			msgs++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	if msgs != 2 {
+		t.Fatalf("expected 2 complete messages got %d", msgs)
+	}
+	if state.header.Stream != "ORDERS" || state.seq != 1 || state.count != 1 {
+		t.Fatalf("invalid state %+v", state)
+	}
+
+	lines := bytes.SplitAfter([]byte(archive), []byte("\n"))
+	expected := len(lines[0]) + len(lines[1]) + len(lines[2])
+	if state.offset != int64(expected) {
+		t.Fatalf("expected offset %d got %d", expected, state.offset)
+	}
+
+	_, err = readStreamExport(bytes.NewBufferString(`{"type":"header","version":2}`+"\n"), nil)
+	if err == nil {
+		t.Fatalf("expected unsupported version error")
+	}
+
+	_, err = readStreamExport(bytes.NewBufferString(`{"type":"msg","seq":1}`+"\n"), nil)
+	if err == nil {
+		t.Fatalf("expected missing header error")
+	}
+}
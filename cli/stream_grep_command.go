@@ -0,0 +1,252 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/fatih/color"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func configureStreamGrepCommand(str *fisk.CmdClause, c *streamCmd) {
+	grep := str.Command("grep", "Searches the payloads of messages in a Stream").Action(c.grepAction)
+	grep.HelpLong(`Scans messages in a Stream using an ephemeral ordered consumer and shows
+those with payloads matching a regular expression.
+
+Using --jsonpath the expression is matched against the values found at a path
+in JSON payloads rather than the entire payload, paths support dotted keys,
+array indexes and * wildcards.
+
+   nats stream grep ORDERS 'out of stock' --subject 'orders.>' --since 24h
+   nats stream grep ORDERS '^gold$' --jsonpath '$.customer.tier'
+   nats stream grep ORDERS 'SKU-1\d+' --jsonpath '$.items[*].sku' --max-matches 10`)
+	grep.Arg("stream", "Stream to search").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	grep.Arg("pattern", "Regular expression to match").Required().StringVar(&c.grepPattern)
+	grep.Flag("subject", "Only search messages matching a subject").PlaceHolder("SUBJECT").StringVar(&c.filterSubject)
+	grep.Flag("since", "Only search messages received since a duration like 1d3h5m2s").PlaceHolder("DURATION").DurationVar(&c.vwStartDelta)
+	grep.Flag("jsonpath", "Match values at a path in JSON payloads").PlaceHolder("PATH").StringVar(&c.grepJSONPath)
+	grep.Flag("ignore-case", "Match without regard to case").Short('i').UnNegatableBoolVar(&c.grepIgnoreCase)
+	grep.Flag("max-matches", "Stop after finding a number of matches").Default("100").IntVar(&c.grepMaxMatches)
+	grep.Flag("raw", "Show only the message data").UnNegatableBoolVar(&c.vwRaw)
+}
+
+// jsonPathValues finds the values in data at a path like $.items[*].sku, values are returned as their
+// string content or JSON encoding when they are not strings
+func jsonPathValues(data []byte, path string) ([]string, error) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	err = json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []any{doc}
+	for _, step := range steps {
+		var next []any
+		for _, node := range nodes {
+			switch n := node.(type) {
+			case map[string]any:
+				if step == "*" {
+					for _, v := range n {
+						next = append(next, v)
+					}
+				} else if v, ok := n[step]; ok {
+					next = append(next, v)
+				}
+
+			case []any:
+				if step == "*" {
+					next = append(next, n...)
+				} else if idx, err := strconv.Atoi(step); err == nil && idx >= 0 && idx < len(n) {
+					next = append(next, n[idx])
+				}
+			}
+		}
+		nodes = next
+	}
+
+	var res []string
+	for _, node := range nodes {
+		if s, ok := node.(string); ok {
+			res = append(res, s)
+			continue
+		}
+
+		j, err := json.Marshal(node)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, string(j))
+	}
+
+	return res, nil
+}
+
+// parseJSONPath splits a path like $.items[0].sku into its keys and indexes
+func parseJSONPath(path string) ([]string, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+
+	var steps []string
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path: empty key")
+			}
+			steps = append(steps, path[:end])
+			path = path[end:]
+
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid path: unterminated [")
+			}
+			step := strings.Trim(path[1:end], `'"`)
+			if step == "" {
+				return nil, fmt.Errorf("invalid path: empty index")
+			}
+			steps = append(steps, step)
+			path = path[end+1:]
+
+		default:
+			return nil, fmt.Errorf("invalid path: expected . or [ at %q", path)
+		}
+	}
+
+	return steps, nil
+}
+
+// grepMatch determines if data matches re, directly or at path when set
+func grepMatch(re *regexp.Regexp, path string, data []byte) bool {
+	if path == "" {
+		return re.Match(data)
+	}
+
+	vals, err := jsonPathValues(data, path)
+	if err != nil {
+		return false
+	}
+
+	for _, v := range vals {
+		if re.MatchString(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *streamCmd) grepAction(_ *fisk.ParseContext) error {
+	if c.grepMaxMatches <= 0 {
+		return fmt.Errorf("--max-matches must be greater than 0")
+	}
+
+	pattern := c.grepPattern
+	if c.grepIgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	if c.grepJSONPath != "" {
+		_, err = parseJSONPath(c.grepJSONPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, js, err := prepareJSHelper()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg := jetstream.OrderedConsumerConfig{}
+	if c.filterSubject != "" {
+		cfg.FilterSubjects = []string{c.filterSubject}
+	}
+	if c.vwStartDelta > 0 {
+		start := time.Now().Add(-c.vwStartDelta)
+		cfg.DeliverPolicy = jetstream.DeliverByStartTimePolicy
+		cfg.OptStartTime = &start
+	}
+
+	var scanned, matched int
+
+	err = walkStream(ctx, js, c.stream, cfg, 0, func(msg jetstream.Msg, meta *jetstream.MsgMetadata) error {
+		scanned++
+
+		if !grepMatch(re, c.grepJSONPath, msg.Data()) {
+			return nil
+		}
+
+		matched++
+		c.renderGrepMatch(msg, meta)
+
+		if matched >= c.grepMaxMatches {
+			return errStopStreamWalk
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	if !c.vwRaw {
+		fmt.Printf("Found %s matches in %s messages\n", f(matched), f(scanned))
+	}
+
+	return nil
+}
+
+func (c *streamCmd) renderGrepMatch(msg jetstream.Msg, meta *jetstream.MsgMetadata) {
+	if c.vwRaw {
+		fmt.Println(string(msg.Data()))
+		return
+	}
+
+	if meta != nil {
+		fmt.Printf("[%s] %s %s\n", color.CyanString("%d", meta.Sequence.Stream), color.GreenString(msg.Subject()), f(meta.Timestamp.Local()))
+	} else {
+		fmt.Printf("%s\n", color.GreenString(msg.Subject()))
+	}
+	fmt.Println(string(msg.Data()))
+	fmt.Println()
+}
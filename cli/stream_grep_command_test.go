@@ -0,0 +1,69 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJSONPathValues(t *testing.T) {
+	data := []byte(`{"customer":{"name":"bob","tier":"gold"},"items":[{"sku":"SKU-1","qty":2},{"sku":"SKU-2","qty":1}]}`)
+
+	cases := []struct {
+		path   string
+		expect []string
+	}{
+		{"$.customer.tier", []string{"gold"}},
+		{"$.items[1].sku", []string{"SKU-2"}},
+		{"$.items[*].sku", []string{"SKU-1", "SKU-2"}},
+		{"$.items[0].qty", []string{"2"}},
+		{"$['customer'].name", []string{"bob"}},
+		{"$.missing", nil},
+		{"$.items[5].sku", nil},
+	}
+
+	for _, tc := range cases {
+		vals, err := jsonPathValues(data, tc.path)
+		if err != nil {
+			t.Fatalf("%s failed: %v", tc.path, err)
+		}
+		if !cmp.Equal(vals, tc.expect) {
+			t.Fatalf("%s expected %v got %v", tc.path, tc.expect, vals)
+		}
+	}
+
+	for _, path := range []string{"$.items[0", "$..sku", "customer"} {
+		_, err := parseJSONPath(path)
+		if err == nil {
+			t.Fatalf("expected %q to fail", path)
+		}
+	}
+
+	re := regexp.MustCompile("^SKU-2$")
+	if !grepMatch(re, "$.items[*].sku", data) {
+		t.Fatalf("expected jsonpath match")
+	}
+	if grepMatch(re, "$.customer.tier", data) {
+		t.Fatalf("expected no jsonpath match")
+	}
+	if !grepMatch(regexp.MustCompile("gold"), "", data) {
+		t.Fatalf("expected payload match")
+	}
+	if grepMatch(re, "$.items[*].sku", []byte("SKU-2")) {
+		t.Fatalf("expected non JSON payload not to match")
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/jsm.go/natscontext"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// headers added to messages copied by the republish migration method
+const (
+	migratedStreamHeader   = "Nats-Migrated-Stream"
+	migratedSequenceHeader = "Nats-Migrated-Sequence"
+	migratedTimeHeader     = "Nats-Migrated-Time"
+	migratedMsgIdHeader    = "Nats-Migrated-Msg-Id"
+)
+
+func (c *streamCmd) migrateAction(_ *fisk.ParseContext) error {
+	if c.migrateContext == selectedContextName() {
+		return fmt.Errorf("the destination context must differ from the selected context")
+	}
+
+	readOnly, err := readOnlyContext(c.migrateContext)
+	if err != nil {
+		return err
+	}
+	if readOnly {
+		return fmt.Errorf("refusing to migrate to context %s in read-only mode", c.migrateContext)
+	}
+
+	c.connectAndAskStream()
+
+	stream, err := c.loadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	nfo, err := stream.Information()
+	if err != nil {
+		return err
+	}
+
+	if c.migrateMethod == "republish" && nfo.Config.Mirror != nil {
+		return fmt.Errorf("mirrors can not be migrated by republishing, use the snapshot method")
+	}
+
+	dnc, dmgr, djs, err := connectMigrateContext(c.migrateContext)
+	if err != nil {
+		return fmt.Errorf("could not connect to context %s: %w", c.migrateContext, err)
+	}
+	defer dnc.Close()
+
+	known, err := dmgr.IsKnownStream(c.stream)
+	if err != nil {
+		return err
+	}
+	if known {
+		return fmt.Errorf("stream %s already exist in context %s", c.stream, c.migrateContext)
+	}
+
+	if !c.force {
+		ok, err := askConfirmation(fmt.Sprintf("Really migrate %s messages in Stream %s to context %s using %s", f(nfo.State.Msgs), c.stream, c.migrateContext, c.migrateMethod), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	start := time.Now()
+
+	var expected *api.StreamState
+	switch c.migrateMethod {
+	case "snapshot":
+		expected, err = c.migrateBySnapshot(stream, nfo, dmgr)
+	default:
+		expected, err = c.migrateByRepublish(nfo, dmgr, djs)
+	}
+	if err != nil {
+		return err
+	}
+
+	dstream, err := dmgr.LoadStream(c.stream)
+	if err != nil {
+		return err
+	}
+	dnfo, err := dstream.Information()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	table := iu.NewTableWriter(opts(), "Migration of %s to %s", c.stream, c.migrateContext)
+	table.AddHeaders("", "Messages", "Bytes", "First Sequence", "Last Sequence")
+	table.AddRow("Source", f(expected.Msgs), fiBytes(expected.Bytes), f(expected.FirstSeq), f(expected.LastSeq))
+	table.AddRow("Destination", f(dnfo.State.Msgs), fiBytes(dnfo.State.Bytes), f(dnfo.State.FirstSeq), f(dnfo.State.LastSeq))
+	fmt.Println(table.Render())
+	fmt.Println()
+
+	if dnfo.State.Msgs != expected.Msgs {
+		return fmt.Errorf("migration verification failed, expected %s messages in the destination but found %s", f(expected.Msgs), f(dnfo.State.Msgs))
+	}
+	if expected.Msgs > 0 && dnfo.State.LastSeq != expected.LastSeq {
+		return fmt.Errorf("migration verification failed, expected last sequence %s in the destination but found %s", f(expected.LastSeq), f(dnfo.State.LastSeq))
+	}
+
+	fmt.Printf("Migrated %s messages in %v\n", f(expected.Msgs), time.Since(start).Round(time.Millisecond))
+
+	return nil
+}
+
+// migrateBySnapshot restores a snapshot of the stream in the destination, it returns the state recorded in the snapshot
+// which can differ from nfo when messages were added while it was taken
+func (c *streamCmd) migrateBySnapshot(stream *jsm.Stream, nfo *api.StreamInfo, dmgr *jsm.Manager) (*api.StreamState, error) {
+	dir, err := os.MkdirTemp("", "nats-migrate-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	err = backupStream(stream, c.showProgress, true, false, dir, 128*1024)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot failed: %w", err)
+	}
+
+	var bm api.JSApiStreamRestoreRequest
+	bmj, err := os.ReadFile(filepath.Join(dir, "backup.json"))
+	if err != nil {
+		return nil, fmt.Errorf("snapshot failed: %w", err)
+	}
+	err = json.Unmarshal(bmj, &bm)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot failed: %w", err)
+	}
+
+	fmt.Printf("Restoring Stream %s in context %s\n", c.stream, c.migrateContext)
+
+	_, _, err = dmgr.RestoreSnapshotFromDirectory(ctx, c.stream, dir, jsm.RestoreConfiguration(nfo.Config))
+	if err != nil {
+		return nil, fmt.Errorf("restore failed: %w", err)
+	}
+
+	return &bm.State, nil
+}
+
+// migrationStreamConfig is the configuration a stream is created with while messages are copied into it, sources are
+// removed so only copied messages are stored and limits that could remove copied messages or prevent deleting gap
+// placeholders are lifted
+func migrationStreamConfig(cfg api.StreamConfig) api.StreamConfig {
+	cfg.Sources = nil
+	cfg.Sealed = false
+	cfg.DenyDelete = false
+	cfg.MaxMsgs = -1
+	cfg.MaxBytes = -1
+	cfg.MaxMsgsPer = -1
+	cfg.Discard = api.DiscardOld
+	cfg.DiscardNewPer = false
+
+	// messages without interest are not stored
+	if cfg.Retention == api.InterestPolicy {
+		cfg.Retention = api.LimitsPolicy
+	}
+
+	return cfg
+}
+
+// migrateByRepublish creates the stream in the destination and copies messages up to the last sequence when the
+// migration started keeping their sequences, gaps left by deleted messages are filled with placeholders that are
+// deleted again. The stream is created using migrationStreamConfig and updated to its real configuration afterwards
+// except for sources, which would store the already copied messages again
+func (c *streamCmd) migrateByRepublish(nfo *api.StreamInfo, dmgr *jsm.Manager, djs jetstream.JetStream) (*api.StreamState, error) {
+	cfg := nfo.Config
+	cfg.FirstSeq = nfo.State.FirstSeq
+
+	dstream, err := dmgr.NewStreamFromDefault(c.stream, migrationStreamConfig(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("could not create destination Stream: %w", err)
+	}
+
+	copied := &api.StreamState{}
+
+	if nfo.State.Msgs > 0 {
+		_, js, err := prepareJSHelper()
+		if err != nil {
+			return nil, err
+		}
+
+		next := max(cfg.FirstSeq, 1)
+		lastReport := time.Now()
+
+		err = walkStream(ctx, js, c.stream, jetstream.OrderedConsumerConfig{}, nfo.State.LastSeq, func(msg jetstream.Msg, meta *jetstream.MsgMetadata) error {
+			for ; next < meta.Sequence.Stream; next++ {
+				err := c.migrateGap(dstream, djs, msg.Subject(), next)
+				if err != nil {
+					return err
+				}
+			}
+
+			out := nats.NewMsg(msg.Subject())
+			out.Data = msg.Data()
+			for k, vals := range msg.Headers() {
+				if strings.HasPrefix(k, "Nats-Expected-") || k == api.JSMsgId {
+					continue
+				}
+				for _, v := range vals {
+					out.Header.Add(k, v)
+				}
+			}
+			// streams may repeat ids outside their duplicate window, the copies are published faster than that so the id is moved
+			if id := msg.Headers().Get(api.JSMsgId); id != "" {
+				out.Header.Set(migratedMsgIdHeader, id)
+			}
+			out.Header.Set(migratedStreamHeader, meta.Stream)
+			out.Header.Set(migratedSequenceHeader, strconv.FormatUint(meta.Sequence.Stream, 10))
+			out.Header.Set(migratedTimeHeader, meta.Timestamp.UTC().Format(time.RFC3339Nano))
+
+			ack, err := djs.PublishMsg(ctx, out, jetstream.WithExpectStream(c.stream), jetstream.WithExpectLastSequence(meta.Sequence.Stream-1))
+			if err != nil {
+				return fmt.Errorf("publishing message %d failed: %w", meta.Sequence.Stream, err)
+			}
+			if ack.Duplicate {
+				return fmt.Errorf("message %d was rejected as a duplicate by the destination", meta.Sequence.Stream)
+			}
+
+			next = meta.Sequence.Stream + 1
+
+			if copied.Msgs == 0 {
+				copied.FirstSeq = meta.Sequence.Stream
+			}
+			copied.Msgs++
+			copied.Bytes += uint64(len(out.Data))
+			copied.LastSeq = meta.Sequence.Stream
+
+			if time.Since(lastReport) > 5*time.Second {
+				log.Printf("Copied %s of %s messages", f(copied.Msgs), f(nfo.State.Msgs))
+				lastReport = time.Now()
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.Sources = nil
+	err = dstream.UpdateConfiguration(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not apply the Stream configuration after copying messages: %w", err)
+	}
+
+	if len(nfo.Config.Sources) > 0 {
+		log.Printf("WARNING: the %d sources of %s were not added to the destination as it would store their messages again", len(nfo.Config.Sources), c.stream)
+	}
+
+	return copied, nil
+}
+
+// migrateGap fills sequence seq, left empty by a deleted message, with a placeholder on subject that is then deleted
+func (c *streamCmd) migrateGap(dstream *jsm.Stream, djs jetstream.JetStream, subject string, seq uint64) error {
+	gap := nats.NewMsg(subject)
+	gap.Header.Set(migratedStreamHeader, c.stream)
+
+	_, err := djs.PublishMsg(ctx, gap, jetstream.WithExpectStream(c.stream), jetstream.WithExpectLastSequence(seq-1))
+	if err != nil {
+		return fmt.Errorf("filling deleted message %d failed: %w", seq, err)
+	}
+
+	err = dstream.DeleteMessage(seq)
+	if err != nil {
+		return fmt.Errorf("removing placeholder for deleted message %d failed: %w", seq, err)
+	}
+
+	return nil
+}
+
+func connectMigrateContext(name string) (*nats.Conn, *jsm.Manager, jetstream.JetStream, error) {
+	nctx, err := natscontext.New(name, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	copts, err := contextNatsOpts(name, nctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	nc, err := nats.Connect(nctx.ServerURL(), copts...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	mgr, err := jsm.New(nc, jsm.WithAPIPrefix(nctx.JSAPIPrefix()), jsm.WithDomain(nctx.JSDomain()), jsm.WithTimeout(opts().Timeout))
+	if err != nil {
+		nc.Close()
+		return nil, nil, nil, err
+	}
+
+	var js jetstream.JetStream
+	switch {
+	case nctx.JSDomain() != "":
+		js, err = jetstream.NewWithDomain(nc, nctx.JSDomain())
+	case nctx.JSAPIPrefix() != "":
+		js, err = jetstream.NewWithAPIPrefix(nc, nctx.JSAPIPrefix())
+	default:
+		js, err = jetstream.New(nc)
+	}
+	if err != nil {
+		nc.Close()
+		return nil, nil, nil, err
+	}
+
+	return nc, mgr, js, nil
+}
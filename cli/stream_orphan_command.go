@@ -0,0 +1,247 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/choria-io/fisk"
+	"github.com/dustin/go-humanize"
+	"github.com/nats-io/nats-server/v2/server"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+const (
+	jsSystemAccountDir = "$SYS"
+	jsRaftGroupsDir    = "_js_"
+	jsMetaGroupDir     = "_meta_"
+)
+
+type orphanedData struct {
+	kind    string
+	account string
+	name    string
+	path    string
+	size    int64
+}
+
+// jsKnownAssets holds the assets a server reports hosting, keyed by account
+type jsKnownAssets struct {
+	streams    map[string]map[string]bool
+	consumers  map[string]map[string]map[string]bool
+	raftGroups map[string]bool
+}
+
+func (c *streamCmd) orphanDataAction(_ *fisk.ParseContext) error {
+	storeDir := c.orphanStoreDir
+	if filepath.Base(storeDir) != "jetstream" && iu.IsDirectory(filepath.Join(storeDir, "jetstream")) {
+		storeDir = filepath.Join(storeDir, "jetstream")
+	}
+
+	if !iu.IsDirectory(storeDir) {
+		return fmt.Errorf("store directory %s does not exist", storeDir)
+	}
+
+	nc, _, err := prepareHelper("", natsOpts()...)
+	if err != nil {
+		return err
+	}
+
+	jszOpts := server.JSzOptions{
+		Accounts:   true,
+		Streams:    true,
+		Consumer:   true,
+		RaftGroups: true,
+	}
+
+	res, err := doJszReq(jszOpts, server.EventFilterOptions{Name: c.orphanServer}, 0, nc)
+	if err != nil {
+		return err
+	}
+
+	var jsz *server.JSInfo
+	for _, resp := range res {
+		if resp.Server != nil && resp.Server.Name == c.orphanServer {
+			if resp.Error != nil {
+				return fmt.Errorf("could not retrieve JetStream information from server %s: %s", c.orphanServer, resp.Error.Description)
+			}
+
+			jsz = resp.Data
+			break
+		}
+	}
+
+	if jsz == nil {
+		return fmt.Errorf("did not receive JetStream information from server %s, ensure the system account is used", c.orphanServer)
+	}
+
+	// classifying data without the full list of streams would report live data as orphaned
+	if jszTruncated(jsz) {
+		return fmt.Errorf("JetStream information from server %s does not list all its streams, refusing to classify data", c.orphanServer)
+	}
+
+	orphans, err := findOrphanedData(storeDir, knownJetStreamAssets(*jsz))
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		fmt.Printf("No orphaned data found in %s\n", storeDir)
+		return nil
+	}
+
+	var total int64
+	table := iu.NewTableWriter(opts(), "Orphaned data on %s", c.orphanServer)
+	table.AddHeaders("Type", "Account", "Name", "Size", "Path")
+	for _, o := range orphans {
+		total += o.size
+		table.AddRow(o.kind, o.account, o.name, humanize.IBytes(uint64(o.size)), o.path)
+	}
+	table.AddFooter("", "", "", humanize.IBytes(uint64(total)), "")
+	fmt.Println(table.Render())
+
+	fmt.Println()
+	fmt.Println("These directories are not known to the server and can be removed once the server is shut down.")
+
+	return nil
+}
+
+func knownJetStreamAssets(nfo server.JSInfo) *jsKnownAssets {
+	known := &jsKnownAssets{
+		streams:    make(map[string]map[string]bool),
+		consumers:  make(map[string]map[string]map[string]bool),
+		raftGroups: map[string]bool{jsMetaGroupDir: true},
+	}
+
+	for _, acc := range nfo.AccountDetails {
+		known.streams[acc.Name] = make(map[string]bool)
+		known.consumers[acc.Name] = make(map[string]map[string]bool)
+
+		for _, stream := range acc.Streams {
+			known.streams[acc.Name][stream.Name] = true
+			known.consumers[acc.Name][stream.Name] = make(map[string]bool)
+
+			if stream.RaftGroup != "" {
+				known.raftGroups[stream.RaftGroup] = true
+			}
+
+			for _, group := range stream.ConsumerRaftGroups {
+				known.raftGroups[group.RaftGroup] = true
+			}
+
+			for _, consumer := range stream.Consumer {
+				known.consumers[acc.Name][stream.Name][consumer.Name] = true
+			}
+		}
+	}
+
+	return known
+}
+
+// findOrphanedData walks a JetStream store directory finding streams, consumers and RAFT groups not known to the server
+func findOrphanedData(storeDir string, known *jsKnownAssets) ([]*orphanedData, error) {
+	var orphans []*orphanedData
+
+	add := func(kind string, account string, name string, path string) {
+		orphans = append(orphans, &orphanedData{kind: kind, account: account, name: name, path: path, size: dirSize(path)})
+	}
+
+	accounts, err := os.ReadDir(storeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acct := range accounts {
+		if !acct.IsDir() {
+			continue
+		}
+
+		if acct.Name() == jsSystemAccountDir {
+			groups, err := os.ReadDir(filepath.Join(storeDir, acct.Name(), jsRaftGroupsDir))
+			if err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+
+			for _, group := range groups {
+				if group.IsDir() && !known.raftGroups[group.Name()] {
+					add("RAFT Group", acct.Name(), group.Name(), filepath.Join(storeDir, acct.Name(), jsRaftGroupsDir, group.Name()))
+				}
+			}
+		}
+
+		streamsDir := filepath.Join(storeDir, acct.Name(), "streams")
+		streams, err := os.ReadDir(streamsDir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, stream := range streams {
+			if !stream.IsDir() {
+				continue
+			}
+
+			if !known.streams[acct.Name()][stream.Name()] {
+				add("Stream", acct.Name(), stream.Name(), filepath.Join(streamsDir, stream.Name()))
+				continue
+			}
+
+			obsDir := filepath.Join(streamsDir, stream.Name(), "obs")
+			consumers, err := os.ReadDir(obsDir)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			for _, consumer := range consumers {
+				if consumer.IsDir() && !known.consumers[acct.Name()][stream.Name()][consumer.Name()] {
+					add("Consumer", acct.Name(), fmt.Sprintf("%s > %s", stream.Name(), consumer.Name()), filepath.Join(obsDir, consumer.Name()))
+				}
+			}
+		}
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].path < orphans[j].path
+	})
+
+	return orphans, nil
+}
+
+func dirSize(path string) int64 {
+	var size int64
+
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+
+		nfo, err := d.Info()
+		if err == nil {
+			size += nfo.Size()
+		}
+
+		return nil
+	})
+
+	return size
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats-server/v2/server"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// placementMissingTags lists the tags required by placement that a server with tags does not have
+func placementMissingTags(placement *api.Placement, tags []string) []string {
+	if placement == nil {
+		return nil
+	}
+
+	var missing []string
+	for _, tag := range placement.Tags {
+		if !slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			missing = append(missing, tag)
+		}
+	}
+
+	return missing
+}
+
+// showEffectivePlacement shows the servers hosting a stream with their cluster and tags compared to the
+// requested placement, this requires system account access
+func (c *streamCmd) showEffectivePlacement(w io.Writer, info *api.StreamInfo) error {
+	if info.Cluster == nil || info.Cluster.Leader == "" {
+		fmt.Fprintln(w, "Stream is not clustered, effective placement is not known")
+		return nil
+	}
+
+	res, err := doReq(nil, "$SYS.REQ.SERVER.PING", 0, c.nc)
+	if err != nil {
+		return err
+	}
+
+	servers := map[string]server.ServerInfo{}
+	for _, r := range res {
+		var resp struct {
+			Server server.ServerInfo `json:"server"`
+		}
+		err = json.Unmarshal(r, &resp)
+		if err != nil {
+			return err
+		}
+
+		servers[resp.Server.Name] = resp.Server
+	}
+
+	if len(servers) == 0 {
+		return fmt.Errorf("no server information received, ensure the system account is used")
+	}
+
+	peers := clusterPeerNames(info.Cluster)
+
+	table := iu.NewTableWriter(opts(), fmt.Sprintf("Effective placement of Stream %s", info.Config.Name))
+	table.AddHeaders("Server", "Role", "Cluster", "Tags", "Missing Tags")
+	for i, peer := range peers {
+		role := "Replica"
+		if i == 0 {
+			role = "Leader"
+		}
+
+		srv, ok := servers[peer]
+		if !ok {
+			table.AddRow(peer, role, "unknown", "unknown", "")
+			continue
+		}
+
+		table.AddRow(peer, role, srv.Cluster, strings.Join(srv.Tags, ", "), strings.Join(placementMissingTags(info.Config.Placement, srv.Tags), ", "))
+	}
+	fmt.Fprintln(w, table.Render())
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nats-io/jsm.go/api"
+)
+
+func TestPlacementMissingTags(t *testing.T) {
+	if placementMissingTags(nil, []string{"az:1"}) != nil {
+		t.Fatalf("expected no missing tags without placement")
+	}
+
+	placement := &api.Placement{Cluster: "east", Tags: []string{"az:1", "ssd"}}
+
+	missing := placementMissingTags(placement, []string{"AZ:1", "ssd", "extra"})
+	if len(missing) != 0 {
+		t.Fatalf("expected no missing tags: %v", missing)
+	}
+
+	missing = placementMissingTags(placement, []string{"az:2"})
+	if !cmp.Equal(missing, []string{"az:1", "ssd"}) {
+		t.Fatalf("invalid missing tags: %v", missing)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/dustin/go-humanize"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+type streamPublisher struct {
+	Name    string  `json:"name"`
+	User    string  `json:"user,omitempty"`
+	Account string  `json:"account"`
+	Server  string  `json:"server"`
+	CID     uint64  `json:"cid"`
+	Msgs    int64   `json:"msgs"`
+	Bytes   int64   `json:"bytes"`
+	Rate    float64 `json:"rate"`
+}
+
+type streamHeaderPublisher struct {
+	Value string `json:"value"`
+	Msgs  uint64 `json:"msgs"`
+	Bytes uint64 `json:"bytes"`
+}
+
+type streamPublishersReport struct {
+	Stream      string                   `json:"stream"`
+	Duration    time.Duration            `json:"duration"`
+	StreamMsgs  uint64                   `json:"stream_msgs"`
+	StreamBytes uint64                   `json:"stream_bytes"`
+	Connections []*streamPublisher       `json:"connections"`
+	Header      string                   `json:"header,omitempty"`
+	HeaderValue []*streamHeaderPublisher `json:"header_values,omitempty"`
+}
+
+func connzSnapshot(rc *SrvReportCmd, nc *nats.Conn) (map[string]connInfo, error) {
+	connz, err := rc.getConnz(0, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	conns := make(map[string]connInfo)
+	for _, conn := range connz.flatConnInfo() {
+		conns[fmt.Sprintf("%s:%d", conn.Info.ID, conn.Cid)] = conn
+	}
+
+	return conns, nil
+}
+
+func (c *streamCmd) publishersAction(_ *fisk.ParseContext) error {
+	c.connectAndAskStream()
+
+	stream, err := c.loadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	rc := &SrvReportCmd{account: c.publishersAccount, json: true}
+
+	before, err := stream.Information()
+	if err != nil {
+		return err
+	}
+
+	startConns, err := connzSnapshot(rc, c.nc)
+	if err != nil {
+		return fmt.Errorf("could not gather connections, ensure the account used has system privileges: %w", err)
+	}
+
+	if !c.json {
+		fmt.Printf("Sampling publishers into %s for %v\n\n", c.stream, c.publishersDuration)
+	}
+
+	start := time.Now()
+	select {
+	case <-time.After(c.publishersDuration):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	after, err := stream.Information()
+	if err != nil {
+		return err
+	}
+
+	endConns, err := connzSnapshot(rc, c.nc)
+	if err != nil {
+		return err
+	}
+	elapsed := time.Since(start)
+
+	report := &streamPublishersReport{
+		Stream:     c.stream,
+		Duration:   elapsed,
+		StreamMsgs: after.State.LastSeq - before.State.LastSeq,
+	}
+	if after.State.Bytes > before.State.Bytes {
+		report.StreamBytes = after.State.Bytes - before.State.Bytes
+	}
+
+	for key, end := range endConns {
+		begin, ok := startConns[key]
+		if !ok {
+			// new connections are counted from zero
+			begin = connInfo{ConnInfo: &server.ConnInfo{}}
+		}
+
+		msgs := end.InMsgs - begin.InMsgs
+		if msgs <= 0 {
+			continue
+		}
+
+		report.Connections = append(report.Connections, &streamPublisher{
+			Name:    end.Name,
+			User:    end.AuthorizedUser,
+			Account: end.Account,
+			Server:  end.Info.Name,
+			CID:     end.Cid,
+			Msgs:    msgs,
+			Bytes:   end.InBytes - begin.InBytes,
+			Rate:    float64(msgs) / elapsed.Seconds(),
+		})
+	}
+
+	sort.Slice(report.Connections, func(i, j int) bool {
+		return report.Connections[i].Msgs > report.Connections[j].Msgs
+	})
+
+	if c.publishersTop > 0 && len(report.Connections) > c.publishersTop {
+		report.Connections = report.Connections[:c.publishersTop]
+	}
+
+	if c.publishersHeader != "" && report.StreamMsgs > 0 {
+		report.Header = c.publishersHeader
+		report.HeaderValue, err = c.publishersByHeader(before.State.LastSeq+1, after.State.LastSeq)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.json {
+		return iu.PrintJSON(report)
+	}
+
+	c.renderPublishersReport(report)
+
+	return nil
+}
+
+// publishersByHeader groups messages stored between first and last by the value of the publishers header
+func (c *streamCmd) publishersByHeader(first uint64, last uint64) ([]*streamHeaderPublisher, error) {
+	_, js, err := prepareJSHelper()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]*streamHeaderPublisher)
+	cfg := jetstream.OrderedConsumerConfig{
+		DeliverPolicy: jetstream.DeliverByStartSequencePolicy,
+		OptStartSeq:   first,
+	}
+
+	err = walkStream(ctx, js, c.stream, cfg, last, func(msg jetstream.Msg, _ *jetstream.MsgMetadata) error {
+		value := msg.Headers().Get(c.publishersHeader)
+		if value == "" {
+			value = "unknown"
+		}
+
+		v, ok := values[value]
+		if !ok {
+			v = &streamHeaderPublisher{Value: value}
+			values[value] = v
+		}
+		v.Msgs++
+		v.Bytes += uint64(len(msg.Data()))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*streamHeaderPublisher
+	for _, v := range values {
+		result = append(result, v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Msgs > result[j].Msgs
+	})
+
+	return result, nil
+}
+
+func (c *streamCmd) renderPublishersReport(report *streamPublishersReport) {
+	rate := float64(report.StreamMsgs) / report.Duration.Seconds()
+	fmt.Printf("Stream %s stored %s messages (%s/s) with %s of data in %v\n\n", report.Stream, f(report.StreamMsgs), f(rate), humanize.IBytes(report.StreamBytes), report.Duration.Round(time.Millisecond))
+
+	if len(report.Connections) == 0 {
+		fmt.Println("No connections published messages during the sample period")
+	} else {
+		table := iu.NewTableWriter(opts(), "Connections publishing during the sample period")
+		table.AddHeaders("Name", "User", "Account", "Server", "CID", "Messages", "Rate", "Bytes", "Est. Stream Share")
+
+		for _, p := range report.Connections {
+			share := "unknown"
+			if report.StreamMsgs > 0 {
+				share = fmt.Sprintf("%.0f%%", min(float64(p.Msgs)/float64(report.StreamMsgs)*100, 100))
+			}
+
+			table.AddRow(p.Name, p.User, p.Account, p.Server, p.CID, f(p.Msgs), fmt.Sprintf("%s/s", f(p.Rate)), humanize.IBytes(uint64(p.Bytes)), share)
+		}
+
+		fmt.Println(table.Render())
+		fmt.Println()
+		fmt.Println("Connection counts include all messages published by each connection, to any subject. The")
+		fmt.Println("estimated Stream share is the messages a connection published divided by the messages the")
+		fmt.Println("Stream stored while sampling, capped at 100%. Use --header with a header set by publishers")
+		fmt.Println("for exact attribution.")
+	}
+
+	if len(report.HeaderValue) > 0 {
+		fmt.Println()
+		table := iu.NewTableWriter(opts(), "Messages stored by %s header", report.Header)
+		table.AddHeaders(report.Header, "Messages", "Bytes", "Stream Share")
+		for _, v := range report.HeaderValue {
+			table.AddRow(v.Value, f(v.Msgs), humanize.IBytes(v.Bytes), fmt.Sprintf("%.0f%%", float64(v.Msgs)/float64(report.StreamMsgs)*100))
+		}
+		fmt.Println(table.Render())
+	}
+}
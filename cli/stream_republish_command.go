@@ -0,0 +1,221 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/dustin/go-humanize"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+func configureStreamRepublishCommand(str *fisk.CmdClause, c *streamCmd) {
+	republish := str.Command("republish-range", "Republishes a range of Stream messages to another subject").Alias("republish").Action(c.republishAction)
+	republish.HelpLong(`Reads messages in a sequence range or time window from a Stream and publishes
+them to a new subject, either a fixed subject or the original subject with a
+prefix, so they can be reprocessed or stored in another Stream.
+
+Headers are preserved and the Nats-Stream, Nats-Subject, Nats-Sequence and
+Nats-Time-Stamp headers are set to identify the original message. Message IDs
+and expectations are not republished as they would cause messages to be
+discarded or rejected.
+
+Messages are published using core NATS unless --target-stream is given, in
+which case JetStream acknowledgements are required from that Stream. Subjects
+the source Stream captures are refused unless --force is given, only messages
+present when the command starts are republished.
+
+   nats stream republish-range ORDERS --start-seq 1000 --end-seq 2000 --destination retry.orders
+   nats stream republish-range ORDERS --since 2h --until 1h --prefix replay --target-stream REPLAY`)
+	republish.Arg("stream", "Stream to read messages from").HintAction(completeStreamNames).Required().StringVar(&c.stream)
+	republish.Flag("start-seq", "First sequence to republish").PlaceHolder("SEQUENCE").Uint64Var(&c.republishStartSeq)
+	republish.Flag("end-seq", "Last sequence to republish").PlaceHolder("SEQUENCE").Uint64Var(&c.republishEndSeq)
+	republish.Flag("since", "Republish messages received since a duration like 1d3h5m2s").PlaceHolder("DURATION").DurationVar(&c.vwStartDelta)
+	republish.Flag("until", "Republish messages received until a duration ago like 1h").PlaceHolder("DURATION").DurationVar(&c.republishUntil)
+	republish.Flag("subject", "Only republish messages matching a subject").PlaceHolder("SUBJECT").StringVar(&c.filterSubject)
+	republish.Flag("destination", "Subject to publish all messages to").PlaceHolder("SUBJECT").StringVar(&c.republishDest)
+	republish.Flag("prefix", "Publish messages to their original subject with a prefix").PlaceHolder("PREFIX").StringVar(&c.republishPrefix)
+	republish.Flag("target-stream", "Require messages to be stored in a specific Stream").PlaceHolder("STREAM").StringVar(&c.republishTarget)
+	republish.Flag("dry-run", "Only show the messages that would be republished").UnNegatableBoolVar(&c.dryRun)
+	republish.Flag("force", "Republish without prompting, including into subjects the Stream captures").Short('f').UnNegatableBoolVar(&c.force)
+}
+
+// republishSubject determines the subject to republish a message originally published to subject
+func republishSubject(subject string, destination string, prefix string) string {
+	if destination != "" {
+		return destination
+	}
+
+	return strings.TrimSuffix(prefix, ".") + "." + subject
+}
+
+// republishHeaders copies headers from the original message and adds headers identifying it
+func republishHeaders(orig nats.Header, stream string, subject string, seq uint64, ts time.Time) nats.Header {
+	hdr := nats.Header{}
+	for k, vals := range orig {
+		// expectations were checked when first published and ids would be seen as duplicates
+		if strings.HasPrefix(k, "Nats-Expected-") || k == nats.MsgIdHdr {
+			continue
+		}
+		for _, v := range vals {
+			hdr.Add(k, v)
+		}
+	}
+
+	hdr.Set(jetstream.StreamHeader, stream)
+	hdr.Set(jetstream.SubjectHeader, subject)
+	hdr.Set(jetstream.SequenceHeader, strconv.FormatUint(seq, 10))
+	hdr.Set(jetstream.TimeStampHeaer, ts.UTC().Format(time.RFC3339Nano))
+
+	return hdr
+}
+
+func (c *streamCmd) republishAction(_ *fisk.ParseContext) error {
+	switch {
+	case c.republishDest == "" && c.republishPrefix == "":
+		return fmt.Errorf("either --destination or --prefix is required")
+	case c.republishDest != "" && c.republishPrefix != "":
+		return fmt.Errorf("--destination and --prefix are mutually exclusive")
+	case c.republishStartSeq == 0 && c.vwStartDelta == 0:
+		return fmt.Errorf("either --start-seq or --since is required")
+	case c.republishStartSeq > 0 && c.vwStartDelta > 0:
+		return fmt.Errorf("--start-seq and --since are mutually exclusive")
+	case c.republishEndSeq > 0 && c.republishEndSeq < c.republishStartSeq:
+		return fmt.Errorf("--end-seq must not be before --start-seq")
+	case c.republishUntil > 0 && c.vwStartDelta > 0 && c.republishUntil >= c.vwStartDelta:
+		return fmt.Errorf("--until must be more recent than --since")
+	}
+
+	nc, js, err := prepareJSHelper()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var until time.Time
+	if c.republishUntil > 0 {
+		until = time.Now().Add(-c.republishUntil)
+	}
+
+	cfg := jetstream.OrderedConsumerConfig{}
+	if c.filterSubject != "" {
+		cfg.FilterSubjects = []string{c.filterSubject}
+	}
+	if c.republishStartSeq > 0 {
+		cfg.DeliverPolicy = jetstream.DeliverByStartSequencePolicy
+		cfg.OptStartSeq = c.republishStartSeq
+	} else {
+		start := time.Now().Add(-c.vwStartDelta)
+		cfg.DeliverPolicy = jetstream.DeliverByStartTimePolicy
+		cfg.OptStartTime = &start
+	}
+
+	target := c.republishDest
+	if target == "" {
+		target = c.republishPrefix + ".>"
+	}
+
+	if !c.force {
+		str, err := js.Stream(ctx, c.stream)
+		if err != nil {
+			return err
+		}
+
+		subject, loop := subjectLoop(str.CachedInfo().Config.Subjects, target)
+		if loop {
+			return fmt.Errorf("destination %q overlaps with Stream subject %q and would republish messages back into %s, use --force to override", target, subject, c.stream)
+		}
+	}
+
+	if !c.force && !c.dryRun {
+		ok, err := askConfirmation(fmt.Sprintf("Really republish the selected messages from Stream %s to %s", c.stream, target), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	var popts []jetstream.PublishOpt
+	if c.republishTarget != "" {
+		popts = append(popts, jetstream.WithExpectStream(c.republishTarget))
+	}
+
+	var count, size uint64
+
+	err = walkStream(ctx, js, c.stream, cfg, c.republishEndSeq, func(msg jetstream.Msg, meta *jetstream.MsgMetadata) error {
+		if !until.IsZero() && meta.Timestamp.After(until) {
+			return errStopStreamWalk
+		}
+
+		subject := republishSubject(msg.Subject(), c.republishDest, c.republishPrefix)
+
+		if c.dryRun {
+			fmt.Printf("[%d] %s => %s\n", meta.Sequence.Stream, msg.Subject(), subject)
+			count++
+			size += uint64(len(msg.Data()))
+			return nil
+		}
+
+		out := nats.NewMsg(subject)
+		out.Data = msg.Data()
+		out.Header = republishHeaders(msg.Headers(), c.stream, msg.Subject(), meta.Sequence.Stream, meta.Timestamp)
+
+		var err error
+		if c.republishTarget == "" {
+			err = nc.PublishMsg(out)
+		} else {
+			_, err = js.PublishMsg(ctx, out, popts...)
+		}
+		if err != nil {
+			return fmt.Errorf("republishing message %d failed after republishing %s messages: %w", meta.Sequence.Stream, f(count), err)
+		}
+
+		count++
+		size += uint64(len(msg.Data()))
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	if !c.dryRun {
+		err = nc.FlushTimeout(opts().Timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.dryRun {
+		fmt.Printf("\nWould republish %s messages with %s of data from %s\n", f(count), humanize.IBytes(size), c.stream)
+	} else {
+		fmt.Printf("Republished %s messages with %s of data from %s\n", f(count), humanize.IBytes(size), c.stream)
+	}
+
+	return nil
+}
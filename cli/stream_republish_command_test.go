@@ -0,0 +1,50 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nats-io/nats.go"
+)
+
+func TestRepublishHeaders(t *testing.T) {
+	if s := republishSubject("orders.new", "retry.orders", ""); s != "retry.orders" {
+		t.Fatalf("invalid destination subject %q", s)
+	}
+	if s := republishSubject("orders.new", "", "replay."); s != "replay.orders.new" {
+		t.Fatalf("invalid prefixed subject %q", s)
+	}
+
+	orig := nats.Header{}
+	orig.Add("X-Trace", "1")
+	orig.Add("X-Trace", "2")
+	orig.Set(nats.MsgIdHdr, "abc")
+	orig.Set(nats.ExpectedLastSeqHdr, "10")
+
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	hdr := republishHeaders(orig, "ORDERS", "orders.new", 42, ts)
+
+	if !cmp.Equal(hdr.Values("X-Trace"), []string{"1", "2"}) {
+		t.Fatalf("headers not preserved: %v", hdr)
+	}
+	if hdr.Get(nats.MsgIdHdr) != "" || hdr.Get(nats.ExpectedLastSeqHdr) != "" {
+		t.Fatalf("message id or expectations were republished: %v", hdr)
+	}
+	if hdr.Get("Nats-Stream") != "ORDERS" || hdr.Get("Nats-Subject") != "orders.new" || hdr.Get("Nats-Sequence") != "42" || hdr.Get("Nats-Time-Stamp") != "2025-01-02T03:04:05Z" {
+		t.Fatalf("invalid provenance headers: %v", hdr)
+	}
+}
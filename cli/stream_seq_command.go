@@ -0,0 +1,203 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats.go"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+type streamSeqResult struct {
+	Stream   string    `json:"stream"`
+	Sequence uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	Subject  string    `json:"subject,omitempty"`
+	Found    bool      `json:"found"`
+}
+
+func (c *streamCmd) seqAction(_ *fisk.ParseContext) error {
+	c.connectAndAskStream()
+
+	stream, err := c.loadStream(c.stream)
+	if err != nil {
+		return err
+	}
+
+	var res *streamSeqResult
+
+	seq, err := strconv.ParseUint(c.seqQuery, 10, 64)
+	if err == nil {
+		res, err = c.timeForSeq(stream, seq)
+	} else {
+		var ts time.Time
+		ts, err = parseSeqTimestamp(c.seqQuery, time.Now())
+		if err != nil {
+			return err
+		}
+		res, err = c.seqForTime(stream, ts)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return iu.PrintJSON(res)
+	}
+
+	if !res.Found {
+		fmt.Printf("No message in Stream %s matches %s, the next message will have sequence %s\n", c.stream, c.seqQuery, f(res.Sequence))
+		return nil
+	}
+
+	fmt.Printf("Stream %s sequence %s was stored at %s (%s ago) on subject %s\n", c.stream, f(res.Sequence), res.Time.Format(time.RFC3339Nano), f(time.Since(res.Time)), res.Subject)
+	fmt.Println()
+	fmt.Printf("  Consumers starting here: --deliver %d\n", res.Sequence)
+	fmt.Printf("    Purge messages before: nats stream purge %s --seq %d\n", c.stream, res.Sequence)
+
+	return nil
+}
+
+// timeForSeq finds the time of seq, or the next message when seq was deleted
+func (c *streamCmd) timeForSeq(stream *jsm.Stream, seq uint64) (*streamSeqResult, error) {
+	res := &streamSeqResult{Stream: stream.Name(), Sequence: seq}
+
+	msg, err := nextStreamMsg(c.nc, stream.Name(), seq, ">")
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return res, nil
+	}
+
+	if msg.Sequence != seq {
+		log.Printf("Sequence %d is not in the Stream, showing the next message", seq)
+	}
+
+	res.Sequence = msg.Sequence
+	res.Time = msg.Time
+	res.Subject = msg.Subject
+	res.Found = true
+
+	return res, nil
+}
+
+// seqForTime performs a binary search using message get probes for the first message stored at or after ts
+func (c *streamCmd) seqForTime(stream *jsm.Stream, ts time.Time) (*streamSeqResult, error) {
+	state, err := stream.State()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &streamSeqResult{Stream: stream.Name(), Sequence: state.LastSeq + 1}
+	if state.Msgs == 0 || state.LastTime.Before(ts) {
+		return res, nil
+	}
+
+	lo, hi := state.FirstSeq, state.LastSeq
+	if !state.FirstTime.Before(ts) {
+		hi = lo
+	}
+
+	// hi always points at, or before deleted sequences preceding, a message at or after ts
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		msg, err := nextStreamMsg(c.nc, stream.Name(), mid, ">")
+		if err != nil {
+			return nil, err
+		}
+
+		if msg == nil || !msg.Time.Before(ts) {
+			hi = mid
+		} else {
+			lo = msg.Sequence + 1
+		}
+	}
+
+	msg, err := nextStreamMsg(c.nc, stream.Name(), lo, ">")
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return res, nil
+	}
+
+	res.Sequence = msg.Sequence
+	res.Time = msg.Time
+	res.Subject = msg.Subject
+	res.Found = true
+
+	return res, nil
+}
+
+// nextStreamMsg loads the message matching filter at seq or the first one after it, nil when there are none
+func nextStreamMsg(nc *nats.Conn, stream string, seq uint64, filter string) (*api.StoredMsg, error) {
+	greq := api.JSApiMsgGetRequest{Seq: seq, NextFor: filter}
+	err := validateRequest(greq)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := json.Marshal(greq)
+	if err != nil {
+		return nil, err
+	}
+
+	subj := jsm.APISubject(fmt.Sprintf(api.JSApiMsgGetT, stream), opts().JsApiPrefix, opts().JsDomain)
+	msg, err := nc.Request(subj, req, opts().Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp api.JSApiMsgGetResponse
+	err = json.Unmarshal(msg.Data, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != nil {
+		if resp.Error.NotFoundError() {
+			return nil, nil
+		}
+		return nil, resp.Error
+	}
+
+	return resp.Message, nil
+}
+
+// parseSeqTimestamp parses absolute timestamps or durations like 2h and -2h meaning that long ago
+func parseSeqTimestamp(v string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+		ts, err := time.ParseInLocation(layout, v, time.Local)
+		if err == nil {
+			return ts, nil
+		}
+	}
+
+	d, err := fisk.ParseDuration(strings.TrimPrefix(v, "-"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not parse %q as a sequence, timestamp or duration", v)
+	}
+
+	return now.Add(-d), nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSeqTimestamp(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 0, 0, 0, time.Local)
+
+	cases := []struct {
+		input  string
+		expect time.Time
+		err    bool
+	}{
+		{input: "2h", expect: now.Add(-2 * time.Hour)},
+		{input: "-2h", expect: now.Add(-2 * time.Hour)},
+		{input: "1d", expect: now.Add(-24 * time.Hour)},
+		{input: "2025-01-02 10:30:00", expect: time.Date(2025, 1, 2, 10, 30, 0, 0, time.Local)},
+		{input: "2025-01-02T10:30:00Z", expect: time.Date(2025, 1, 2, 10, 30, 0, 0, time.UTC)},
+		{input: "yesterday", err: true},
+	}
+
+	for _, tc := range cases {
+		res, err := parseSeqTimestamp(tc.input, now)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("expected an error for %q", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parse of %q failed: %v", tc.input, err)
+		}
+		if !res.Equal(tc.expect) {
+			t.Fatalf("expected %v got %v for %q", tc.expect, res, tc.input)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/choria-io/fisk"
+	"github.com/fatih/color"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+var tailSubjectColors = []color.Attribute{color.FgCyan, color.FgGreen, color.FgYellow, color.FgBlue, color.FgMagenta, color.FgHiCyan, color.FgHiGreen, color.FgHiYellow, color.FgHiBlue, color.FgHiMagenta}
+
+// tailSubjectColor picks a stable color for a subject so related messages are easy to spot
+func tailSubjectColor(subject string) *color.Color {
+	h := fnv.New32a()
+	h.Write([]byte(subject))
+
+	return color.New(tailSubjectColors[h.Sum32()%uint32(len(tailSubjectColors))])
+}
+
+func init() {
+	registerCommand("tail", 16, configureTailCommand)
+}
+
+func configureTailCommand(app commandHost) {
+	c := &streamCmd{msgID: -1, metadata: map[string]string{}}
+
+	configureStreamTailCommand(app.Command("tail", "Follows new messages in one or more Streams using ephemeral ordered consumers"), c)
+}
+
+func configureStreamTailCommand(tail *fisk.CmdClause, c *streamCmd) {
+	tail.Action(c.tailAction)
+	tail.HelpLong(`Shows messages as they are added to Streams, when tailing several Streams
+their messages are interleaved and labeled with the Stream they came from.
+
+   nats stream tail ORDERS
+   nats tail ORDERS SHIPMENTS --since 10m
+
+Merging by time holds messages for the --merge-window so messages stored in
+different Streams are shown in the order they were stored.`)
+	tail.Arg("stream", "Stream names").HintAction(completeStreamNames).StringsVar(&c.tailStreams)
+	tail.Flag("since", "Starts with messages received since a duration like 10m rather than the last message").PlaceHolder("DURATION").DurationVar(&c.vwStartDelta)
+	tail.Flag("subject", "Filter the stream using a subject").StringVar(&c.vwSubject)
+	tail.Flag("raw", "Show only the message data").UnNegatableBoolVar(&c.vwRaw)
+	tail.Flag("translate", "Translate the message data by running it through the given command before output").StringVar(&c.vwTranslate)
+	tail.Flag("decode", fmt.Sprintf("Decodes the message data before output, can be repeated to decode in order (%s)", strings.Join(payloadDecoders, ", "))).PlaceHolder("DECODER").EnumsVar(&c.vwDecoders, payloadDecoders...)
+	tail.Flag("merge-by", "How messages from multiple Streams are interleaved (time, arrival)").Default("time").EnumVar(&c.tailMergeBy, "time", "arrival")
+	tail.Flag("merge-window", "How long to hold messages when merging by time").Default("1s").DurationVar(&c.tailWindow)
+	addUntilSealedFlags(tail, &c.vwUntilSeal, &c.vwGrace)
+}
+
+// tailItem is a message received from one of the tailed streams
+type tailItem struct {
+	stream  string
+	msg     jetstream.Msg
+	stored  time.Time
+	arrived time.Time
+}
+
+// tailMerger holds messages from multiple streams for a window so they can be shown ordered by their stored time
+type tailMerger struct {
+	window time.Duration
+	items  []tailItem
+}
+
+func newTailMerger(window time.Duration) *tailMerger {
+	return &tailMerger{window: window}
+}
+
+func (m *tailMerger) add(item tailItem) {
+	idx := sort.Search(len(m.items), func(i int) bool { return m.items[i].stored.After(item.stored) })
+	m.items = slices.Insert(m.items, idx, item)
+}
+
+// ready removes and returns, in stored time order, the messages held for at least the window, a message is only
+// released once all messages stored before it are released
+func (m *tailMerger) ready(now time.Time) []tailItem {
+	n := 0
+	for n < len(m.items) && now.Sub(m.items[n].arrived) >= m.window {
+		n++
+	}
+
+	res := slices.Clone(m.items[:n])
+	m.items = m.items[n:]
+
+	return res
+}
+
+// flush removes and returns all held messages
+func (m *tailMerger) flush() []tailItem {
+	res := m.items
+	m.items = nil
+
+	return res
+}
+
+func (c *streamCmd) tailAction(_ *fisk.ParseContext) error {
+	switch len(c.tailStreams) {
+	case 0:
+		c.connectAndAskStream()
+		c.tailStreams = []string{c.stream}
+	case 1:
+		c.stream = c.tailStreams[0]
+		c.connectAndAskStream()
+	}
+
+	_, js, err := prepareJSHelper()
+	if err != nil {
+		return err
+	}
+
+	cfg := jetstream.OrderedConsumerConfig{
+		DeliverPolicy: jetstream.DeliverLastPolicy,
+	}
+	if c.vwStartDelta > 0 {
+		start := time.Now().Add(-c.vwStartDelta)
+		cfg.DeliverPolicy = jetstream.DeliverByStartTimePolicy
+		cfg.OptStartTime = &start
+	}
+	if c.vwSubject != "" {
+		cfg.FilterSubjects = []string{c.vwSubject}
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	multi := len(c.tailStreams) > 1
+	items := make(chan tailItem, 1000)
+
+	var consumers []jetstream.Consumer
+	var waiters []*endOfStream
+
+	defer func() {
+		// the ordered consumers would be removed by the server once inactive, remove them right away
+		for i, cons := range consumers {
+			info := cons.CachedInfo()
+			if info == nil {
+				continue
+			}
+
+			dctx, dcancel := context.WithTimeout(context.Background(), opts().Timeout)
+			js.DeleteConsumer(dctx, c.tailStreams[i], info.Name)
+			dcancel()
+		}
+	}()
+
+	for _, stream := range c.tailStreams {
+		cons, err := js.OrderedConsumer(ctx, stream, cfg)
+		if err != nil {
+			return fmt.Errorf("could not tail Stream %s: %w", stream, err)
+		}
+		consumers = append(consumers, cons)
+
+		var eos *endOfStream
+		if c.vwUntilSeal {
+			str, err := js.Stream(ctx, stream)
+			if err != nil {
+				return err
+			}
+
+			eos = newEndOfStream(c.vwGrace, func() (consumptionState, error) {
+				return tailConsumptionState(str, cons)
+			})
+			waiters = append(waiters, eos)
+		}
+
+		cc, err := cons.Consume(func(msg jetstream.Msg) {
+			if eos != nil {
+				eos.received()
+			}
+
+			item := tailItem{stream: stream, msg: msg, arrived: time.Now()}
+			meta, err := msg.Metadata()
+			if err == nil {
+				item.stored = meta.Timestamp
+			} else {
+				item.stored = item.arrived
+			}
+
+			select {
+			case items <- item:
+			case <-ctx.Done():
+			}
+		}, jetstream.ConsumeErrHandler(func(_ jetstream.ConsumeContext, err error) {
+			if opts().Trace {
+				log.Printf("Consume error on %s: %v", stream, err)
+			}
+		}))
+		if err != nil {
+			return err
+		}
+		defer cc.Stop()
+	}
+
+	// unblocks message handlers waiting to deliver messages before the consumers are stopped
+	defer cancel()
+
+	consumed := make(chan struct{})
+	if len(waiters) > 0 {
+		go func() {
+			for _, eos := range waiters {
+				if eos.wait(ctx) != nil {
+					return
+				}
+			}
+			close(consumed)
+		}()
+	}
+
+	var merger *tailMerger
+	var flush <-chan time.Time
+	if multi && c.tailMergeBy == "time" {
+		merger = newTailMerger(c.tailWindow)
+		ticker := time.NewTicker(max(c.tailWindow/4, 50*time.Millisecond))
+		defer ticker.Stop()
+		flush = ticker.C
+	}
+
+	stats := newSubStats()
+	render := func(item tailItem) {
+		stats.received(len(item.msg.Data()))
+		c.renderTailMsg(item.stream, item.msg, multi)
+	}
+
+	// shows the messages already received and a summary when stopping
+	finish := func() {
+		for len(items) > 0 {
+			item := <-items
+			if merger == nil {
+				render(item)
+			} else {
+				merger.add(item)
+			}
+		}
+		if merger != nil {
+			for _, item := range merger.flush() {
+				render(item)
+			}
+		}
+
+		fmt.Fprintln(os.Stderr)
+		stats.render(os.Stderr, time.Now())
+	}
+
+	if !c.vwRaw {
+		noun := "Stream"
+		if multi {
+			noun = "Streams"
+		}
+		fmt.Printf("Tailing %s %s, press ^C to stop\n\n", noun, strings.Join(c.tailStreams, ", "))
+	}
+
+	for {
+		select {
+		case item := <-items:
+			if merger == nil {
+				render(item)
+				continue
+			}
+			merger.add(item)
+
+		case now := <-flush:
+			for _, item := range merger.ready(now) {
+				render(item)
+			}
+
+		case <-ctx.Done():
+			finish()
+			return nil
+
+		case <-consumed:
+			finish()
+
+			if !c.vwRaw {
+				fmt.Printf("\nConsumed all messages from %s\n", strings.Join(c.tailStreams, ", "))
+			}
+
+			return nil
+		}
+	}
+}
+
+// tailConsumptionState is the end of stream state used by --until-sealed, ordered consumers do not acknowledge messages
+func tailConsumptionState(str jetstream.Stream, cons jetstream.Consumer) (consumptionState, error) {
+	sctx, cancel := context.WithTimeout(ctx, opts().Timeout)
+	defer cancel()
+
+	ci, err := cons.Info(sctx)
+	if err != nil {
+		return consumptionState{}, err
+	}
+
+	si, err := str.Info(sctx)
+	if err != nil {
+		return consumptionState{}, err
+	}
+
+	return consumptionState{Pending: ci.NumPending, Sealed: si.Config.Sealed}, nil
+}
+
+// renderTailMsg shows a message received from stream, labeling it with the stream name when label is set
+func (c *streamCmd) renderTailMsg(stream string, msg jetstream.Msg, label bool) {
+	data := msg.Data()
+	if len(c.vwDecoders) > 0 {
+		decoded, err := decodePayload(data, c.vwDecoders)
+		if err != nil {
+			log.Printf("Could not decode message on %s: %v", msg.Subject(), err)
+		} else {
+			data = decoded
+		}
+	}
+
+	if c.vwRaw {
+		outPutMSGBodyCompact(data, c.vwTranslate, msg.Subject(), stream)
+		return
+	}
+
+	if label {
+		fmt.Printf("%s ", tailSubjectColor(stream).Sprintf("[%s]", stream))
+	}
+
+	meta, err := msg.Metadata()
+	if err != nil {
+		fmt.Printf("%s ", tailSubjectColor(msg.Subject()).Sprint(msg.Subject()))
+	} else {
+		fmt.Printf("[%s] [#%d] %s ", meta.Timestamp.Format(time.TimeOnly), meta.Sequence.Stream, tailSubjectColor(msg.Subject()).Sprint(msg.Subject()))
+	}
+
+	outPutMSGBodyCompact(data, c.vwTranslate, msg.Subject(), stream)
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTailMerger(t *testing.T) {
+	now := time.Now()
+	m := newTailMerger(time.Second)
+
+	m.add(tailItem{stream: "B", stored: now.Add(-2 * time.Second), arrived: now})
+	m.add(tailItem{stream: "A", stored: now.Add(-3 * time.Second), arrived: now.Add(500 * time.Millisecond)})
+	m.add(tailItem{stream: "C", stored: now.Add(-time.Second), arrived: now})
+
+	if ready := m.ready(now.Add(time.Second)); len(ready) != 0 {
+		t.Fatalf("expected the earliest message to hold back the others, got %d", len(ready))
+	}
+
+	ready := m.ready(now.Add(1500 * time.Millisecond))
+	if len(ready) != 3 || ready[0].stream != "A" || ready[1].stream != "B" || ready[2].stream != "C" {
+		t.Fatalf("invalid order: %+v", ready)
+	}
+
+	m.add(tailItem{stream: "D", stored: now, arrived: now})
+	if flushed := m.flush(); len(flushed) != 1 || len(m.items) != 0 {
+		t.Fatalf("expected flush to return all messages")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// errStopStreamWalk can be returned by a walkStream callback to end the walk early without an error
+var errStopStreamWalk = errors.New("stream walk stopped")
+
+// walkStream passes messages matching cfg to cb in sequence order using an ordered consumer, it stops at last or, when
+// last is 0, at the last sequence the stream held when the walk started. Messages added while walking, including ones
+// cb publishes back into the stream, are therefore not visited. An error is returned when messages stop arriving
+// before the end is reached so callers never mistake a timeout for a complete walk
+func walkStream(ctx context.Context, js jetstream.JetStream, stream string, cfg jetstream.OrderedConsumerConfig, last uint64, cb func(msg jetstream.Msg, meta *jetstream.MsgMetadata) error) error {
+	if last == 0 {
+		str, err := js.Stream(ctx, stream)
+		if err != nil {
+			return err
+		}
+
+		last = str.CachedInfo().State.LastSeq
+	}
+
+	if last == 0 || cfg.OptStartSeq > last {
+		return nil
+	}
+
+	cons, err := js.OrderedConsumer(ctx, stream, cfg)
+	if err != nil {
+		return err
+	}
+
+	var seen uint64
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		batch, err := cons.Fetch(1000, jetstream.FetchMaxWait(opts().Timeout))
+		if err != nil {
+			return err
+		}
+
+		received := 0
+		done := false
+
+		for msg := range batch.Messages() {
+			received++
+
+			meta, err := msg.Metadata()
+			if err != nil {
+				return err
+			}
+
+			if meta.Sequence.Stream > last {
+				done = true
+				break
+			}
+
+			seen = meta.Sequence.Stream
+
+			err = cb(msg, meta)
+			if errors.Is(err, errStopStreamWalk) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if meta.Sequence.Stream == last || meta.NumPending == 0 {
+				done = true
+				break
+			}
+		}
+
+		if done {
+			return nil
+		}
+
+		if batch.Error() != nil {
+			return batch.Error()
+		}
+
+		if received == 0 {
+			// each fetch recreates the consumer so its pending count reflects what is left to read
+			nfo := cons.CachedInfo()
+			if nfo != nil && nfo.NumPending == 0 {
+				return nil
+			}
+
+			return fmt.Errorf("stopped receiving messages from Stream %s after sequence %d before reaching sequence %d", stream, seen, last)
+		}
+	}
+}
@@ -19,11 +19,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
 	"sort"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/choria-io/fisk"
@@ -41,7 +43,9 @@ type subCmd struct {
 	queue                 string
 	durable               string
 	raw                   bool
+	jsonl                 bool
 	translate             string
+	decoders              []string
 	jsAck                 bool
 	inbox                 bool
 	match                 bool
@@ -56,7 +60,7 @@ type subCmd struct {
 	deliverLast           bool
 	deliverSince          string
 	deliverLastPerSubject bool
-	headersOnly           bool
+	display               msgDisplay
 	stream                string
 	jetStream             bool
 	ignoreSubjects        []string
@@ -65,6 +69,14 @@ type subCmd struct {
 	deltaTimeStamps       bool
 	subjectsOnly          bool
 	graphOnly             bool
+	summary               bool
+	summaryInterval       time.Duration
+	report                bool
+	reportFor             time.Duration
+	subFor                time.Duration
+	schemaFile            string
+	invalidOnly           bool
+	schema                *payloadSchema
 	width                 int
 	height                int
 	messageRates          map[string]*subMessageRate
@@ -103,7 +115,9 @@ func configureSubCommand(app commandHost) {
 	act.Flag("queue", "Subscribe to a named queue group").StringVar(&c.queue)
 	act.Flag("durable", "Use a durable consumer (requires JetStream)").StringVar(&c.durable)
 	act.Flag("raw", "Show the raw data received").Short('r').UnNegatableBoolVar(&c.raw)
+	act.Flag("jsonl", "Show each message as a single line of JSON including headers and metadata").UnNegatableBoolVar(&c.jsonl)
 	act.Flag("translate", "Translate the message data by running it through the given command before output").StringVar(&c.translate)
+	act.Flag("decode", fmt.Sprintf("Decodes the message data before output, can be repeated to decode in order (%s)", strings.Join(payloadDecoders, ", "))).PlaceHolder("DECODER").EnumsVar(&c.decoders, payloadDecoders...)
 	act.Flag("ack", "Acknowledge JetStream message that have the correct metadata").BoolVar(&c.jsAck)
 	// We do not support (explicit) ackPolicy right now. The only situation where it is useful would be WorkQueue policy right now.
 	// Deleting from a stream with WorkQueue through ack could be unexpected behavior in the sub command.
@@ -112,8 +126,10 @@ func configureSubCommand(app commandHost) {
 	act.Flag("match-replies", "Match replies to requests").UnNegatableBoolVar(&c.match)
 	act.Flag("inbox", "Subscribes to a generate inbox").Short('i').UnNegatableBoolVar(&c.inbox)
 	act.Flag("count", "Quit after receiving this many messages").UintVar(&c.limit)
+	act.Flag("for", "Quit after receiving messages for this long").PlaceHolder("DURATION").DurationVar(&c.subFor)
+	addPayloadSchemaFlags(act, &c.schemaFile, &c.invalidOnly)
 	act.Flag("dump", "Dump received messages to files, 1 file per message. Specify - for null terminated STDOUT for use with xargs -0").PlaceHolder("DIRECTORY").StringVar(&c.dump)
-	act.Flag("headers-only", "Do not render any data, shows only headers").UnNegatableBoolVar(&c.headersOnly)
+	addMsgDisplayFlags(act, &c.display)
 	act.Flag("subjects-only", "Prints only the messages' subjects").UnNegatableBoolVar(&c.subjectsOnly)
 	act.Flag("start-sequence", "Starts at a specific Stream sequence (requires JetStream)").PlaceHolder("SEQUENCE").Uint64Var(&c.sseq)
 	act.Flag("all", "Delivers all messages found in the Stream (requires JetStream)").UnNegatableBoolVar(&c.deliverAll)
@@ -126,10 +142,14 @@ func configureSubCommand(app commandHost) {
 	act.Flag("wait", "Unsubscribe after this amount of time without any traffic").DurationVar(&c.wait)
 	act.Flag("report-subjects", "Subscribes to subject patterns and builds a de-duplicated report of active subjects receiving data").UnNegatableBoolVar(&c.reportSubjects)
 	act.Flag("report-subscriptions", "Subscribes to subject patterns and builds a de-duplicated report of active subscriptions receiving data").UnNegatableBoolVar(&c.reportSub)
-	act.Flag("report-top", "Number of subjects to show when doing 'report-subjects'. Default is 10.").Default("10").IntVar(&c.reportSubjectsCount)
+	act.Flag("report-top", "Number of subjects to show when doing 'report-subjects' or 'report'. Default is 10.").Default("10").IntVar(&c.reportSubjectsCount)
 	act.Flag("timestamp", "Show timestamps in output").Short('t').UnNegatableBoolVar(&c.timeStamps)
 	act.Flag("delta-time", "Show time since start in output").Short('d').UnNegatableBoolVar(&c.deltaTimeStamps)
 	act.Flag("graph", "Graph the rate of messages received").UnNegatableBoolVar(&c.graphOnly)
+	act.Flag("summary", "Count messages and bytes per subject without showing them, showing a summary on exit").UnNegatableBoolVar(&c.summary)
+	act.Flag("summary-interval", "Also show the summary at this interval").PlaceHolder("DURATION").DurationVar(&c.summaryInterval)
+	act.Flag("report", "Consume without showing messages, then report the message rate, payload sizes and top subjects").UnNegatableBoolVar(&c.report)
+	act.Flag("report-for", "How long to consume for when reporting").Default("1m").DurationVar(&c.reportFor)
 }
 
 func init() {
@@ -297,6 +317,28 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 	if c.dump == "-" && c.inbox {
 		return fmt.Errorf("generating inboxes is not compatible with dumping to stdout using null terminated strings")
 	}
+	if c.report && c.summaryInterval > 0 {
+		return fmt.Errorf("reports are not compatible with summary intervals")
+	}
+	if c.report {
+		if c.reportSubjectsCount <= 0 {
+			return fmt.Errorf("subject count must be at least one")
+		}
+		c.summary = true
+	}
+	if c.summaryInterval > 0 {
+		c.summary = true
+	}
+	if c.summary && (c.dump != "" || c.jsonl || c.graphOnly || c.reportSubjects || c.reportSub || c.match) {
+		return fmt.Errorf("summaries are not compatible with dumping, JSON Lines output, graphs, reports or reply matching")
+	}
+	if c.jsonl && (c.dump != "" || c.graphOnly || c.reportSubjects || c.reportSub) {
+		return fmt.Errorf("JSON Lines output is not compatible with dumping, graphs or reports")
+	}
+	if c.jsonl {
+		// jsonl is a machine readable raw mode, suppress all informational output
+		c.raw = true
+	}
 	if c.reportSubjects && c.reportSubjectsCount == 0 {
 		return fmt.Errorf("subject count must be at least one")
 	}
@@ -307,6 +349,18 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 	if c.timeStamps && c.deltaTimeStamps {
 		return fmt.Errorf("timestamp and delta-time flags are mutually exclusive")
 	}
+	err = c.display.validate()
+	if err != nil {
+		return err
+	}
+
+	c.schema, err = newPayloadSchema(c.schemaFile, c.invalidOnly)
+	if err != nil {
+		return err
+	}
+	if c.schema != nil && (c.summary || c.graphOnly || c.reportSubjects) {
+		return fmt.Errorf("schema validation is not compatible with summaries, graphs or reports")
+	}
 
 	if c.dump != "" && c.dump != "-" {
 		err = os.MkdirAll(c.dump, 0700)
@@ -322,16 +376,20 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 		dump           = c.dump != ""
 		ctr            = uint(0)
 		ignoreSubjects = splitCLISubjects(c.ignoreSubjects)
-		ctx, cancel    = context.WithCancel(ctx)
+		sigCtx, stop   = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		ctx, cancel    = context.WithCancel(sigCtx)
+		stats          = newSubStats()
 
 		replySub *nats.Subscription
 		matchMap map[string]*nats.Msg
 
 		subjectReportMap      map[string]int64
 		subjectBytesReportMap map[string]int64
+		payloadSizes          = newPayloadHistogram()
 
 		startTime = time.Now()
 	)
+	defer stop()
 	defer cancel()
 
 	if c.graphOnly {
@@ -365,6 +423,16 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 		defer t.Stop()
 	}
 
+	if c.report {
+		rt := time.AfterFunc(c.reportFor, cancel)
+		defer rt.Stop()
+	}
+
+	if c.subFor > 0 {
+		ft := time.AfterFunc(c.subFor, cancel)
+		defer ft.Stop()
+	}
+
 	handler := func(m *nats.Msg) {
 		mu.Lock()
 		defer mu.Unlock()
@@ -377,6 +445,7 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 		if c.jsAck && info != nil {
 			defer func() {
 				err = m.Respond(nil)
+				stats.acked(err)
 				if err != nil && !dump && !c.raw {
 					log.Printf("Acknowledging message via subject %s failed: %s\n", m.Reply, err)
 				}
@@ -402,6 +471,8 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 		}
 
 		ctr++
+		stats.received(len(m.Data))
+
 		switch {
 		case c.reportSubjects:
 			subjMu.Lock()
@@ -413,6 +484,16 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 			subjectBytesReportMap[sub] += int64(len(m.Data))
 			subjMu.Unlock()
 
+		case c.summary:
+			subjMu.Lock()
+			subjectReportMap[m.Subject]++
+			subjectBytesReportMap[m.Subject] += int64(len(m.Data))
+			subjMu.Unlock()
+
+			if c.report {
+				payloadSizes.add(len(m.Data))
+			}
+
 		case c.graphOnly:
 			if m.Sub == nil {
 				return
@@ -421,6 +502,10 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 			subjMu.Lock()
 			c.messageRates[m.Sub.Subject].lastCount++
 			subjMu.Unlock()
+
+		case c.schema != nil && !c.schema.inspect(m.Subject, decodedMsg(m, c.decoders).Data):
+			// valid messages are not shown when only invalid ones are requested
+
 		default:
 			if c.match && m.Reply != "" {
 				matchMap[m.Reply] = m
@@ -484,11 +569,27 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 		}
 	}
 
-	if c.reportSubjects {
+	if c.reportSubjects || c.summary {
 		subjectReportMap = make(map[string]int64)
 		subjectBytesReportMap = make(map[string]int64)
 	}
 
+	if c.summaryInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(c.summaryInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					c.printSummary(&subjMu, subjectReportMap, subjectBytesReportMap, startTime)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
 	var ignoredSubjInfo string
 	if len(ignoreSubjects) > 0 {
 		ignoredSubjInfo = fmt.Sprintf("\nIgnored subjects: %s", f(ignoreSubjects))
@@ -545,7 +646,7 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 			nats.AckNone(),
 		}
 
-		if c.headersOnly || c.subjectsOnly {
+		if c.display.headersOnly || c.subjectsOnly {
 			opts = append(opts, nats.HeadersOnly())
 		}
 
@@ -660,9 +761,58 @@ func (c *subCmd) subscribe(p *fisk.ParseContext) error {
 
 	<-ctx.Done()
 
+	drainSubscriptions(append(subs, replySub), opts().Timeout)
+
+	switch {
+	case c.report:
+		c.printReport(&subjMu, subjectReportMap, subjectBytesReportMap, payloadSizes, startTime)
+		return nil
+	case c.summary:
+		c.printSummary(&subjMu, subjectReportMap, subjectBytesReportMap, startTime)
+	}
+
+	// the statistics are only shown when interrupted so output of limited subscriptions can be used in scripts
+	if sigCtx.Err() != nil {
+		fmt.Fprintln(os.Stderr)
+		stats.render(os.Stderr, time.Now())
+	}
+	c.schema.render(os.Stderr)
+
 	return nil
 }
 
+// printSummary shows the message and byte counts for every subject seen since startTime
+func (c *subCmd) printSummary(subjMu *sync.Mutex, counts map[string]int64, sizes map[string]int64, startTime time.Time) {
+	subjMu.Lock()
+	defer subjMu.Unlock()
+
+	subjects := make([]string, 0, len(counts))
+	for subject := range counts {
+		subjects = append(subjects, subject)
+	}
+
+	sort.Slice(subjects, func(i, j int) bool {
+		if counts[subjects[i]] == counts[subjects[j]] {
+			return subjects[i] < subjects[j]
+		}
+		return counts[subjects[i]] > counts[subjects[j]]
+	})
+
+	elapsed := time.Since(startTime)
+
+	var totalCount, totalBytes int64
+	table := iu.NewTableWriter(opts(), fmt.Sprintf("Summary of %s subjects received over %s", f(len(subjects)), f(elapsed.Round(time.Second))))
+	table.AddHeaders("Subject", "Messages", "Bytes", "Messages/s")
+	for _, subject := range subjects {
+		totalCount += counts[subject]
+		totalBytes += sizes[subject]
+		table.AddRow(subject, f(counts[subject]), humanize.IBytes(uint64(sizes[subject])), f(float64(counts[subject])/elapsed.Seconds()))
+	}
+	table.AddFooter("Totals", f(totalCount), humanize.IBytes(uint64(totalBytes)), f(float64(totalCount)/elapsed.Seconds()))
+
+	fmt.Println(table.Render())
+}
+
 func (c *subCmd) firstSubject() string {
 	if len(c.subjects) == 0 {
 		return ""
@@ -688,6 +838,13 @@ func (c *subCmd) printMsg(msg *nats.Msg, reply *nats.Msg, ctr uint, startTime ti
 		timeStamp = fmt.Sprintf(" @ %s", time.Since(startTime).String())
 	}
 
+	if c.dump == "" {
+		msg = decodedMsg(msg, c.decoders)
+		if reply != nil {
+			reply = decodedMsg(reply, c.decoders)
+		}
+	}
+
 	if c.dump != "" {
 		// Output format 1: dumping, to stdout or files
 
@@ -711,11 +868,21 @@ func (c *subCmd) printMsg(msg *nats.Msg, reply *nats.Msg, ctr uint, startTime ti
 			c.dumpMsg(reply, stdout, replyFile, ctr)
 		}
 
+	} else if c.jsonl {
+		// Output format 2: JSON Lines
+		err := outPutMSGJSONL(c.display.filter(msg), c.translate)
+		if err == nil && reply != nil {
+			err = outPutMSGJSONL(c.display.filter(reply), c.translate)
+		}
+		if err != nil {
+			log.Printf("Could not render message as JSON: %s", err)
+		}
+
 	} else if c.raw {
-		// Output format 2: raw
-		outPutMSGBodyCompact(msg.Data, c.translate, "", "")
+		// Output format 3: raw
+		c.display.printRaw(msg.Header, func() { outPutMSGBodyCompact(msg.Data, c.translate, "", "") })
 		if reply != nil {
-			fmt.Println(string(reply.Data))
+			c.display.printRaw(reply.Header, func() { fmt.Println(string(reply.Data)) })
 		}
 
 	} else {
@@ -737,7 +904,7 @@ func (c *subCmd) printMsg(msg *nats.Msg, reply *nats.Msg, ctr uint, startTime ti
 			return
 		}
 
-		c.prettyPrintMsg(msg, c.headersOnly, c.translate)
+		c.prettyPrintMsg(msg, c.translate)
 
 		if reply != nil {
 			if info == nil {
@@ -748,7 +915,7 @@ func (c *subCmd) printMsg(msg *nats.Msg, reply *nats.Msg, ctr uint, startTime ti
 				fmt.Printf("[#%d] Matched reply JetStream message: consumer: %s > %s / subject: %s / delivered: %d / consumer seq: %d / stream seq: %d\n", ctr, info.Stream(), info.Consumer(), reply.Subject, info.Delivered(), info.ConsumerSequence(), info.StreamSequence())
 			}
 
-			c.prettyPrintMsg(reply, c.headersOnly, c.translate)
+			c.prettyPrintMsg(reply, c.translate)
 
 		}
 	} // output format type dispatch
@@ -778,18 +945,6 @@ func (c *subCmd) dumpMsg(msg *nats.Msg, stdout bool, filepath string, ctr uint)
 	}
 }
 
-func (c *subCmd) prettyPrintMsg(msg *nats.Msg, headersOnly bool, filter string) {
-	if len(msg.Header) > 0 {
-		for h, vals := range msg.Header {
-			for _, val := range vals {
-				fmt.Printf("%s: %s\n", h, val)
-			}
-		}
-
-		fmt.Println()
-	}
-
-	if !headersOnly {
-		outPutMSGBody(msg.Data, filter, msg.Subject, "")
-	}
+func (c *subCmd) prettyPrintMsg(msg *nats.Msg, filter string) {
+	c.display.printMsg(msg.Header, func() { outPutMSGBody(msg.Data, filter, msg.Subject, "") })
 }
@@ -0,0 +1,157 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+// payloadHistogramBuckets are the upper bounds of the payload size buckets, larger payloads go in a final bucket
+var payloadHistogramBuckets = []int{0, 64, 256, 1024, 4 * 1024, 16 * 1024, 64 * 1024, 256 * 1024, 1024 * 1024}
+
+// payloadHistogram records the distribution of payload sizes
+type payloadHistogram struct {
+	counts []uint64
+	total  uint64
+	bytes  uint64
+	min    int
+	max    int
+	mu     sync.Mutex
+}
+
+func newPayloadHistogram() *payloadHistogram {
+	return &payloadHistogram{counts: make([]uint64, len(payloadHistogramBuckets)+1), min: math.MaxInt}
+}
+
+// add records a payload of size bytes
+func (h *payloadHistogram) add(size int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	idx := sort.SearchInts(payloadHistogramBuckets, size)
+	h.counts[idx]++
+	h.total++
+	h.bytes += uint64(size)
+	h.min = min(h.min, size)
+	h.max = max(h.max, size)
+}
+
+// bucketLabel describes the sizes held in bucket i
+func (h *payloadHistogram) bucketLabel(i int) string {
+	switch {
+	case i == 0:
+		return "empty"
+	case i == len(payloadHistogramBuckets):
+		return fmt.Sprintf("> %s", humanize.IBytes(uint64(payloadHistogramBuckets[i-1])))
+	default:
+		return fmt.Sprintf("%s - %s", humanize.IBytes(uint64(payloadHistogramBuckets[i-1]+1)), humanize.IBytes(uint64(payloadHistogramBuckets[i])))
+	}
+}
+
+// render writes the distribution as a table with a bar for every non empty bucket
+func (h *payloadHistogram) render(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.total == 0 {
+		fmt.Fprintln(w, "No messages received")
+		return
+	}
+
+	var most uint64
+	for _, c := range h.counts {
+		most = max(most, c)
+	}
+
+	table := iu.NewTableWriter(opts(), fmt.Sprintf("Payload sizes of %s messages, min %s avg %s max %s", f(h.total), humanize.IBytes(uint64(h.min)), humanize.IBytes(h.bytes/h.total), humanize.IBytes(uint64(h.max))))
+	table.AddHeaders("Size", "Messages", "%", "")
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+
+		table.AddRow(h.bucketLabel(i), f(c), fmt.Sprintf("%.1f", float64(c)*100/float64(h.total)), strings.Repeat("*", int(math.Ceil(float64(c)*40/float64(most)))))
+	}
+
+	fmt.Fprintln(w, table.Render())
+}
+
+// topSubjects returns up to n subjects ordered by their value in vals, ties sorted by name
+func topSubjects(vals map[string]int64, n int) []string {
+	subjects := make([]string, 0, len(vals))
+	for subject := range vals {
+		subjects = append(subjects, subject)
+	}
+
+	sort.Slice(subjects, func(i, j int) bool {
+		if vals[subjects[i]] == vals[subjects[j]] {
+			return subjects[i] < subjects[j]
+		}
+		return vals[subjects[i]] > vals[subjects[j]]
+	})
+
+	if n > 0 && len(subjects) > n {
+		subjects = subjects[:n]
+	}
+
+	return subjects
+}
+
+// printReport shows the traffic rate, payload size distribution and the busiest subjects by messages and bytes
+func (c *subCmd) printReport(subjMu *sync.Mutex, counts map[string]int64, sizes map[string]int64, hist *payloadHistogram, startTime time.Time) {
+	subjMu.Lock()
+	defer subjMu.Unlock()
+
+	elapsed := time.Since(startTime)
+
+	var totalCount, totalBytes int64
+	for subject := range counts {
+		totalCount += counts[subject]
+		totalBytes += sizes[subject]
+	}
+
+	fmt.Printf("Received %s messages totaling %s on %s subjects in %s", f(totalCount), humanize.IBytes(uint64(totalBytes)), f(len(counts)), f(elapsed.Round(time.Second)))
+	if elapsed > 0 {
+		fmt.Printf(" (%s msg/s, %s/s)", f(float64(totalCount)/elapsed.Seconds()), humanize.IBytes(uint64(float64(totalBytes)/elapsed.Seconds())))
+	}
+	fmt.Println()
+	fmt.Println()
+
+	hist.render(os.Stdout)
+	if totalCount == 0 {
+		return
+	}
+
+	for _, by := range []struct {
+		name string
+		vals map[string]int64
+	}{{"Messages", counts}, {"Bytes", sizes}} {
+		table := iu.NewTableWriter(opts(), fmt.Sprintf("Top %d Subjects by %s", c.reportSubjectsCount, by.name))
+		table.AddHeaders("Subject", "Messages", "Bytes", "Messages/s", "% Bytes")
+		for _, subject := range topSubjects(by.vals, c.reportSubjectsCount) {
+			table.AddRow(subject, f(counts[subject]), humanize.IBytes(uint64(sizes[subject])), f(float64(counts[subject])/elapsed.Seconds()), fmt.Sprintf("%.1f", float64(sizes[subject])*100/float64(max(totalBytes, 1))))
+		}
+		fmt.Println(table.Render())
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPayloadHistogram(t *testing.T) {
+	h := newPayloadHistogram()
+	for _, size := range []int{0, 1, 64, 65, 1024, 2 * 1024 * 1024} {
+		h.add(size)
+	}
+
+	expect := []uint64{1, 2, 1, 1, 0, 0, 0, 0, 0, 1}
+	if !cmp.Equal(h.counts, expect) {
+		t.Fatalf("expected %v got %v", expect, h.counts)
+	}
+	if h.total != 6 || h.min != 0 || h.max != 2*1024*1024 {
+		t.Fatalf("invalid totals %d min %d max %d", h.total, h.min, h.max)
+	}
+
+	if l := h.bucketLabel(0); l != "empty" {
+		t.Fatalf("invalid label %q", l)
+	}
+	if l := h.bucketLabel(2); l != "65 B - 256 B" {
+		t.Fatalf("invalid label %q", l)
+	}
+	if l := h.bucketLabel(len(payloadHistogramBuckets)); l != "> 1.0 MiB" {
+		t.Fatalf("invalid label %q", l)
+	}
+
+	top := topSubjects(map[string]int64{"a": 1, "b": 10, "c": 10, "d": 5}, 3)
+	if !cmp.Equal(top, []string{"b", "c", "d"}) {
+		t.Fatalf("invalid top subjects %v", top)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/nats-io/nats.go"
+)
+
+// subStats counts messages and acknowledgements handled by long-running subscribers for the summary shown when they stop,
+// recording on a nil subStats does nothing
+type subStats struct {
+	start    time.Time
+	msgs     atomic.Uint64
+	bytes    atomic.Uint64
+	acks     atomic.Uint64
+	ackFails atomic.Uint64
+}
+
+func newSubStats() *subStats {
+	return &subStats{start: time.Now()}
+}
+
+// received records a message with a payload of size bytes
+func (s *subStats) received(size int) {
+	if s == nil {
+		return
+	}
+
+	s.msgs.Add(1)
+	s.bytes.Add(uint64(size))
+}
+
+// acked records the outcome of an acknowledgement
+func (s *subStats) acked(err error) {
+	if s == nil {
+		return
+	}
+
+	if err != nil {
+		s.ackFails.Add(1)
+	} else {
+		s.acks.Add(1)
+	}
+}
+
+// render writes the summary assuming the subscriber ran until now
+func (s *subStats) render(w io.Writer, now time.Time) {
+	took := now.Sub(s.start)
+	msgs := s.msgs.Load()
+	size := s.bytes.Load()
+
+	fmt.Fprintf(w, "Received %s messages totaling %s in %s", f(msgs), humanize.IBytes(size), f(took))
+	if took > 0 && msgs > 0 {
+		fmt.Fprintf(w, " (%s msg/s, %s/s)", f(float64(msgs)/took.Seconds()), humanize.IBytes(uint64(float64(size)/took.Seconds())))
+	}
+	fmt.Fprintln(w)
+
+	acks := s.acks.Load()
+	fails := s.ackFails.Load()
+	if acks > 0 || fails > 0 {
+		fmt.Fprintf(w, "Acknowledged %s messages, %s acknowledgements failed\n", f(acks), f(fails))
+	}
+}
+
+// drainSubscriptions drains subs so that messages already received are handled, waiting up to timeout for them to complete
+func drainSubscriptions(subs []*nats.Subscription, timeout time.Duration) {
+	var draining []*nats.Subscription
+	for _, sub := range subs {
+		if sub == nil || !sub.IsValid() {
+			continue
+		}
+		if sub.Drain() == nil {
+			draining = append(draining, sub)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for _, sub := range draining {
+		for sub.IsValid() && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
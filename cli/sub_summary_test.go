@@ -0,0 +1,47 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubStats(t *testing.T) {
+	var nilStats *subStats
+	nilStats.received(10)
+	nilStats.acked(nil)
+
+	stats := newSubStats()
+	stats.start = time.Now().Add(-2 * time.Second)
+	stats.received(1024)
+	stats.received(1024)
+
+	out := bytes.NewBuffer(nil)
+	stats.render(out, stats.start.Add(2*time.Second))
+	expected := "Received 2 messages totaling 2.0 KiB in 2.00s (1 msg/s, 1.0 KiB/s)\n"
+	if out.String() != expected {
+		t.Fatalf("expected %q got %q", expected, out.String())
+	}
+
+	stats.acked(nil)
+	stats.acked(errors.New("timeout"))
+	out.Reset()
+	stats.render(out, stats.start.Add(2*time.Second))
+	if !bytes.HasSuffix(out.Bytes(), []byte("Acknowledged 1 messages, 1 acknowledgements failed\n")) {
+		t.Fatalf("invalid acknowledgement summary %q", out.String())
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"github.com/choria-io/fisk"
 	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
 	"github.com/nats-io/natscli/top"
 	ui "gopkg.in/gizak/termui.v1"
 )
@@ -32,22 +33,29 @@ type topCmd struct {
 	raw             bool
 	maxRefresh      int
 	showSubs        bool
+	serverSort      string
 }
 
 func configureTopCommand(app commandHost) {
 	c := &topCmd{}
 
-	top := app.Command("top", "Shows top-like statistic for connections on a specific server").Action(c.topAction)
-	top.Arg("name", "The server name to gather statistics for").Required().StringVar(&c.host)
-	top.Flag("conns", "Maximum number of connections to show").Default("1024").Short('n').IntVar(&c.conns)
-	top.Flag("interval", "Refresh interval").Default("1").Short('d').IntVar(&c.delay)
-	top.Flag("sort", "Sort connections by").Default("cid").EnumVar(&c.sort, "cid", "start", "subs", "pending", "msgs_to", "msgs_from", "bytes_to", "bytes_from", "last", "idle", "uptime", "stop", "reason", "rtt")
-	top.Flag("lookup", "Looks up client addresses in DNS").Default("false").UnNegatableBoolVar(&c.lookup)
-	top.Flag("output", "Saves the first snapshot to a file").Short('o').StringVar(&c.output)
-	top.Flag("delimiter", "Specifies a output delimiter, defaults to grid-like text").StringVar(&c.outputDelimiter)
-	top.Flag("raw", "Show raw bytes").Short('b').Default("false").UnNegatableBoolVar(&c.raw)
-	top.Flag("max-refresh", "Maximum refreshes").Short('r').Default("-1").IntVar(&c.maxRefresh)
-	top.Flag("subs", "Shows the subscriptions column").Default("false").UnNegatableBoolVar(&c.showSubs)
+	cmd := app.Command("top", "Shows top-like statistic for all servers or connections on a specific server").Action(c.topAction)
+	cmd.HelpLong(`Without a server name a live view of all servers is shown including load,
+connections and message rates. Press o to change the sort column, r to reverse
+the sort order and q to quit.
+
+With a server name the connections on that server are shown.`)
+	cmd.Arg("name", "The server name to gather connection statistics for").StringVar(&c.host)
+	cmd.Flag("conns", "Maximum number of connections to show").Default("1024").Short('n').IntVar(&c.conns)
+	cmd.Flag("interval", "Refresh interval").Default("1").Short('d').IntVar(&c.delay)
+	cmd.Flag("sort", "Sort connections by").Default("cid").EnumVar(&c.sort, "cid", "start", "subs", "pending", "msgs_to", "msgs_from", "bytes_to", "bytes_from", "last", "idle", "uptime", "stop", "reason", "rtt")
+	cmd.Flag("lookup", "Looks up client addresses in DNS").Default("false").UnNegatableBoolVar(&c.lookup)
+	cmd.Flag("output", "Saves the first snapshot to a file").Short('o').StringVar(&c.output)
+	cmd.Flag("delimiter", "Specifies a output delimiter, defaults to grid-like text").StringVar(&c.outputDelimiter)
+	cmd.Flag("raw", "Show raw bytes").Short('b').Default("false").UnNegatableBoolVar(&c.raw)
+	cmd.Flag("max-refresh", "Maximum refreshes").Short('r').Default("-1").IntVar(&c.maxRefresh)
+	cmd.Flag("subs", "Shows the subscriptions column").Default("false").UnNegatableBoolVar(&c.showSubs)
+	cmd.Flag("server-sort", "Sort servers by when no server name is given").Default("name").EnumVar(&c.serverSort, top.ServerSortOpts...)
 }
 
 func init() {
@@ -60,6 +68,10 @@ func (c *topCmd) topAction(_ *fisk.ParseContext) error {
 		return err
 	}
 
+	if c.host == "" {
+		return c.serversAction(nc)
+	}
+
 	engine := top.NewEngine(nc, c.host, c.conns, c.delay, opts().Trace)
 
 	_, err = engine.Request("VARZ")
@@ -90,3 +102,27 @@ func (c *topCmd) topAction(_ *fisk.ParseContext) error {
 
 	return nil
 }
+
+func (c *topCmd) serversAction(nc *nats.Conn) error {
+	engine := top.NewServersEngine(nc, c.delay, c.serverSort, opts().Trace)
+
+	if c.output != "" {
+		if c.outputDelimiter != "" {
+			return fmt.Errorf("delimited output is only supported for connection statistics")
+		}
+
+		return top.SaveServersSnapshotToFile(engine, c.output)
+	}
+
+	err := ui.Init()
+	if err != nil {
+		return err
+	}
+	defer ui.Close()
+
+	go engine.MonitorStats()
+
+	top.StartServersUI(engine, c.raw, c.maxRefresh)
+
+	return nil
+}
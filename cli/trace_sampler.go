@@ -0,0 +1,127 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	glog "log"
+	"os"
+	"sync"
+)
+
+var (
+	traceRequestMarker  = []byte(">>> ")
+	traceResponseMarker = []byte("<<< ")
+	traceSamplingOnce   sync.Once
+)
+
+// traceSampler is a log writer that passes through 1 in every rate traced API requests along with their
+// responses, all other log output is passed through unchanged
+type traceSampler struct {
+	out        io.Writer
+	rate       uint64
+	seen       uint64
+	suppressed uint64
+	pending    map[string]int
+	mu         sync.Mutex
+}
+
+func newTraceSampler(out io.Writer, rate uint64) *traceSampler {
+	return &traceSampler{
+		out:     out,
+		rate:    max(rate, 1),
+		pending: make(map[string]int),
+	}
+}
+
+// setupTraceSampling installs a trace sampler as log output when tracing with a sample rate
+func setupTraceSampling() {
+	if !opts().Trace || opts().TraceSample <= 1 {
+		return
+	}
+
+	traceSamplingOnce.Do(func() {
+		glog.SetOutput(newTraceSampler(os.Stderr, uint64(opts().TraceSample)))
+	})
+}
+
+// traceEntry finds the marker and subject of a trace log line, ok is false for other log lines
+func traceEntry(line []byte) (request bool, subject string, ok bool) {
+	// allows for the log timestamp prefix
+	head := line[:min(len(line), 32)]
+
+	idx := bytes.Index(head, traceRequestMarker)
+	request = idx >= 0
+	if !request {
+		idx = bytes.Index(head, traceResponseMarker)
+		if idx < 0 {
+			return false, "", false
+		}
+	}
+
+	rest := line[idx+len(traceRequestMarker):]
+	end := bytes.IndexAny(rest, ": \n")
+	if end >= 0 {
+		rest = rest[:end]
+	}
+
+	return request, string(rest), true
+}
+
+func (t *traceSampler) Write(p []byte) (int, error) {
+	request, subject, ok := traceEntry(p)
+	if !ok {
+		return t.out.Write(p)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if request {
+		t.seen++
+		if (t.seen-1)%t.rate != 0 {
+			t.suppressed++
+			return len(p), nil
+		}
+
+		// requests like acks never see a response, avoid growing forever
+		if len(t.pending) > 10000 {
+			clear(t.pending)
+		}
+
+		t.pending[subject]++
+	} else {
+		if t.pending[subject] == 0 {
+			t.suppressed++
+			return len(p), nil
+		}
+
+		t.pending[subject]--
+		if t.pending[subject] == 0 {
+			delete(t.pending, subject)
+		}
+	}
+
+	if t.suppressed > 0 {
+		_, err := fmt.Fprintf(t.out, "... %s trace entries suppressed, showing 1 in %d requests\n\n", f(t.suppressed), t.rate)
+		if err != nil {
+			return 0, err
+		}
+		t.suppressed = 0
+	}
+
+	return t.out.Write(p)
+}
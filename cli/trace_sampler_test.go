@@ -0,0 +1,48 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTraceSampler(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	ts := newTraceSampler(out, 3)
+
+	for i := 0; i < 6; i++ {
+		ts.Write([]byte("10:00:00 >>> $JS.API.INFO\n{}\n\n"))
+		ts.Write([]byte("10:00:00 <<< $JS.API.INFO\n{\"type\":\"info\"}\n\n"))
+	}
+	ts.Write([]byte("10:00:00 unrelated log line\n"))
+
+	lines := bytes.Count(out.Bytes(), []byte(">>> $JS.API.INFO"))
+	if lines != 2 {
+		t.Fatalf("expected 2 sampled requests got %d:\n%s", lines, out.String())
+	}
+
+	lines = bytes.Count(out.Bytes(), []byte("<<< $JS.API.INFO"))
+	if lines != 2 {
+		t.Fatalf("expected 2 sampled responses got %d:\n%s", lines, out.String())
+	}
+
+	if !bytes.Contains(out.Bytes(), []byte("... 4 trace entries suppressed")) {
+		t.Fatalf("expected suppressed counter:\n%s", out.String())
+	}
+
+	if !bytes.Contains(out.Bytes(), []byte("unrelated log line")) {
+		t.Fatalf("expected unrelated lines to pass through:\n%s", out.String())
+	}
+}
@@ -16,8 +16,11 @@ package cli
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,6 +30,8 @@ import (
 	"net/textproto"
 	"os"
 	"os/exec"
+	"os/user"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
@@ -35,6 +40,7 @@ import (
 	"text/template"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/jedib0t/go-pretty/v6/progress"
 
@@ -45,8 +51,12 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/choria-io/fisk"
+	"github.com/fatih/color"
+	"github.com/ghodss/yaml"
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/shlex"
 	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
 	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/jsm.go/natscontext"
@@ -75,6 +85,10 @@ func selectConsumer(mgr *jsm.Manager, stream string, consumer string, force bool
 		return "", nil, fmt.Errorf("cannot pick a Consumer without a terminal and no Consumer name supplied")
 	}
 
+	if opts().NoPrompt {
+		return "", nil, fmt.Errorf("no Consumer name supplied and interactive selection is disabled by --no-prompt")
+	}
+
 	consumers, err := mgr.ConsumerNames(stream)
 	if err != nil {
 		return "", nil, err
@@ -136,6 +150,10 @@ func selectStream(mgr *jsm.Manager, stream string, force bool, all bool) (string
 		return "", nil, fmt.Errorf("cannot pick a Stream without a terminal and no Stream name supplied")
 	}
 
+	if opts().NoPrompt {
+		return "", nil, fmt.Errorf("no Stream name supplied and interactive selection is disabled by --no-prompt")
+	}
+
 	if force {
 		return "", nil, fmt.Errorf("unknown stream %q", stream)
 	}
@@ -252,6 +270,18 @@ func splitString(s string) []string {
 	})
 }
 
+// metadataMatches determines if metadata holds every key and value in selectors
+func metadataMatches(metadata map[string]string, selectors map[string]string) bool {
+	for k, v := range selectors {
+		val, ok := metadata[k]
+		if !ok || val != v {
+			return false
+		}
+	}
+
+	return true
+}
+
 func splitCLISubjects(subjects []string) []string {
 	new := []string{}
 
@@ -272,17 +302,37 @@ func natsOpts() []nats.Option {
 		return []nats.Option{}
 	}
 
-	copts, err := opts().Config.NATSOptions()
-	fisk.FatalIfError(err, "configuration error")
+	copts, err := contextNatsOpts(selectedContextName(), opts().Config)
+	if err != nil {
+		fisk.Fatalf("%v", err)
+	}
+
+	return copts
+}
+
+// contextNatsOpts are the options for connections to the context name configured by nctx, they include the
+// credentials from its credential helper and install the connection guard for it
+func contextNatsOpts(name string, nctx *natscontext.Context) ([]nats.Option, error) {
+	copts, err := nctx.NATSOptions()
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+
+	hopts, err := credentialHelperOptions(name, nctx)
+	if err != nil {
+		return nil, fmt.Errorf("credential helper error: %w", err)
+	}
+	copts = append(copts, hopts...)
 
 	connectionName := strings.TrimSpace(opts().ConnectionName)
 	if len(connectionName) == 0 {
-		connectionName = "NATS CLI Version " + Version
+		connectionName = defaultConnectionName()
 	}
 
-	return append(copts, []nats.Option{
+	copts = append(copts, reconnectOpts()...)
+
+	copts = append(copts, []nats.Option{
 		nats.Name(connectionName),
-		nats.MaxReconnects(-1),
 		nats.ConnectHandler(func(conn *nats.Conn) {
 			if opts().Trace {
 				log.Printf(">>> Connected to %s", conn.ConnectedUrlRedacted())
@@ -294,8 +344,12 @@ func natsOpts() []nats.Option {
 			}
 		}),
 		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
-			if err != nil {
+			switch {
+			case err == nil:
+			case !opts().NoReconnect:
 				log.Printf("Disconnected due to: %s, will attempt reconnect", err)
+			default:
+				log.Printf("Disconnected due to: %s", err)
 			}
 		}),
 		nats.ReconnectHandler(func(nc *nats.Conn) {
@@ -310,6 +364,100 @@ func natsOpts() []nats.Option {
 			}
 		}),
 	}...)
+
+	if opts().InjectLatency != "" {
+		// must be last so it wraps any dialer configured by the context
+		copts = append(copts, injectLatency(opts().InjectLatency))
+	}
+
+	return append(copts, contextGuardOpts(name, nctx)...), nil
+}
+
+// longRunningCommands run until interrupted, they reconnect without limit by default so server restarts do not end
+// them. Entries also match the sub commands of a command
+var longRunningCommands = []string{
+	"subscribe",
+	"reply",
+	"events",
+	"tail",
+	"consumer sub",
+	"stream tail",
+	"stream watch",
+	"kv watch",
+	"object watch",
+	"server watch",
+	"server check exporter",
+}
+
+// isLongRunningCommand determines if the full command name runs until interrupted
+func isLongRunningCommand(cmd string) bool {
+	for _, lr := range longRunningCommands {
+		if cmd == lr || strings.HasPrefix(cmd, lr+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reconnectOpts configures reconnect behavior using --reconnect, --max-reconnects and --reconnect-wait so long-running
+// commands survive server restarts and report when they give up while others fail after the usual attempts
+func reconnectOpts() []nats.Option {
+	if opts().NoReconnect {
+		return []nats.Option{
+			nats.NoReconnect(),
+			nats.ClosedHandler(func(nc *nats.Conn) {
+				if err := nc.LastError(); err != nil {
+					log.Printf("Connection closed: %s", err)
+				}
+			}),
+		}
+	}
+
+	maxReconnects := opts().MaxReconnects
+	if maxReconnects == 0 {
+		maxReconnects = nats.DefaultMaxReconnect
+		if isLongRunningCommand(selectedCommand) {
+			maxReconnects = -1
+		}
+	}
+
+	copts := []nats.Option{
+		nats.MaxReconnects(maxReconnects),
+		nats.ClosedHandler(func(nc *nats.Conn) {
+			if err := nc.LastError(); err != nil {
+				log.Printf("Connection closed after %s reconnects: %s", f(nc.Stats().Reconnects), err)
+			}
+		}),
+	}
+
+	if opts().ReconnectWait > 0 {
+		copts = append(copts, nats.ReconnectWait(opts().ReconnectWait))
+	}
+
+	return copts
+}
+
+// defaultConnectionName identifies the operator, host and command so server connz and audit views can attribute activity
+func defaultConnectionName() string {
+	name := "unknown"
+	u, err := user.Current()
+	if err == nil && u.Username != "" {
+		name = u.Username
+	} else if env := os.Getenv("USER"); env != "" {
+		name = env
+	}
+
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+
+	if selectedCommand == "" {
+		return fmt.Sprintf("%s@%s/nats-cli", name, host)
+	}
+
+	return fmt.Sprintf("%s@%s/nats-cli/%s", name, host, selectedCommand)
 }
 
 func jsOpts() []nats.JSOpt {
@@ -363,9 +511,23 @@ func newNatsConnUnlocked(servers string, copts ...nats.Option) (*nats.Conn, erro
 
 	var err error
 
+	setupTraceSampling()
+
 	opts.Conn, err = nats.Connect(servers, copts...)
+	if err != nil {
+		return opts.Conn, err
+	}
 
-	return opts.Conn, err
+	if opts.ExpectVersion != "" {
+		err = iu.RequireServerVersion(opts.Conn, opts.ExpectVersion)
+		if err != nil {
+			opts.Conn.Close()
+			opts.Conn = nil
+			return nil, err
+		}
+	}
+
+	return opts.Conn, nil
 }
 
 func newNatsConn(servers string, copts ...nats.Option) (*nats.Conn, error) {
@@ -410,7 +572,7 @@ func prepareHelper(servers string, copts ...nats.Option) (*nats.Conn, *jsm.Manag
 }
 
 func validator() *SchemaValidator {
-	if os.Getenv("NOVALIDATE") == "" {
+	if validationLevel() != validationOff {
 		return new(SchemaValidator)
 	}
 
@@ -450,10 +612,14 @@ func prepareHelperUnlocked(servers string, copts ...nats.Option) (*nats.Conn, *j
 		jsm.WithDomain(opts.Config.JSDomain()),
 	}
 
-	if os.Getenv("NOVALIDATE") == "" {
+	if validationLevel() != validationOff {
 		jsopts = append(jsopts, jsm.WithAPIValidation(validator()))
 	}
 
+	if opts.PedanticRequests {
+		jsopts = append(jsopts, jsm.WithPedanticRequests())
+	}
+
 	if opts.Timeout != 0 {
 		jsopts = append(jsopts, jsm.WithTimeout(opts.Timeout))
 	}
@@ -539,6 +705,26 @@ func readMIMEHeader(tp *textproto.Reader) (textproto.MIMEHeader, error) {
 	}
 }
 
+// encodeHeadersMsg encodes hdr in the wire format understood by decodeHeadersMsg, headers are sorted by name
+func encodeHeadersMsg(hdr nats.Header) []byte {
+	keys := make([]string, 0, len(hdr))
+	for k := range hdr {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	b.WriteString(hdrLine)
+	for _, k := range keys {
+		for _, v := range hdr[k] {
+			fmt.Fprintf(&b, "%s: %s%s", k, v, crlf)
+		}
+	}
+	b.WriteString(crlf)
+
+	return b.Bytes()
+}
+
 type pubData struct {
 	Cnt       int
 	Count     int
@@ -716,6 +902,19 @@ func loadContext(softFail bool) error {
 	return err
 }
 
+// selectedContextName is the name of the context in use, empty when contexts are disabled or none is selected
+func selectedContextName() string {
+	if SkipContexts {
+		return ""
+	}
+
+	if opts().CfgCtx != "" {
+		return opts().CfgCtx
+	}
+
+	return natscontext.SelectedContext()
+}
+
 func fileAccessible(f string) (bool, error) {
 	stat, err := os.Stat(f)
 	if err != nil {
@@ -735,6 +934,272 @@ func fileAccessible(f string) (bool, error) {
 	return true, nil
 }
 
+// readConfigFile reads a JSON or YAML configuration from file or STDIN when file is -, when expandEnv is set environment
+// variables are substituted and, when valuesFile or sets are given, it is rendered as a template using those values
+func readConfigFile(file string, valuesFile string, sets []string, expandEnv bool) ([]byte, error) {
+	var f []byte
+	var err error
+
+	if file == "-" {
+		f, err = io.ReadAll(os.Stdin)
+	} else {
+		f, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	isYAML := isYAMLConfig(file, f)
+
+	if expandEnv {
+		f, err = expandConfigEnv(f, isYAML, os.LookupEnv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// configurations may hold subject transform destinations like {{wildcard(1)}} so
+	// they are only treated as templates when values are given to render them with
+	if valuesFile != "" || len(sets) > 0 {
+		values, err := parseConfigValues(valuesFile, sets)
+		if err != nil {
+			return nil, err
+		}
+
+		f, err = renderConfigTemplate(filepath.Base(file), f, values)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isYAML {
+		return yaml.YAMLToJSON(f)
+	}
+
+	return f, nil
+}
+
+// isYAMLConfig determines if a configuration is YAML based on the file extension or, for STDIN and other
+// extensions, by checking if the content is a JSON object or array
+func isYAMLConfig(file string, body []byte) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return true
+	case ".json":
+		return false
+	}
+
+	trimmed := bytes.TrimSpace(body)
+
+	return len(trimmed) > 0 && trimmed[0] != '{' && trimmed[0] != '['
+}
+
+var configEnvRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandConfigEnv replaces ${VAR} and ${VAR:-default} with environment variables found using lookup, in JSON values are
+// escaped so they can be placed inside strings while in YAML they are placed as is and may not span multiple lines
+func expandConfigEnv(cfg []byte, isYAML bool, lookup func(string) (string, bool)) ([]byte, error) {
+	var missing []string
+	var invalid []string
+
+	res := configEnvRe.ReplaceAllFunc(cfg, func(m []byte) []byte {
+		parts := configEnvRe.FindSubmatch(m)
+		val, ok := lookup(string(parts[1]))
+		if !ok || val == "" {
+			if parts[2] == nil {
+				if !ok {
+					missing = append(missing, string(parts[1]))
+				}
+				return nil
+			}
+			val = string(parts[2])
+		}
+
+		if isYAML {
+			if strings.ContainsAny(val, "\r\n") {
+				invalid = append(invalid, string(parts[1]))
+			}
+			return []byte(val)
+		}
+
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.Encode(val)
+		j := bytes.TrimSpace(buf.Bytes())
+
+		return j[1 : len(j)-1]
+	})
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("undefined environment variables: %s", strings.Join(missing, ", "))
+	}
+
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("environment variables can not span multiple lines in YAML: %s", strings.Join(invalid, ", "))
+	}
+
+	return res, nil
+}
+
+// parseConfigValues loads template values from a JSON or YAML file and applies key=value overrides from sets
+func parseConfigValues(file string, sets []string) (map[string]any, error) {
+	values := make(map[string]any)
+
+	if file != "" {
+		vf, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		err = yaml.Unmarshal(vf, &values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid values file %s: %w", file, err)
+		}
+	}
+
+	for _, set := range sets {
+		k, v, ok := strings.Cut(set, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid value %q, expected key=value", set)
+		}
+
+		values[k] = v
+	}
+
+	return values, nil
+}
+
+// renderConfigTemplate renders body as a Go template, referencing values that are not set is an error but optional
+// values can be accessed using index and given defaults using the default function, {{ index . "x" | default 1 }}
+func renderConfigTemplate(name string, body []byte, values map[string]any) ([]byte, error) {
+	funcs := template.FuncMap{
+		"default": func(dflt any, v any) any {
+			if v == nil || v == "" {
+				return dflt
+			}
+			return v
+		},
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", name, err)
+	}
+
+	var out bytes.Buffer
+	err = tmpl.Execute(&out, values)
+	if err != nil {
+		return nil, fmt.Errorf("could not render %s: %w", name, err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// subjectLoop finds the first of subjects that would match messages published to dest, subject mapping tokens in dest are treated as wildcards
+func subjectLoop(subjects []string, dest string) (string, bool) {
+	tokens := strings.Split(dest, ".")
+	for i, token := range tokens {
+		if strings.Contains(token, "{{") || strings.HasPrefix(token, "$") {
+			tokens[i] = "*"
+		}
+	}
+	dest = strings.Join(tokens, ".")
+
+	for _, subject := range subjects {
+		if server.SubjectsCollide(subject, dest) {
+			return subject, true
+		}
+	}
+
+	return "", false
+}
+
+// configDiff compares live and desired configurations, string lists that only differ in ordering are considered equal
+func configDiff(live any, desired any) string {
+	sorter := cmp.Transformer("Sort", func(in []string) []string {
+		out := append([]string(nil), in...)
+		sort.Strings(out)
+		return out
+	})
+
+	return cmp.Diff(live, desired, sorter)
+}
+
+// printCSV writes headers followed by rows to stdout as CSV, values are rendered using their default format
+func printCSV(headers []string, rows [][]any) error {
+	return writeCSV(os.Stdout, headers, rows)
+}
+
+func writeCSV(out io.Writer, headers []string, rows [][]any) error {
+	w := csv.NewWriter(out)
+
+	err := w.Write(headers)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = fmt.Sprint(v)
+		}
+
+		err = w.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+// colorDiff colors added and removed lines of a configuration diff, color is disabled when not on a terminal
+func colorDiff(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			lines[i] = color.GreenString(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = color.RedString(line)
+		}
+	}
+
+	return strings.Join(lines, "")
+}
+
+// renderValidatePlan shows how desired differs from an existing asset and whether the server would accept the update
+func renderValidatePlan(kind string, name string, live any, desired any, rejected []string) error {
+	diff := configDiff(live, desired)
+
+	fmt.Println()
+	if diff == "" {
+		fmt.Printf("%s %s exists and matches the proposed configuration\n", kind, name)
+		return nil
+	}
+
+	fmt.Printf("%s %s exists, changes compared to the live configuration (-live +proposed):\n\n", kind, name)
+	fmt.Print(colorDiff(diff))
+	fmt.Println()
+
+	if len(rejected) > 0 {
+		fmt.Printf("Update would be %s, immutable fields changed:\n\n", color.RedString("rejected"))
+		for _, r := range rejected {
+			fmt.Printf("   %s\n", r)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("Update would be %s\n", color.GreenString("accepted"))
+
+	return nil
+}
+
 func isJsonString(s string) bool {
 	trimmed := strings.TrimSpace(s)
 	return strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}")
@@ -932,13 +1397,89 @@ func doReq(req any, subj string, waitFor int, nc *nats.Conn) ([][]byte, error) {
 	return res, err
 }
 
+// jszPageSize is the number of accounts requested in each page of JSZ results
+const jszPageSize = 1024
+
+// doJszReq requests JSZ from servers matching filter, fetching further pages from each server until all accounts it
+// hosts are received so callers see every account rather than the first page
+func doJszReq(jszOpts server.JSzOptions, filter server.EventFilterOptions, waitFor int, nc *nats.Conn) ([]*server.ServerAPIJszResponse, error) {
+	jszOpts.Offset = 0
+	if jszOpts.Limit == 0 {
+		jszOpts.Limit = jszPageSize
+	}
+
+	res, err := doReq(&server.JszEventOptions{JSzOptions: jszOpts, EventFilterOptions: filter}, "$SYS.REQ.SERVER.PING.JSZ", waitFor, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*server.ServerAPIJszResponse
+
+	for _, r := range res {
+		response := &server.ServerAPIJszResponse{}
+		err = json.Unmarshal(r, response)
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, response)
+
+		if response.Data == nil || response.Server == nil || jszOpts.Account != "" {
+			continue
+		}
+
+		page := len(response.Data.AccountDetails)
+		pageOpts := jszOpts
+
+		for page == pageOpts.Limit {
+			pageOpts.Offset += pageOpts.Limit
+
+			pres, err := doReq(&server.JszEventOptions{JSzOptions: pageOpts}, fmt.Sprintf("$SYS.REQ.SERVER.%s.JSZ", response.Server.ID), 1, nc)
+			if err != nil {
+				return nil, err
+			}
+			if len(pres) != 1 {
+				return nil, fmt.Errorf("did not receive JSZ page %d from server %s", pageOpts.Offset/pageOpts.Limit+1, response.Server.Name)
+			}
+
+			next := &server.ServerAPIJszResponse{}
+			err = json.Unmarshal(pres[0], next)
+			if err != nil {
+				return nil, err
+			}
+			if next.Error != nil {
+				return nil, fmt.Errorf("JSZ page %d from server %s failed: %s", pageOpts.Offset/pageOpts.Limit+1, response.Server.Name, next.Error.Description)
+			}
+			if next.Data == nil {
+				break
+			}
+
+			page = len(next.Data.AccountDetails)
+			response.Data.AccountDetails = append(response.Data.AccountDetails, next.Data.AccountDetails...)
+		}
+	}
+
+	return responses, nil
+}
+
+// jszTruncated determines if the account details in nfo lack streams the server reports hosting, streams are only
+// counted in the details when they were requested
+func jszTruncated(nfo *server.JSInfo) bool {
+	streams := 0
+	for _, acct := range nfo.AccountDetails {
+		streams += len(acct.Streams)
+	}
+
+	return streams < nfo.Streams
+}
+
 type raftLeader struct {
 	name    string
 	cluster string
 	groups  int
 }
 
-func renderRaftLeaders(leaders map[string]*raftLeader, grpTitle string) {
+func renderRaftLeaders(w io.Writer, leaders map[string]*raftLeader, grpTitle string) {
 	table := iu.NewTableWriter(opts(), "RAFT Leader Report")
 	table.AddHeaders("Server", "Cluster", grpTitle, "Distribution")
 
@@ -979,7 +1520,7 @@ func renderRaftLeaders(leaders map[string]*raftLeader, grpTitle string) {
 		}
 		table.AddRow(l.name, l.cluster, f(l.groups), strings.Repeat("*", dots))
 	}
-	fmt.Println(table.Render())
+	fmt.Fprintln(w, table.Render())
 }
 
 func compactStrings(source []string) []string {
@@ -1147,6 +1688,89 @@ func outPutMSGBody(data []byte, filter string, subject string, stream string) {
 	}
 }
 
+// jsonLinesMsg is a self-contained representation of a message used for JSON Lines output
+type jsonLinesMsg struct {
+	Subject  string              `json:"subject"`
+	Reply    string              `json:"reply,omitempty"`
+	Headers  map[string][]string `json:"headers,omitempty"`
+	Received time.Time           `json:"received"`
+	JS       *jsonLinesMsgMeta   `json:"jetstream,omitempty"`
+	Encoding string              `json:"encoding"`
+	Data     string              `json:"data"`
+}
+
+type jsonLinesMsgMeta struct {
+	Domain           string    `json:"domain,omitempty"`
+	Stream           string    `json:"stream"`
+	Consumer         string    `json:"consumer,omitempty"`
+	StreamSequence   uint64    `json:"stream_seq"`
+	ConsumerSequence uint64    `json:"consumer_seq,omitempty"`
+	Delivered        int       `json:"delivered,omitempty"`
+	Pending          uint64    `json:"pending"`
+	Time             time.Time `json:"time"`
+}
+
+// outPutMSGJSONL writes msg as a single line of JSON, the payload is a string when valid UTF-8 and base64 encoded otherwise
+func outPutMSGJSONL(msg *nats.Msg, filter string) error {
+	j, err := msgJSONL(msg, filter)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(j))
+
+	return nil
+}
+
+// msgJSONL encodes msg as a single line of JSON without a trailing new line, see outPutMSGJSONL
+func msgJSONL(msg *nats.Msg, filter string) ([]byte, error) {
+	jm := jsonLinesMsg{
+		Subject:  msg.Subject,
+		Reply:    msg.Reply,
+		Received: time.Now().UTC(),
+	}
+
+	if len(msg.Header) > 0 {
+		jm.Headers = msg.Header
+	}
+
+	if msg.Reply != "" {
+		info, err := jsm.ParseJSMsgMetadata(msg)
+		if err == nil && info != nil {
+			jm.JS = &jsonLinesMsgMeta{
+				Domain:           info.Domain(),
+				Stream:           info.Stream(),
+				Consumer:         info.Consumer(),
+				StreamSequence:   info.StreamSequence(),
+				ConsumerSequence: info.ConsumerSequence(),
+				Delivered:        info.Delivered(),
+				Pending:          info.Pending(),
+				Time:             info.TimeStamp().UTC(),
+			}
+		}
+	}
+
+	var stream string
+	if jm.JS != nil {
+		stream = jm.JS.Stream
+	}
+
+	data, err := filterDataThroughCmd(msg.Data, filter, msg.Subject, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	if utf8.Valid(data) {
+		jm.Encoding = "string"
+		jm.Data = string(data)
+	} else {
+		jm.Encoding = "base64"
+		jm.Data = base64.StdEncoding.EncodeToString(data)
+	}
+
+	return json.Marshal(jm)
+}
+
 func filterDataThroughCmd(data []byte, filter, subject, stream string) ([]byte, error) {
 	if filter == "" {
 		return data, nil
@@ -1180,6 +1804,75 @@ func filterDataThroughCmd(data []byte, filter, subject, stream string) ([]byte,
 	return runner.CombinedOutput()
 }
 
+// warnServerFeature warns when the connected server is older than the version that introduced a feature
+func warnServerFeature(nc *nats.Conn, feature string, major, minor, patch int) {
+	if nc == nil || iu.ServerMinVersion(nc, major, minor, patch) {
+		return
+	}
+
+	log.Printf("WARNING: %s requires NATS Server %d.%d.%d, the connected server %s might ignore it", feature, major, minor, patch, nc.ConnectedServerVersion())
+}
+
+// payloadDecoders are the built in decoders that can be applied to message data before display
+var payloadDecoders = []string{"base64", "gzip", "zstd", "s2", "hex"}
+
+// decodePayload passes data through each of the decoders in order
+func decodePayload(data []byte, decoders []string) ([]byte, error) {
+	var err error
+
+	for _, decoder := range decoders {
+		switch decoder {
+		case "base64":
+			data, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		case "gzip":
+			var r *gzip.Reader
+			r, err = gzip.NewReader(bytes.NewReader(data))
+			if err == nil {
+				data, err = io.ReadAll(r)
+			}
+		case "zstd":
+			var r *zstd.Decoder
+			r, err = zstd.NewReader(nil)
+			if err == nil {
+				data, err = r.DecodeAll(data, nil)
+				r.Close()
+			}
+		case "s2":
+			data, err = io.ReadAll(s2.NewReader(bytes.NewReader(data)))
+		case "hex":
+			data = []byte(hex.Dump(data))
+		default:
+			err = fmt.Errorf("unknown decoder %q", decoder)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s decoding failed: %w", decoder, err)
+		}
+	}
+
+	return data, nil
+}
+
+// decodedMsg returns a copy of msg with its data decoded for display, msg is returned unchanged when decoding fails
+func decodedMsg(msg *nats.Msg, decoders []string) *nats.Msg {
+	if len(decoders) == 0 {
+		return msg
+	}
+
+	data, err := decodePayload(msg.Data, decoders)
+	if err != nil {
+		log.Printf("Could not decode message on %s: %v", msg.Subject, err)
+		return msg
+	}
+
+	dmsg := nats.NewMsg(msg.Subject)
+	dmsg.Reply = msg.Reply
+	dmsg.Header = msg.Header
+	dmsg.Data = data
+
+	return dmsg
+}
+
 // currentActiveServers determines how many servers the connected server knows about
 func currentActiveServers(nc *nats.Conn) (int, error) {
 	var expect int
@@ -14,11 +14,18 @@
 package cli
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/nats-server/v2/server"
 )
 
 func TestParseStringAsBytes(t *testing.T) {
@@ -166,3 +173,208 @@ func TestHostnameCompactor(t *testing.T) {
 		t.Fatalf("Recevied %#v", result)
 	}
 }
+
+func TestRenderConfigTemplate(t *testing.T) {
+	values, err := parseConfigValues("", []string{"replicas=3", "name=ORDERS"})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+
+	out, err := renderConfigTemplate("t", []byte(`{"name":"{{ .name }}","num_replicas":{{ .replicas }},"max_bytes":{{ index . "max_bytes" | default -1 }}}`), values)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	expect := `{"name":"ORDERS","num_replicas":3,"max_bytes":-1}`
+	if string(out) != expect {
+		t.Fatalf("expected %s got %s", expect, out)
+	}
+
+	_, err = renderConfigTemplate("t", []byte(`{"name":"{{ .missing }}"}`), values)
+	if err == nil {
+		t.Fatalf("expected an error for unset values")
+	}
+
+	_, err = parseConfigValues("", []string{"replicas"})
+	if err == nil {
+		t.Fatalf("expected an error for invalid values")
+	}
+}
+
+func TestReadConfigFileTransforms(t *testing.T) {
+	cfg := `{"name":"ORDERS","subject_transform":{"src":"orders.*","dest":"orders.{{wildcard(1)}}"}}`
+	file := filepath.Join(t.TempDir(), "orders.json")
+	err := os.WriteFile(file, []byte(cfg), 0600)
+	if err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	res, err := readConfigFile(file, "", nil, false)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if string(res) != cfg {
+		t.Fatalf("expected %s got %s", cfg, res)
+	}
+}
+
+func TestSubjectLoop(t *testing.T) {
+	cases := []struct {
+		subjects []string
+		dest     string
+		subject  string
+		loop     bool
+	}{
+		{subjects: []string{"orders.>"}, dest: "orders.new", subject: "orders.>", loop: true},
+		{subjects: []string{"orders.*"}, dest: "archive.orders", loop: false},
+		{subjects: []string{"js.in.*", "orders.*.*"}, dest: "orders.{{wildcard(1)}}.new", subject: "orders.*.*", loop: true},
+		{subjects: []string{"orders.*"}, dest: "orders.$1.new", loop: false},
+		{subjects: []string{"orders.*"}, dest: "orders.$1", subject: "orders.*", loop: true},
+	}
+
+	for _, tc := range cases {
+		subject, loop := subjectLoop(tc.subjects, tc.dest)
+		if loop != tc.loop || subject != tc.subject {
+			t.Fatalf("expected %v %q for %q got %v %q", tc.loop, tc.subject, tc.dest, loop, subject)
+		}
+	}
+}
+
+func TestDecodePayload(t *testing.T) {
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte("hello world"))
+	w.Close()
+
+	encoded := base64.StdEncoding.EncodeToString(gz.Bytes())
+
+	res, err := decodePayload([]byte(encoded), []string{"base64", "gzip"})
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if string(res) != "hello world" {
+		t.Fatalf("expected hello world got %q", res)
+	}
+
+	res, err = decodePayload([]byte("hello"), []string{"hex"})
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if string(res) != hex.Dump([]byte("hello")) {
+		t.Fatalf("unexpected hex dump %q", res)
+	}
+
+	_, err = decodePayload([]byte("hello world"), []string{"gzip"})
+	if err == nil {
+		t.Fatalf("expected an error decoding invalid gzip data")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	err := writeCSV(buf, []string{"Name", "Messages", "Filter"}, [][]any{
+		{"ORDERS", uint64(10), "orders.new"},
+		{"a,b", 1, ""},
+	})
+	if err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	expected := "Name,Messages,Filter\nORDERS,10,orders.new\n\"a,b\",1,\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q got %q", expected, buf.String())
+	}
+}
+
+func TestExpandConfigEnv(t *testing.T) {
+	env := map[string]string{"NAME": "ORDERS", "QUOTED": `a "b"`, "EMPTY": "", "SUBJ": "orders.>"}
+	lookup := func(k string) (string, bool) {
+		v, ok := env[k]
+		return v, ok
+	}
+
+	res, err := expandConfigEnv([]byte(`{"name":"${NAME}","description":"${QUOTED}","subjects":["$KV.>","${SUBJ}"],"max_msgs":${MAX:-10},"x":"${EMPTY:-y}"}`), false, lookup)
+	if err != nil {
+		t.Fatalf("expand failed: %v", err)
+	}
+
+	expected := `{"name":"ORDERS","description":"a \"b\"","subjects":["$KV.>","orders.>"],"max_msgs":10,"x":"y"}`
+	if string(res) != expected {
+		t.Fatalf("expected %s got %s", expected, res)
+	}
+
+	res, err = expandConfigEnv([]byte("name: ${NAME}\nsubjects:\n  - ${SUBJ}\n"), true, lookup)
+	if err != nil {
+		t.Fatalf("expand failed: %v", err)
+	}
+
+	expected = "name: ORDERS\nsubjects:\n  - orders.>\n"
+	if string(res) != expected {
+		t.Fatalf("expected %q got %q", expected, res)
+	}
+
+	if !isYAMLConfig("-", res) || isYAMLConfig("-", []byte(` {"name":"ORDERS"}`)) {
+		t.Fatalf("invalid format detection")
+	}
+
+	_, err = expandConfigEnv([]byte(`{"name":"${MISSING}"}`), false, lookup)
+	if err == nil || err.Error() != "undefined environment variables: MISSING" {
+		t.Fatalf("expected undefined variable error got %v", err)
+	}
+}
+
+func TestMetadataMatches(t *testing.T) {
+	meta := map[string]string{"team": "payments", "env": "prod"}
+
+	if !metadataMatches(meta, nil) {
+		t.Fatalf("expected empty selectors to match")
+	}
+	if !metadataMatches(nil, map[string]string{}) {
+		t.Fatalf("expected empty selectors to match nil metadata")
+	}
+	if !metadataMatches(meta, map[string]string{"team": "payments", "env": "prod"}) {
+		t.Fatalf("expected all selectors to match")
+	}
+	if metadataMatches(meta, map[string]string{"team": "payments", "env": "dev"}) {
+		t.Fatalf("expected mismatched value to not match")
+	}
+	if metadataMatches(meta, map[string]string{"owner": "x"}) {
+		t.Fatalf("expected missing key to not match")
+	}
+	if metadataMatches(nil, map[string]string{"team": "payments"}) {
+		t.Fatalf("expected nil metadata to not match")
+	}
+}
+
+func TestJszTruncated(t *testing.T) {
+	nfo := &server.JSInfo{
+		AccountDetails: []*server.AccountDetail{
+			{Name: "A", Streams: []server.StreamDetail{{Name: "S1"}, {Name: "S2"}}},
+			{Name: "B", Streams: []server.StreamDetail{{Name: "S1"}}},
+		},
+	}
+
+	nfo.Streams = 3
+	if jszTruncated(nfo) {
+		t.Fatalf("expected complete details")
+	}
+
+	nfo.Streams = 4
+	if !jszTruncated(nfo) {
+		t.Fatalf("expected truncated details")
+	}
+}
+
+func TestIsLongRunningCommand(t *testing.T) {
+	for _, cmd := range []string{"subscribe", "consumer sub", "server watch js", "kv watch", "server check exporter"} {
+		if !isLongRunningCommand(cmd) {
+			t.Fatalf("expected %q to be long-running", cmd)
+		}
+	}
+
+	for _, cmd := range []string{"stream info", "kv get", "server check stream", "consumer subscribe", "request"} {
+		if isLongRunningCommand(cmd) {
+			t.Fatalf("expected %q to not be long-running", cmd)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/jsm.go/api"
+	iu "github.com/nats-io/natscli/internal/util"
+)
+
+const (
+	// validationOff disables all schema validation
+	validationOff = "off"
+	// validationResponses validates configurations before submission and JetStream API responses, the default
+	validationResponses = "responses"
+	// validationStrict also validates every request the CLI builds before sending it
+	validationStrict = "strict"
+)
+
+var (
+	contextValidationOnce sync.Once
+	contextValidation     string
+)
+
+// apiMessage is a JetStream API message that can be validated against its schema
+type apiMessage interface {
+	Validate(...api.StructValidator) (bool, []string)
+	SchemaType() string
+}
+
+// validationLevel determines the schema validation level using --strict, the NOVALIDATE environment variable or the selected context
+func validationLevel() string {
+	if opts().StrictValidation {
+		return validationStrict
+	}
+
+	if os.Getenv("NOVALIDATE") != "" {
+		return validationOff
+	}
+
+	level := contextValidationLevel()
+	if level != "" {
+		return level
+	}
+
+	return validationResponses
+}
+
+// contextValidationLevel is the validation level configured for the selected context, the configuration is only loaded once
+func contextValidationLevel() string {
+	contextValidationOnce.Do(func() {
+		name := selectedContextName()
+		if name == "" {
+			return
+		}
+
+		cfg, err := iu.LoadConfig()
+		if err == nil {
+			contextValidation = cfg.ValidationLevels[name]
+		}
+	})
+
+	return contextValidation
+}
+
+// validateRequest validates a request the CLI builds itself before it is sent when strict validation is enabled
+func validateRequest(req apiMessage) error {
+	if validationLevel() != validationStrict {
+		return nil
+	}
+
+	ok, errs := req.Validate(new(SchemaValidator))
+	if ok {
+		return nil
+	}
+
+	return schemaMismatchError("request", req.SchemaType(), errs)
+}
+
+// schemaMismatchError reports every schema violation on its own line so mismatches between CLI and server versions stand out
+func schemaMismatchError(kind string, schemaType string, errs []string) error {
+	return fmt.Errorf("schema mismatch: %s is not a valid %q message:\n\t%s", kind, schemaType, strings.Join(errs, "\n\t"))
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/nats-io/jsm.go/api"
+	"github.com/nats-io/natscli/options"
+)
+
+func TestValidationLevel(t *testing.T) {
+	prev := options.DefaultOptions
+	defer func() { options.DefaultOptions = prev }()
+
+	options.DefaultOptions = &options.Options{StrictValidation: true}
+	t.Setenv("NOVALIDATE", "1")
+	if level := validationLevel(); level != validationStrict {
+		t.Fatalf("expected strict validation got %q", level)
+	}
+
+	err := validateRequest(api.JSApiMsgGetRequest{Seq: 1})
+	if err != nil {
+		t.Fatalf("valid request failed: %v", err)
+	}
+
+	options.DefaultOptions = &options.Options{}
+	if level := validationLevel(); level != validationOff {
+		t.Fatalf("expected validation to be off got %q", level)
+	}
+
+	err = schemaMismatchError("request", "io.nats.test", []string{"a", "b"})
+	expected := "schema mismatch: request is not a valid \"io.nats.test\" message:\n\ta\n\tb"
+	if err.Error() != expected {
+		t.Fatalf("expected %q got %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StateExporter polls Stream and Consumer state on an interval and exposes the most recent values as Prometheus metrics
+type StateExporter struct {
+	ns       string
+	mgr      *jsm.Manager
+	streams  []string
+	interval time.Duration
+
+	streamMessages      *prometheus.Desc
+	streamBytes         *prometheus.Desc
+	streamLastSeq       *prometheus.Desc
+	streamConsumers     *prometheus.Desc
+	streamReplicas      *prometheus.Desc
+	streamCurrent       *prometheus.Desc
+	streamHealthy       *prometheus.Desc
+	consumerPending     *prometheus.Desc
+	consumerAckPending  *prometheus.Desc
+	consumerRedelivered *prometheus.Desc
+	consumerWaiting     *prometheus.Desc
+	consumerLag         *prometheus.Desc
+	consumerHealthy     *prometheus.Desc
+	pollDuration        *prometheus.Desc
+	pollErrors          *prometheus.Desc
+
+	metrics []prometheus.Metric
+	errors  float64
+	mu      sync.Mutex
+}
+
+// NewStateExporter creates a new exporter for the given streams, all streams are exported when none are given
+func NewStateExporter(ns string, mgr *jsm.Manager, streams []string, interval time.Duration) *StateExporter {
+	if ns == "" {
+		ns = "natscli"
+	}
+
+	streamLabels := []string{"stream"}
+	consumerLabels := []string{"stream", "consumer"}
+
+	desc := func(name string, help string, labels []string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(ns, "", name), help, labels, nil)
+	}
+
+	return &StateExporter{
+		ns:       ns,
+		mgr:      mgr,
+		streams:  streams,
+		interval: interval,
+
+		streamMessages:      desc("stream_messages", "Number of messages stored in the Stream", streamLabels),
+		streamBytes:         desc("stream_bytes", "Number of bytes stored in the Stream", streamLabels),
+		streamLastSeq:       desc("stream_last_sequence", "The last sequence stored in the Stream", streamLabels),
+		streamConsumers:     desc("stream_consumers", "Number of Consumers on the Stream", streamLabels),
+		streamReplicas:      desc("stream_replicas", "Number of configured replicas for the Stream", streamLabels),
+		streamCurrent:       desc("stream_replicas_current", "Number of replicas, including the leader, that are current", streamLabels),
+		streamHealthy:       desc("stream_cluster_healthy", "1 when the Stream has a leader and all replicas are current", streamLabels),
+		consumerPending:     desc("consumer_pending", "Number of messages not yet delivered by the Consumer", consumerLabels),
+		consumerAckPending:  desc("consumer_ack_pending", "Number of messages awaiting acknowledgement", consumerLabels),
+		consumerRedelivered: desc("consumer_redelivered", "Number of messages that were redelivered", consumerLabels),
+		consumerWaiting:     desc("consumer_waiting", "Number of outstanding pull requests", consumerLabels),
+		consumerLag:         desc("consumer_lag", "Number of Stream sequences between the Stream last sequence and the Consumer ack floor", consumerLabels),
+		consumerHealthy:     desc("consumer_cluster_healthy", "1 when the Consumer has a leader and all replicas are current", consumerLabels),
+		pollDuration:        desc("state_poll_duration_seconds", "Time taken to gather the most recent state", nil),
+		pollErrors:          desc("state_poll_errors_total", "Number of times gathering state failed", nil),
+	}
+}
+
+// Run polls state until ctx is canceled
+func (e *StateExporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.poll()
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (e *StateExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.streamMessages
+	ch <- e.streamBytes
+	ch <- e.streamLastSeq
+	ch <- e.streamConsumers
+	ch <- e.streamReplicas
+	ch <- e.streamCurrent
+	ch <- e.streamHealthy
+	ch <- e.consumerPending
+	ch <- e.consumerAckPending
+	ch <- e.consumerRedelivered
+	ch <- e.consumerWaiting
+	ch <- e.consumerLag
+	ch <- e.consumerHealthy
+	ch <- e.pollDuration
+	ch <- e.pollErrors
+}
+
+// Collect implements prometheus.Collector
+func (e *StateExporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, m := range e.metrics {
+		ch <- m
+	}
+
+	ch <- prometheus.MustNewConstMetric(e.pollErrors, prometheus.CounterValue, e.errors)
+}
+
+func (e *StateExporter) poll() {
+	start := time.Now()
+
+	var metrics []prometheus.Metric
+	gauge := func(d *prometheus.Desc, v float64, labels ...string) {
+		metrics = append(metrics, prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v, labels...))
+	}
+
+	names := e.streams
+	if len(names) == 0 {
+		var err error
+		names, err = e.mgr.StreamNames(nil)
+		if err != nil {
+			log.Printf("Could not list Streams: %v", err)
+			e.pollFailed()
+			return
+		}
+	}
+
+	failed := false
+	for _, name := range names {
+		stream, err := e.mgr.LoadStream(name)
+		if err != nil {
+			log.Printf("Could not load Stream %s: %v", name, err)
+			failed = true
+			continue
+		}
+
+		info, err := stream.LatestInformation()
+		if err != nil {
+			log.Printf("Could not load Stream %s information: %v", name, err)
+			failed = true
+			continue
+		}
+
+		current, healthy := clusterHealth(info.Cluster, info.Config.Replicas)
+
+		gauge(e.streamMessages, float64(info.State.Msgs), name)
+		gauge(e.streamBytes, float64(info.State.Bytes), name)
+		gauge(e.streamLastSeq, float64(info.State.LastSeq), name)
+		gauge(e.streamConsumers, float64(info.State.Consumers), name)
+		gauge(e.streamReplicas, float64(info.Config.Replicas), name)
+		gauge(e.streamCurrent, float64(current), name)
+		gauge(e.streamHealthy, healthy, name)
+
+		_, err = stream.EachConsumer(func(consumer *jsm.Consumer) {
+			state, err := consumer.LatestState()
+			if err != nil {
+				log.Printf("Could not load Consumer %s > %s state: %v", name, consumer.Name(), err)
+				failed = true
+				return
+			}
+
+			var lag uint64
+			if info.State.LastSeq > state.AckFloor.Stream {
+				lag = info.State.LastSeq - state.AckFloor.Stream
+			}
+
+			replicas := state.Config.Replicas
+			if replicas == 0 {
+				replicas = info.Config.Replicas
+			}
+			_, healthy := clusterHealth(state.Cluster, replicas)
+
+			gauge(e.consumerPending, float64(state.NumPending), name, state.Name)
+			gauge(e.consumerAckPending, float64(state.NumAckPending), name, state.Name)
+			gauge(e.consumerRedelivered, float64(state.NumRedelivered), name, state.Name)
+			gauge(e.consumerWaiting, float64(state.NumWaiting), name, state.Name)
+			gauge(e.consumerLag, float64(lag), name, state.Name)
+			gauge(e.consumerHealthy, healthy, name, state.Name)
+		})
+		if err != nil {
+			log.Printf("Could not load Consumers for Stream %s: %v", name, err)
+			failed = true
+		}
+	}
+
+	gauge(e.pollDuration, time.Since(start).Seconds())
+
+	e.mu.Lock()
+	e.metrics = metrics
+	if failed {
+		e.errors++
+	}
+	e.mu.Unlock()
+}
+
+func (e *StateExporter) pollFailed() {
+	e.mu.Lock()
+	e.errors++
+	e.mu.Unlock()
+}
+
+// clusterHealth determines how many peers are current and if the group is healthy
+func clusterHealth(ci *api.ClusterInfo, replicas int) (int, float64) {
+	if ci == nil {
+		return 1, 1
+	}
+
+	if ci.Leader == "" {
+		return 0, 0
+	}
+
+	current := 1
+	for _, peer := range ci.Replicas {
+		if peer.Current && !peer.Offline {
+			current++
+		}
+	}
+
+	if current < replicas {
+		return current, 0
+	}
+
+	return current, 1
+}
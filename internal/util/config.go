@@ -22,7 +22,18 @@ import (
 )
 
 type Config struct {
-	SelectedOperator string `json:"select_operator"`
+	SelectedOperator string            `json:"select_operator"`
+	Aliases          map[string]string `json:"aliases,omitempty"`
+	// ConsumerNameTemplates holds consumer naming conventions keyed by context name
+	ConsumerNameTemplates map[string]string `json:"consumer_name_templates,omitempty"`
+	// ReadOnlyContexts lists contexts that refuse to modify JetStream assets
+	ReadOnlyContexts map[string]bool `json:"read_only_contexts,omitempty"`
+	// ValidationLevels holds the JetStream API schema validation level keyed by context name
+	ValidationLevels map[string]string `json:"validation_levels,omitempty"`
+	// CredentialHelpers holds a command that supplies credentials or a token when connecting keyed by context name
+	CredentialHelpers map[string]string `json:"credential_helpers,omitempty"`
+	// AuditFile is a file that records of changes made to JetStream assets are appended to
+	AuditFile string `json:"audit_file,omitempty"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -105,6 +105,24 @@ func ServerMinVersion(nc *nats.Conn, major, minor, patch int) bool {
 	return true
 }
 
+// RequireServerVersion asserts that the connected server is at least version, given as major.minor.patch or major.minor
+func RequireServerVersion(nc *nats.Conn, version string) error {
+	if strings.Count(version, ".") == 1 {
+		version = version + ".0"
+	}
+
+	major, minor, patch, err := versionComponents(version)
+	if err != nil {
+		return fmt.Errorf("invalid server version %q: %w", version, err)
+	}
+
+	if !ServerMinVersion(nc, major, minor, patch) {
+		return fmt.Errorf("the connected server version %s does not meet the required version %s", nc.ConnectedServerVersion(), version)
+	}
+
+	return nil
+}
+
 // ToJSON converts any to json string
 func ToJSON(d any) (string, error) {
 	j, err := json.MarshalIndent(d, "", "  ")
@@ -117,12 +135,17 @@ func ToJSON(d any) (string, error) {
 
 // PrintJSON prints any to stdout as json
 func PrintJSON(d any) error {
+	return FprintJSON(os.Stdout, d)
+}
+
+// FprintJSON writes d as indented JSON to w
+func FprintJSON(w io.Writer, d any) error {
 	j, err := json.MarshalIndent(d, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(string(j))
+	fmt.Fprintln(w, string(j))
 
 	return nil
 }
@@ -244,6 +267,10 @@ func AskOne(p survey.Prompt, response any, opts ...survey.AskOpt) error {
 		return fmt.Errorf("cannot prompt for user input without a terminal")
 	}
 
+	if options.DefaultOptions != nil && options.DefaultOptions.NoPrompt {
+		return fmt.Errorf("cannot prompt for user input, interactive prompts are disabled by --no-prompt")
+	}
+
 	return survey.AskOne(p, response, append(SurveyColors(), opts...)...)
 }
 
@@ -14,11 +14,13 @@
 package main
 
 import (
+	"errors"
 	iu "github.com/nats-io/natscli/internal/util"
 	"log"
 	"os"
 	"runtime"
 	"runtime/debug"
+	"strconv"
 
 	"github.com/choria-io/fisk"
 	"github.com/nats-io/natscli/plugins"
@@ -36,6 +38,7 @@ NATS Server and JetStream administration.
 See 'nats cheat' for a quick cheatsheet of commands`
 
 	ncli := fisk.New("nats", help)
+	ncli.Terminate(cli.Exit)
 	ncli.Author("NATS Authors <info@nats.io>")
 	ncli.UsageWriter(os.Stdout)
 	ncli.Version(getVersion())
@@ -51,7 +54,8 @@ See 'nats cheat' for a quick cheatsheet of commands`
 	ncli.Flag("server", "NATS server urls").Short('s').Envar("NATS_URL").PlaceHolder("URL").StringVar(&opts.Servers)
 	ncli.Flag("user", "Username or Token").Envar("NATS_USER").PlaceHolder("USER").StringVar(&opts.Username)
 	ncli.Flag("password", "Password").Envar("NATS_PASSWORD").PlaceHolder("PASSWORD").StringVar(&opts.Password)
-	ncli.Flag("connection-name", "Nickname to use for the underlying NATS Connection").Default("NATS CLI Version " + getVersion()).PlaceHolder("NAME").StringVar(&opts.ConnectionName)
+	ncli.Flag("connection-name", "Nickname to use for the underlying NATS Connection, defaults to user@host/nats-cli/command").PlaceHolder("NAME").StringVar(&opts.ConnectionName)
+	ncli.Flag("conn-name", "Nickname to use for the underlying NATS Connection").PlaceHolder("NAME").Hidden().StringVar(&opts.ConnectionName)
 	ncli.Flag("creds", "User credentials").Envar("NATS_CREDS").PlaceHolder("FILE").StringVar(&opts.Creds)
 	ncli.Flag("nkey", "User NKEY").Envar("NATS_NKEY").PlaceHolder("FILE").StringVar(&opts.Nkey)
 	ncli.Flag("tlscert", "TLS public certificate").Envar("NATS_CERT").PlaceHolder("FILE").ExistingFileVar(&opts.TlsCert)
@@ -65,6 +69,10 @@ See 'nats cheat' for a quick cheatsheet of commands`
 		ncli.Flag("certstore-ca-match", "Which certificate authority should be used from the store").StringsVar(&opts.WinCertCaStoreMatch)
 	}
 	ncli.Flag("timeout", "Time to wait on responses from NATS").Default("5s").Envar("NATS_TIMEOUT").PlaceHolder("DURATION").DurationVar(&opts.Timeout)
+	ncli.Flag("reconnect", "Reconnect when the connection to the server is lost").Default("true").Envar("NATS_RECONNECT").SetValue(&negatedBool{&opts.NoReconnect})
+	ncli.Flag("max-reconnects", "Maximum number of reconnect attempts, -1 for unlimited, defaults to unlimited for long-running commands").Envar("NATS_MAX_RECONNECTS").PlaceHolder("N").IntVar(&opts.MaxReconnects)
+	ncli.Flag("reconnect-wait", "Time to wait between reconnect attempts").Default("2s").Envar("NATS_RECONNECT_WAIT").PlaceHolder("DURATION").DurationVar(&opts.ReconnectWait)
+	ncli.Flag("inject-latency", "Delays network reads and writes to simulate slow links").Hidden().PlaceHolder("read=DURATION,write=DURATION").StringVar(&opts.InjectLatency)
 	ncli.Flag("socks-proxy", "SOCKS5 proxy for connecting to NATS server").Envar("NATS_SOCKS_PROXY").PlaceHolder("PROXY").StringVar(&opts.SocksProxy)
 	ncli.Flag("js-api-prefix", "Subject prefix for access to JetStream API").PlaceHolder("PREFIX").StringVar(&opts.JsApiPrefix)
 	ncli.Flag("js-event-prefix", "Subject prefix for access to JetStream Advisories").PlaceHolder("PREFIX").StringVar(&opts.JsEventPrefix)
@@ -74,15 +82,82 @@ See 'nats cheat' for a quick cheatsheet of commands`
 	ncli.Flag("colors", "Sets a color scheme to use").PlaceHolder("SCHEME").Envar("NATS_COLOR").EnumVar(&opts.ColorScheme, iu.ValidStyles()...)
 	ncli.Flag("context", "Configuration context").Envar("NATS_CONTEXT").PlaceHolder("NAME").StringVar(&opts.CfgCtx)
 	ncli.Flag("trace", "Trace API interactions").UnNegatableBoolVar(&opts.Trace)
+	ncli.Flag("trace-sample", "Only show 1 in N traced API interactions").Envar("NATS_TRACE_SAMPLE").PlaceHolder("N").IntVar(&opts.TraceSample)
+	ncli.Flag("expect-version", "Fail unless the connected server is at least this version").Envar("NATS_EXPECT_VERSION").PlaceHolder("VERSION").StringVar(&opts.ExpectVersion)
+	ncli.Flag("no-pager", "Disables paging of long output").Envar("NATS_NO_PAGER").UnNegatableBoolVar(&opts.NoPager)
+	ncli.Flag("no-prompt", "Fail rather than prompt for missing input").Envar("NATS_NO_PROMPT").UnNegatableBoolVar(&opts.NoPrompt)
+	ncli.Flag("read-only", "Refuse requests that modify JetStream assets, acknowledge messages or write to Key-Value and Object stores").Envar("NATS_READ_ONLY").UnNegatableBoolVar(&opts.ReadOnly)
+	ncli.Flag("audit-file", "Appends a record of every request that changes JetStream assets, Key-Value or Object stores to a file").Envar("NATS_AUDIT_FILE").PlaceHolder("FILE").StringVar(&opts.AuditFile)
+	ncli.Flag("strict", "Validate JetStream API requests and responses against their schemas").Envar("NATS_STRICT").UnNegatableBoolVar(&opts.StrictValidation)
+	ncli.Flag("pedantic", "Ask the server to reject JetStream API requests instead of adjusting them").Envar("NATS_PEDANTIC").UnNegatableBoolVar(&opts.PedanticRequests)
 	ncli.Flag("no-context", "Disable the selected context").UnNegatableBoolVar(&cli.SkipContexts)
 
 	log.SetFlags(log.Ltime)
 
 	plugins.AddToApp(ncli)
 
-	ncli.MustParseWithUsage(os.Args[1:])
+	args := cli.ExpandAliases(ncli, os.Args[1:])
+
+	_, err = ncli.Parse(args)
+	if err != nil {
+		exitWithError(ncli, args, err)
+	}
+
+	cli.Exit(0)
 }
 
+// usageErrors are the parse errors that are reported along with usage information
+var usageErrors = []error{
+	fisk.ErrSubCommandRequired, fisk.ErrExpectedKnownCommand, fisk.ErrRequiredArgument, fisk.ErrRequiredFlag,
+	fisk.ErrUnknownLongFlag, fisk.ErrUnknownShortFlag, fisk.ErrExpectedFlagArgument, fisk.ErrFlagCannotRepeat,
+	fisk.ErrUnexpectedArgument, fisk.ErrDuplicateCommand,
+}
+
+// exitWithError exits with the code requested by a command or reports err like fisk.MustParseWithUsage
+func exitWithError(app *fisk.Application, args []string, err error) {
+	if errors.Is(err, cli.ErrDifferencesFound) {
+		cli.Exit(1)
+	}
+
+	var exitErr *cli.ExitCodeError
+	if errors.As(err, &exitErr) {
+		app.Errorf("%v", exitErr)
+		cli.Exit(exitErr.Code)
+	}
+
+	for _, uerr := range usageErrors {
+		if errors.Is(err, uerr) {
+			pc, _ := app.ParseContext(args)
+			app.FatalUsageContext(pc, "%v, use --help for full help including flags and arguments", err)
+		}
+	}
+
+	app.Fatalf("%v", err)
+}
+
+// negatedBool is a negatable boolean flag that sets the option it points to to the opposite value, letting a flag
+// default to true while the option keeps a false zero value
+type negatedBool struct {
+	v *bool
+}
+
+func (n *negatedBool) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+
+	*n.v = !b
+
+	return nil
+}
+
+func (n *negatedBool) String() string { return strconv.FormatBool(!*n.v) }
+
+func (n *negatedBool) IsBoolFlag() bool { return true }
+
+func (n *negatedBool) BoolFlagIsNegatable() bool { return true }
+
 func getVersion() string {
 	if version != "development" {
 		return version
@@ -84,4 +84,29 @@ type Options struct {
 	WinCertStoreMatch string
 	// WinCertCaStoreMatch is the queries for CAs to use
 	WinCertCaStoreMatch []string
+	// ExpectVersion is the minimum version the connected server must have
+	ExpectVersion string
+	// TraceSample shows only 1 in every TraceSample traced API interactions
+	TraceSample int
+	// NoPager disables paging of long output
+	NoPager bool
+	// NoPrompt disables all interactive prompts, commands fail instead of asking for missing input
+	NoPrompt bool
+	// ReadOnly refuses to run commands that modify JetStream assets
+	ReadOnly bool
+	// AuditFile is a file that records of changes made to JetStream assets are appended to
+	AuditFile string
+	// StrictValidation validates JetStream API requests and responses against their schemas
+	StrictValidation bool
+	// PedanticRequests asks the server to reject JetStream API requests rather than adjusting them
+	PedanticRequests bool
+	// NoReconnect disables reconnecting after the connection to the server is lost
+	NoReconnect bool
+	// MaxReconnects is the maximum number of reconnect attempts, -1 for unlimited and 0 for unlimited in long-running
+	// commands and the nats.go default in others
+	MaxReconnects int
+	// ReconnectWait is how long to wait between reconnect attempts
+	ReconnectWait time.Duration
+	// InjectLatency delays network reads and writes for testing, like read=50ms,write=20ms
+	InjectLatency string
 }
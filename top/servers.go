@@ -0,0 +1,368 @@
+// Copyright 2025 The NATS Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	ui "gopkg.in/gizak/termui.v1"
+)
+
+// ServerSortOpts are the valid sort options for the servers view
+var ServerSortOpts = []string{"name", "conns", "subs", "cpu", "mem", "slow", "msgs_in", "msgs_out", "bytes_in", "bytes_out"}
+
+// ServersEngine polls VARZ from all servers and calculates rates between polls
+type ServersEngine struct {
+	Nc         *nats.Conn
+	Delay      int
+	SortOpt    string
+	Reverse    bool
+	Trace      bool
+	StatsCh    chan *ServersStats
+	ShutdownCh chan struct{}
+	last       map[string]*server.Varz
+}
+
+// ServersStats represents the monitored data from all NATS servers
+type ServersStats struct {
+	Servers []*ServerStats
+	Error   error
+}
+
+// ServerStats represents the monitored data and rates for a single server
+type ServerStats struct {
+	Varz  *server.Varz
+	Rates *Rates
+}
+
+func NewServersEngine(nc *nats.Conn, delay int, sortOpt string, trace bool) *ServersEngine {
+	return &ServersEngine{
+		Nc:         nc,
+		Delay:      delay,
+		SortOpt:    sortOpt,
+		Trace:      trace,
+		StatsCh:    make(chan *ServersStats),
+		ShutdownCh: make(chan struct{}),
+		last:       make(map[string]*server.Varz),
+	}
+}
+
+// MonitorStats is ran as a goroutine and sends polled values to the stats channel
+func (e *ServersEngine) MonitorStats() {
+	e.StatsCh <- e.fetchStats()
+
+	ticker := time.NewTicker(time.Duration(e.Delay) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.ShutdownCh:
+			return
+		case <-ticker.C:
+			e.StatsCh <- e.fetchStats()
+		}
+	}
+}
+
+// FetchStatsSnapshot polls all servers once, rates are not calculated
+func (e *ServersEngine) FetchStatsSnapshot() *ServersStats {
+	return e.fetchStats()
+}
+
+func (e *ServersEngine) fetchStats() *ServersStats {
+	stats := &ServersStats{}
+
+	varzs, err := e.requestAll()
+	if err != nil {
+		stats.Error = err
+		return stats
+	}
+
+	current := make(map[string]*server.Varz)
+	for _, varz := range varzs {
+		current[varz.ID] = varz
+		srv := &ServerStats{Varz: varz, Rates: &Rates{}}
+
+		last, ok := e.last[varz.ID]
+		if ok {
+			tdelta := varz.Now.Sub(last.Now).Seconds()
+			if tdelta > 0 {
+				srv.Rates.InMsgsRate = float64(varz.InMsgs-last.InMsgs) / tdelta
+				srv.Rates.OutMsgsRate = float64(varz.OutMsgs-last.OutMsgs) / tdelta
+				srv.Rates.InBytesRate = float64(varz.InBytes-last.InBytes) / tdelta
+				srv.Rates.OutBytesRate = float64(varz.OutBytes-last.OutBytes) / tdelta
+			}
+		}
+
+		stats.Servers = append(stats.Servers, srv)
+	}
+	e.last = current
+
+	return stats
+}
+
+func (e *ServersEngine) requestAll() ([]*server.Varz, error) {
+	subj := "$SYS.REQ.SERVER.PING.VARZ"
+	inbox := e.Nc.NewRespInbox()
+
+	sub, err := e.Nc.SubscribeSync(inbox)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	if e.Trace {
+		log.Printf(">>> %s", subj)
+	}
+
+	err = e.Nc.PublishRequest(subj, inbox, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*server.Varz
+	timeout := time.Second
+
+	for {
+		msg, err := sub.NextMsg(timeout)
+		if errors.Is(err, nats.ErrTimeout) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// once servers start responding the rest are not far behind
+		timeout = 300 * time.Millisecond
+
+		if e.Trace {
+			log.Printf("<<< (%dB) %s", len(msg.Data), string(msg.Data))
+		}
+
+		out := &serverAPIResponse{}
+		err = json.Unmarshal(msg.Data, out)
+		if err != nil || out.Error != nil {
+			continue
+		}
+
+		varz := &server.Varz{}
+		err = json.Unmarshal(out.Data, varz)
+		if err != nil {
+			continue
+		}
+
+		res = append(res, varz)
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("no results received, ensure the account used has system privileges and appropriate permissions")
+	}
+
+	return res, nil
+}
+
+func (e *ServersEngine) nextSort() {
+	for i, opt := range ServerSortOpts {
+		if opt == e.SortOpt {
+			e.SortOpt = ServerSortOpts[(i+1)%len(ServerSortOpts)]
+			return
+		}
+	}
+
+	e.SortOpt = ServerSortOpts[0]
+}
+
+func (e *ServersEngine) sort(servers []*ServerStats) {
+	less := func(i, j int) bool {
+		vi, vj := servers[i].Varz, servers[j].Varz
+		ri, rj := servers[i].Rates, servers[j].Rates
+
+		switch e.SortOpt {
+		case "conns":
+			return vi.Connections > vj.Connections
+		case "subs":
+			return vi.Subscriptions > vj.Subscriptions
+		case "cpu":
+			return vi.CPU > vj.CPU
+		case "mem":
+			return vi.Mem > vj.Mem
+		case "slow":
+			return vi.SlowConsumers > vj.SlowConsumers
+		case "msgs_in":
+			return ri.InMsgsRate > rj.InMsgsRate
+		case "msgs_out":
+			return ri.OutMsgsRate > rj.OutMsgsRate
+		case "bytes_in":
+			return ri.InBytesRate > rj.InBytesRate
+		case "bytes_out":
+			return ri.OutBytesRate > rj.OutBytesRate
+		default:
+			return vi.Name < vj.Name
+		}
+	}
+
+	sort.SliceStable(servers, func(i, j int) bool {
+		if e.Reverse {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
+
+// SaveServersSnapshotToFile writes a single poll of all servers to a file, - writes to STDOUT
+func SaveServersSnapshotToFile(engine *ServersEngine, outputFile string) error {
+	stats := engine.FetchStatsSnapshot()
+	if stats.Error != nil {
+		return stats.Error
+	}
+
+	text := generateServersParagraph(engine, stats, false)
+
+	if outputFile == "-" {
+		fmt.Print(text)
+		return nil
+	}
+
+	return os.WriteFile(outputFile, []byte(text), 0600)
+}
+
+func generateServersParagraph(engine *ServersEngine, stats *ServersStats, rawBytes bool) string {
+	engine.sort(stats.Servers)
+
+	var (
+		conns     int
+		slow      int64
+		inMsgs    float64
+		outMsgs   float64
+		inBytes   float64
+		outBytes  float64
+		nameWidth = len("SERVER")
+		clusWidth = len("CLUSTER")
+	)
+
+	for _, srv := range stats.Servers {
+		conns += srv.Varz.Connections
+		slow += srv.Varz.SlowConsumers
+		inMsgs += srv.Rates.InMsgsRate
+		outMsgs += srv.Rates.OutMsgsRate
+		inBytes += srv.Rates.InBytesRate
+		outBytes += srv.Rates.OutBytesRate
+		nameWidth = max(nameWidth, len(srv.Varz.Name))
+		clusWidth = max(clusWidth, len(srv.Varz.Cluster.Name))
+	}
+
+	order := "descending"
+	if (engine.SortOpt == "name") != engine.Reverse {
+		order = "ascending"
+	}
+
+	var text strings.Builder
+	errText := ""
+	if stats.Error != nil {
+		errText = stats.Error.Error()
+	}
+
+	fmt.Fprintf(&text, "NATS Servers: %d  Sort: %s %s  %s\n", len(stats.Servers), engine.SortOpt, order, errText)
+	fmt.Fprintf(&text, "  Load: Connections: %d  Slow Consumers: %d\n", conns, slow)
+	fmt.Fprintf(&text, "  In:   Msgs/Sec: %.1f  Bytes/Sec: %s\n", inMsgs, Psize(rawBytes, int64(inBytes)))
+	fmt.Fprintf(&text, "  Out:  Msgs/Sec: %.1f  Bytes/Sec: %s\n\n", outMsgs, Psize(rawBytes, int64(outBytes)))
+
+	rowFmt := fmt.Sprintf("%%-%ds  %%-%ds  %%-8s  %%-6s  %%-8s  %%-8s  %%-8s  %%-6s  %%-10s  %%-10s  %%-10s  %%-10s\n", nameWidth, clusWidth)
+
+	fmt.Fprintf(&text, rowFmt, "SERVER", "CLUSTER", "VERSION", "CPU", "MEM", "CONNS", "SUBS", "SLOW", "MSGS_IN/S", "MSGS_OUT/S", "BYTES_IN/S", "BYTES_OUT/S")
+
+	for _, srv := range stats.Servers {
+		v := srv.Varz
+		fmt.Fprintf(&text, rowFmt,
+			v.Name,
+			v.Cluster.Name,
+			v.Version,
+			fmt.Sprintf("%.1f", v.CPU),
+			Psize(false, v.Mem),
+			Nsize(rawBytes, int64(v.Connections)),
+			Nsize(rawBytes, int64(v.Subscriptions)),
+			Nsize(rawBytes, v.SlowConsumers),
+			fmt.Sprintf("%.1f", srv.Rates.InMsgsRate),
+			fmt.Sprintf("%.1f", srv.Rates.OutMsgsRate),
+			Psize(rawBytes, int64(srv.Rates.InBytesRate)),
+			Psize(rawBytes, int64(srv.Rates.OutBytesRate)),
+		)
+	}
+
+	text.WriteString("\no: next sort column  r: reverse sort  b: raw numbers  q: quit\n")
+
+	return text.String()
+}
+
+// StartServersUI periodically refreshes the screen showing all servers
+func StartServersUI(engine *ServersEngine, rawBytes bool, maxRefresh int) {
+	stats := &ServersStats{}
+
+	par := ui.NewPar(generateServersParagraph(engine, stats, rawBytes))
+	par.Height = ui.TermHeight()
+	par.Width = ui.TermWidth()
+	par.HasBorder = false
+
+	ui.Body.Rows = ui.NewGrid(ui.NewRow(ui.NewCol(ui.TermWidth(), 0, par))).Rows
+	ui.Body.Align()
+	ui.Render(ui.Body)
+
+	evt := ui.EventCh()
+	refreshes := 0
+
+	for {
+		select {
+		case stats = <-engine.StatsCh:
+			refreshes++
+
+		case e := <-evt:
+			if e.Type == ui.EventKey {
+				switch {
+				case e.Ch == 'q' || e.Key == ui.KeyCtrlC:
+					close(engine.ShutdownCh)
+					cleanExit()
+				case e.Ch == 'o':
+					engine.nextSort()
+				case e.Ch == 'r':
+					engine.Reverse = !engine.Reverse
+				case e.Ch == 'b':
+					rawBytes = !rawBytes
+				}
+			}
+
+			if e.Type == ui.EventResize {
+				par.Height = ui.TermHeight()
+				ui.Body.Width = ui.TermWidth()
+				ui.Body.Align()
+			}
+		}
+
+		par.Text = generateServersParagraph(engine, stats, rawBytes)
+		ui.Render(ui.Body)
+
+		if maxRefresh > 0 && refreshes >= maxRefresh {
+			close(engine.ShutdownCh)
+			cleanExit()
+		}
+	}
+}